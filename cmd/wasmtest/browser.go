@@ -0,0 +1,214 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// browserPage loads wasm_exec.js and runs the test binary. Output and the
+// exit code are posted back with synchronous requests, so that they arrive in
+// order and before the page is closed.
+const browserPage = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>wasmtest</title>
+<script src="/wasm_exec.js"></script>
+<script>
+"use strict";
+
+function post(path, body) {
+	const xhr = new XMLHttpRequest();
+	xhr.open("POST", path, false);
+	xhr.send(body);
+}
+
+globalThis.fs.writeSync = (fd, buf) => {
+	post("/write?fd=" + fd, buf);
+	return buf.length;
+};
+
+(async () => {
+	const go = new Go();
+	const config = await (await fetch("/config")).json();
+	go.argv = config.argv;
+	go.env = config.env;
+	go.exit = (code) => post("/exit?code=" + code);
+	const result = await WebAssembly.instantiateStreaming(fetch("/test.wasm"), go.importObject);
+	await go.run(result.instance);
+})().catch((err) => {
+	post("/write?fd=2", new TextEncoder().encode(String(err && err.stack || err) + "\n"));
+	post("/exit?code=1");
+});
+</script>
+</head>
+<body></body>
+</html>
+`
+
+// browserEnv are the environment variables passed to the tests in the browser.
+// They are the ones read by the Go runtime; the rest of the host environment,
+// which may hold credentials, is not handed to the page.
+var browserEnv = []string{"GODEBUG", "GOGC", "GOMAXPROCS", "GOMEMLIMIT", "GOTRACEBACK"}
+
+// defaultTimeout is the timeout of go test, used when the test binary is run
+// without -test.timeout
+const defaultTimeout = 10 * time.Minute
+
+// startupGrace is the time allowed on top of the test timeout for starting
+// the browser and loading the binary. It is shorter than the minute go test
+// waits before killing wasmtest, so that the browser is killed first.
+const startupGrace = 30 * time.Second
+
+// runBrowser runs a wasm binary in a page of headless Chrome, so that the
+// tests use the DOM and storage of a real browser. The browser is killed if
+// the tests do not finish within their timeout, for example because the page
+// failed to load.
+func runBrowser(browser, binary, wasmExec string, args []string) (int, error) {
+	config, err := json.Marshal(map[string]interface{}{
+		"argv": append([]string{"test.wasm"}, args...),
+		"env":  environ(),
+	})
+	if err != nil {
+		return 1, err
+	}
+
+	exit := make(chan int, 1)
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		io.WriteString(w, browserPage)
+	})
+	mux.HandleFunc("/wasm_exec.js", func(w http.ResponseWriter, r *http.Request) {
+		http.ServeFile(w, r, wasmExec)
+	})
+	mux.HandleFunc("/test.wasm", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/wasm")
+		http.ServeFile(w, r, binary)
+	})
+	mux.HandleFunc("/config", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(config)
+	})
+	mux.HandleFunc("/write", func(w http.ResponseWriter, r *http.Request) {
+		out := os.Stdout
+		if r.URL.Query().Get("fd") == "2" {
+			out = os.Stderr
+		}
+		io.Copy(out, r.Body)
+	})
+	mux.HandleFunc("/exit", func(w http.ResponseWriter, r *http.Request) {
+		code, err := strconv.Atoi(r.URL.Query().Get("code"))
+		if err != nil {
+			code = 1
+		}
+		select {
+		case exit <- code:
+		default:
+		}
+	})
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return 1, err
+	}
+	server := &http.Server{Handler: mux}
+	go server.Serve(listener)
+	defer server.Close()
+
+	profile, err := os.MkdirTemp("", "wasmtest-browser")
+	if err != nil {
+		return 1, err
+	}
+	defer os.RemoveAll(profile)
+	browserArgs := []string{
+		"--headless",
+		"--disable-gpu",
+		"--no-first-run",
+		"--no-default-browser-check",
+		"--user-data-dir=" + profile,
+	}
+	if os.Geteuid() == 0 {
+		// Chrome refuses to run as root with its sandbox enabled
+		browserArgs = append(browserArgs, "--no-sandbox")
+	}
+	url := fmt.Sprintf("http://%s/", listener.Addr())
+	cmd := exec.Command(browser, append(browserArgs, url)...)
+	if err := cmd.Start(); err != nil {
+		return 1, err
+	}
+	exited := make(chan error, 1)
+	go func() {
+		exited <- cmd.Wait()
+	}()
+
+	var deadline <-chan time.Time
+	timeout := browserTimeout(args)
+	if timeout > 0 {
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+		deadline = timer.C
+	}
+
+	select {
+	case code := <-exit:
+		cmd.Process.Kill()
+		<-exited
+		return code, nil
+	case err := <-exited:
+		return 1, fmt.Errorf("%s exited before the tests finished: %v", browser, err)
+	case <-deadline:
+		cmd.Process.Kill()
+		<-exited
+		return 1, fmt.Errorf("the tests did not finish in %s within %v", browser, timeout)
+	}
+}
+
+// browserTimeout returns how long to wait for the tests: their -test.timeout
+// plus startupGrace, or zero if the timeout is disabled with -test.timeout=0
+func browserTimeout(args []string) time.Duration {
+	timeout := defaultTimeout
+	for i, arg := range args {
+		var value string
+		switch {
+		case strings.HasPrefix(arg, "-test.timeout="):
+			value = strings.TrimPrefix(arg, "-test.timeout=")
+		case arg == "-test.timeout" && i+1 < len(args):
+			value = args[i+1]
+		default:
+			continue
+		}
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			continue
+		}
+		timeout = d
+	}
+	if timeout <= 0 {
+		return 0
+	}
+	return timeout + startupGrace
+}
+
+// environ returns the environment passed to the tests in the browser
+func environ() map[string]string {
+	env := make(map[string]string)
+	for _, name := range browserEnv {
+		if value, ok := os.LookupEnv(name); ok {
+			env[name] = value
+		}
+	}
+	return env
+}
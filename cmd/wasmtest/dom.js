@@ -0,0 +1,1710 @@
+// A lightweight DOM for running the dom package tests under Node.js.
+// It implements the node tree, elements and attributes, inline styles,
+// class lists, simple selectors and event dispatch with capture and bubble
+// phases. Layout is not implemented: sizes and positions are always zero.
+//
+// Keep it small and shaped like the spec: every interface and member here
+// follows its definition in the DOM standard, so that code passing against it
+// behaves the same in a browser. Nothing beyond this core is emulated. Tests
+// of other APIs, such as forms, canvas, SVG geometry and the window, skip
+// themselves when the API is missing and run in headless Chrome instead;
+// should one of them need to run under Node.js, install a polyfill for it
+// from runner.js, like storage.js and indexeddb.js, rather than growing this
+// file.
+
+"use strict";
+
+const ELEMENT_NODE = 1;
+const TEXT_NODE = 3;
+const COMMENT_NODE = 8;
+const DOCUMENT_NODE = 9;
+const DOCUMENT_FRAGMENT_NODE = 11;
+
+const XHTML_NAMESPACE = "http://www.w3.org/1999/xhtml";
+const SVG_NAMESPACE = "http://www.w3.org/2000/svg";
+const MATHML_NAMESPACE = "http://www.w3.org/1998/Math/MathML";
+const XML_NAMESPACE = "http://www.w3.org/XML/1998/namespace";
+const XMLNS_NAMESPACE = "http://www.w3.org/2000/xmlns/";
+
+const VOID_ELEMENTS = new Set(["area", "base", "br", "col", "embed", "hr", "img", "input", "link", "meta", "source", "track", "wbr"]);
+
+function domException(message, name) {
+	return new DOMException(message, name);
+}
+
+// validateQualifiedName splits a qualified name into its prefix and local
+// name, throwing if it is invalid or its prefix does not fit the namespace.
+function validateQualifiedName(namespace, qualifiedName) {
+	if (!/^[^\s"'>/=:]+(:[^\s"'>/=:]+)?$/.test(qualifiedName)) {
+		throw domException(`The qualified name provided ('${qualifiedName}') is not a valid name.`, "InvalidCharacterError");
+	}
+	const i = qualifiedName.indexOf(":");
+	const prefix = i < 0 ? null : qualifiedName.slice(0, i);
+	const localName = qualifiedName.slice(i + 1);
+	if ((prefix !== null && namespace === null) ||
+		(prefix === "xml" && namespace !== XML_NAMESPACE) ||
+		((qualifiedName === "xmlns" || prefix === "xmlns") !== (namespace === XMLNS_NAMESPACE))) {
+		throw domException(`The namespace '${namespace}' is not valid for the qualified name '${qualifiedName}'.`, "NamespaceError");
+	}
+	return [prefix, localName];
+}
+
+// namespaceArg converts null, undefined and the empty string to null
+function namespaceArg(namespace) {
+	return namespace == null || namespace === "" ? null : String(namespace);
+}
+
+// Events
+
+class Event {
+	constructor(type, init = {}) {
+		if (arguments.length === 0) {
+			throw new TypeError("Failed to construct 'Event': 1 argument required, but only 0 present.");
+		}
+		this.type = String(type);
+		this.bubbles = !!init.bubbles;
+		this.cancelable = !!init.cancelable;
+		this.composed = !!init.composed;
+		this.target = null;
+		this.currentTarget = null;
+		this.eventPhase = Event.NONE;
+		this.defaultPrevented = false;
+		this.isTrusted = false;
+		this.timeStamp = performance.now();
+		this._path = [];
+		this._stop = false;
+		this._stopImmediate = false;
+		this._passive = false;
+	}
+
+	get srcElement() {
+		return this.target;
+	}
+
+	get cancelBubble() {
+		return this._stop;
+	}
+
+	set cancelBubble(value) {
+		if (value) {
+			this._stop = true;
+		}
+	}
+
+	stopPropagation() {
+		this._stop = true;
+	}
+
+	stopImmediatePropagation() {
+		this._stop = true;
+		this._stopImmediate = true;
+	}
+
+	preventDefault() {
+		if (this.cancelable && !this._passive) {
+			this.defaultPrevented = true;
+		}
+	}
+
+	composedPath() {
+		return this.eventPhase === Event.NONE ? [] : this._path.slice();
+	}
+}
+Event.NONE = 0;
+Event.CAPTURING_PHASE = 1;
+Event.AT_TARGET = 2;
+Event.BUBBLING_PHASE = 3;
+
+class UIEvent extends Event {
+	constructor(type, init = {}) {
+		super(type, init);
+		this.view = init.view ?? null;
+		this.detail = init.detail ?? 0;
+	}
+}
+
+class MouseEvent extends UIEvent {
+	constructor(type, init = {}) {
+		super(type, init);
+		this.screenX = init.screenX ?? 0;
+		this.screenY = init.screenY ?? 0;
+		this.clientX = init.clientX ?? 0;
+		this.clientY = init.clientY ?? 0;
+		this.movementX = init.movementX ?? 0;
+		this.movementY = init.movementY ?? 0;
+		this.ctrlKey = !!init.ctrlKey;
+		this.shiftKey = !!init.shiftKey;
+		this.altKey = !!init.altKey;
+		this.metaKey = !!init.metaKey;
+		this.button = init.button ?? 0;
+		this.buttons = init.buttons ?? 0;
+		this.relatedTarget = init.relatedTarget ?? null;
+	}
+
+	get x() { return this.clientX; }
+	get y() { return this.clientY; }
+	get pageX() { return this.clientX; }
+	get pageY() { return this.clientY; }
+	get offsetX() { return this.clientX; }
+	get offsetY() { return this.clientY; }
+
+	getModifierState(key) {
+		return getModifierState(this, key);
+	}
+}
+
+class PointerEvent extends MouseEvent {
+	constructor(type, init = {}) {
+		super(type, init);
+		this.pointerId = init.pointerId ?? 0;
+		this.width = init.width ?? 1;
+		this.height = init.height ?? 1;
+		this.pressure = init.pressure ?? 0;
+		this.tangentialPressure = init.tangentialPressure ?? 0;
+		this.tiltX = init.tiltX ?? 0;
+		this.tiltY = init.tiltY ?? 0;
+		this.twist = init.twist ?? 0;
+		this.pointerType = init.pointerType ?? "";
+		this.isPrimary = !!init.isPrimary;
+	}
+}
+
+class WheelEvent extends MouseEvent {
+	constructor(type, init = {}) {
+		super(type, init);
+		this.deltaX = init.deltaX ?? 0;
+		this.deltaY = init.deltaY ?? 0;
+		this.deltaZ = init.deltaZ ?? 0;
+		this.deltaMode = init.deltaMode ?? 0;
+	}
+}
+WheelEvent.DOM_DELTA_PIXEL = 0;
+WheelEvent.DOM_DELTA_LINE = 1;
+WheelEvent.DOM_DELTA_PAGE = 2;
+
+class FocusEvent extends UIEvent {
+	constructor(type, init = {}) {
+		super(type, init);
+		this.relatedTarget = init.relatedTarget ?? null;
+	}
+}
+
+class KeyboardEvent extends UIEvent {
+	constructor(type, init = {}) {
+		super(type, init);
+		this.key = init.key ?? "";
+		this.code = init.code ?? "";
+		this.location = init.location ?? 0;
+		this.repeat = !!init.repeat;
+		this.isComposing = !!init.isComposing;
+		this.ctrlKey = !!init.ctrlKey;
+		this.shiftKey = !!init.shiftKey;
+		this.altKey = !!init.altKey;
+		this.metaKey = !!init.metaKey;
+	}
+
+	getModifierState(key) {
+		return getModifierState(this, key);
+	}
+}
+
+class InputEvent extends UIEvent {
+	constructor(type, init = {}) {
+		super(type, init);
+		this.data = init.data ?? null;
+		this.inputType = init.inputType ?? "";
+		this.isComposing = !!init.isComposing;
+	}
+}
+
+class Touch {
+	constructor(init) {
+		if (!init || init.target == null) {
+			throw new TypeError("Failed to construct 'Touch': required member target is undefined.");
+		}
+		this.identifier = Number(init.identifier);
+		this.target = init.target;
+		for (const name of ["clientX", "clientY", "screenX", "screenY", "pageX", "pageY", "radiusX", "radiusY", "rotationAngle", "force"]) {
+			this[name] = init[name] ?? 0;
+		}
+	}
+}
+
+class TouchEvent extends UIEvent {
+	constructor(type, init = {}) {
+		super(type, init);
+		this.touches = Array.from(init.touches ?? []);
+		this.targetTouches = Array.from(init.targetTouches ?? []);
+		this.changedTouches = Array.from(init.changedTouches ?? []);
+		this.ctrlKey = !!init.ctrlKey;
+		this.shiftKey = !!init.shiftKey;
+		this.altKey = !!init.altKey;
+		this.metaKey = !!init.metaKey;
+	}
+}
+
+function getModifierState(event, key) {
+	const keys = { Alt: event.altKey, Control: event.ctrlKey, Meta: event.metaKey, Shift: event.shiftKey };
+	return !!keys[key];
+}
+
+class CustomEvent extends Event {
+	constructor(type, init = {}) {
+		super(type, init);
+		this.detail = init.detail ?? null;
+	}
+}
+
+class PageTransitionEvent extends Event {
+	constructor(type, init = {}) {
+		super(type, init);
+		this.persisted = !!init.persisted;
+	}
+}
+
+function listenerOptions(options) {
+	if (typeof options === "boolean") {
+		return { capture: options };
+	}
+	return options ?? {};
+}
+
+class EventTarget {
+	constructor() {
+		this._listeners = [];
+	}
+
+	addEventListener(type, callback, options) {
+		if (callback == null) {
+			return;
+		}
+		options = listenerOptions(options);
+		const listener = {
+			type: String(type),
+			callback,
+			capture: !!options.capture,
+			once: !!options.once,
+			passive: !!options.passive,
+			removed: false,
+		};
+		const signal = options.signal;
+		if (signal && signal.aborted) {
+			return;
+		}
+		if (this._listeners.some((l) => l.type === listener.type && l.callback === callback && l.capture === listener.capture)) {
+			return;
+		}
+		this._listeners.push(listener);
+		if (signal) {
+			signal.addEventListener("abort", () => this.removeEventListener(listener.type, callback, listener.capture));
+		}
+	}
+
+	removeEventListener(type, callback, options) {
+		const capture = !!listenerOptions(options).capture;
+		const i = this._listeners.findIndex((l) => l.type === String(type) && l.callback === callback && l.capture === capture);
+		if (i >= 0) {
+			this._listeners[i].removed = true;
+			this._listeners.splice(i, 1);
+		}
+	}
+
+	dispatchEvent(event) {
+		if (!(event instanceof Event)) {
+			throw new TypeError("Failed to execute 'dispatchEvent' on 'EventTarget': parameter 1 is not of type 'Event'.");
+		}
+		const path = [];
+		for (let t = this; t; t = t._parentTarget()) {
+			path.push(t);
+		}
+		event.target = this;
+		event._path = path;
+		event._stop = false;
+		event._stopImmediate = false;
+
+		event.eventPhase = Event.CAPTURING_PHASE;
+		for (let i = path.length - 1; i > 0 && !event._stop; i--) {
+			path[i]._invoke(event, true);
+		}
+		event.eventPhase = Event.AT_TARGET;
+		if (!event._stop) {
+			this._invoke(event, null);
+		}
+		event.eventPhase = Event.BUBBLING_PHASE;
+		for (let i = 1; i < path.length && event.bubbles && !event._stop; i++) {
+			path[i]._invoke(event, false);
+		}
+
+		event.eventPhase = Event.NONE;
+		event.currentTarget = null;
+		return !event.defaultPrevented;
+	}
+
+	_parentTarget() {
+		return null;
+	}
+
+	// _invoke calls the listeners for the event's type. A capture of null
+	// calls both capturing and bubbling listeners, as at the target.
+	_invoke(event, capture) {
+		event.currentTarget = this;
+		for (const l of this._listeners.slice()) {
+			if (l.removed || l.type !== event.type || (capture !== null && l.capture !== capture)) {
+				continue;
+			}
+			if (l.once) {
+				this.removeEventListener(l.type, l.callback, l.capture);
+			}
+			event._passive = l.passive;
+			try {
+				if (typeof l.callback === "function") {
+					l.callback.call(this, event);
+				} else {
+					l.callback.handleEvent(event);
+				}
+			} catch (err) {
+				console.error(err);
+			}
+			event._passive = false;
+			if (event._stopImmediate) {
+				break;
+			}
+		}
+	}
+}
+
+// Node lists
+
+class NodeList extends Array {
+	static from(nodes) {
+		return Object.setPrototypeOf(nodes.slice(), NodeList.prototype);
+	}
+
+	item(i) {
+		return this[i] ?? null;
+	}
+}
+
+class HTMLCollection extends NodeList {
+	namedItem(name) {
+		return this.find((e) => e.id === name || e.getAttribute("name") === name) ?? null;
+	}
+}
+
+// NamedNodeMap is the live, array-like but not Array, list of attributes
+// returned by Element.attributes
+class NamedNodeMap {
+	constructor(element) {
+		this._element = element;
+		return new Proxy(this, {
+			get(target, name, receiver) {
+				if (typeof name === "string" && /^\d+$/.test(name)) {
+					return target.item(Number(name)) ?? undefined;
+				}
+				return Reflect.get(target, name, receiver);
+			},
+		});
+	}
+
+	get length() {
+		return this._element._attributes.length;
+	}
+
+	_attr(a) {
+		return a ? { ...a, ownerElement: this._element } : null;
+	}
+
+	item(i) {
+		return this._attr(this._element._attributes[i]);
+	}
+
+	getNamedItem(name) {
+		return this._attr(this._element._attributes.find((a) => a.name === this._element._attributeName(name)));
+	}
+
+	getNamedItemNS(namespace, localName) {
+		namespace = namespaceArg(namespace);
+		return this._attr(this._element._attributes.find((a) => a.namespaceURI === namespace && a.localName === String(localName)));
+	}
+
+	*[Symbol.iterator]() {
+		for (let i = 0; i < this.length; i++) {
+			yield this.item(i);
+		}
+	}
+}
+
+// Nodes
+
+class Node extends EventTarget {
+	constructor(ownerDocument, nodeType, nodeName) {
+		super();
+		this.ownerDocument = ownerDocument;
+		this.nodeType = nodeType;
+		this.nodeName = nodeName;
+		this.parentNode = null;
+		this._children = [];
+	}
+
+	_parentTarget() {
+		if (this.parentNode) {
+			return this.parentNode;
+		}
+		return this.nodeType === DOCUMENT_NODE ? globalThis : null;
+	}
+
+	get baseURI() {
+		return "about:blank";
+	}
+
+	get isConnected() {
+		return this.getRootNode().nodeType === DOCUMENT_NODE;
+	}
+
+	get childNodes() {
+		return NodeList.from(this._children);
+	}
+
+	get parentElement() {
+		return this.parentNode && this.parentNode.nodeType === ELEMENT_NODE ? this.parentNode : null;
+	}
+
+	get firstChild() {
+		return this._children[0] ?? null;
+	}
+
+	get lastChild() {
+		return this._children[this._children.length - 1] ?? null;
+	}
+
+	get previousSibling() {
+		if (!this.parentNode) {
+			return null;
+		}
+		const siblings = this.parentNode._children;
+		return siblings[siblings.indexOf(this) - 1] ?? null;
+	}
+
+	get nextSibling() {
+		if (!this.parentNode) {
+			return null;
+		}
+		const siblings = this.parentNode._children;
+		return siblings[siblings.indexOf(this) + 1] ?? null;
+	}
+
+	get nodeValue() {
+		return null;
+	}
+
+	set nodeValue(value) {}
+
+	get textContent() {
+		let text = "";
+		for (const c of this._children) {
+			if (c.nodeType === TEXT_NODE || c.nodeType === ELEMENT_NODE || c.nodeType === DOCUMENT_FRAGMENT_NODE) {
+				text += c.textContent;
+			}
+		}
+		return text;
+	}
+
+	set textContent(value) {
+		this._replaceChildren(value == null || value === "" ? [] : [this._document().createTextNode(String(value))]);
+	}
+
+	_document() {
+		return this.ownerDocument ?? this;
+	}
+
+	getRootNode() {
+		let n = this;
+		while (n.parentNode) {
+			n = n.parentNode;
+		}
+		return n;
+	}
+
+	hasChildNodes() {
+		return this._children.length > 0;
+	}
+
+	contains(other) {
+		for (let n = other; n; n = n.parentNode) {
+			if (n === this) {
+				return true;
+			}
+		}
+		return false;
+	}
+
+	isSameNode(other) {
+		return this === other;
+	}
+
+	isEqualNode(other) {
+		if (!other || other.nodeType !== this.nodeType || other.nodeName !== this.nodeName || other.nodeValue !== this.nodeValue) {
+			return false;
+		}
+		if (this.nodeType === ELEMENT_NODE) {
+			if (this._attributes.length !== other._attributes.length) {
+				return false;
+			}
+			if (other.namespaceURI !== this.namespaceURI || this._attributes.some((a) => other.getAttributeNS(a.namespaceURI, a.localName) !== a.value)) {
+				return false;
+			}
+		}
+		if (this._children.length !== other._children.length) {
+			return false;
+		}
+		return this._children.every((c, i) => c.isEqualNode(other._children[i]));
+	}
+
+	compareDocumentPosition(other) {
+		if (other === this) {
+			return 0;
+		}
+		if (this.getRootNode() !== other.getRootNode()) {
+			return Node.DOCUMENT_POSITION_DISCONNECTED | Node.DOCUMENT_POSITION_IMPLEMENTATION_SPECIFIC | Node.DOCUMENT_POSITION_FOLLOWING;
+		}
+		if (this.contains(other)) {
+			return Node.DOCUMENT_POSITION_CONTAINED_BY | Node.DOCUMENT_POSITION_FOLLOWING;
+		}
+		if (other.contains(this)) {
+			return Node.DOCUMENT_POSITION_CONTAINS | Node.DOCUMENT_POSITION_PRECEDING;
+		}
+		const order = [];
+		walk(this.getRootNode(), (n) => { order.push(n); });
+		return order.indexOf(other) < order.indexOf(this) ? Node.DOCUMENT_POSITION_PRECEDING : Node.DOCUMENT_POSITION_FOLLOWING;
+	}
+
+	lookupPrefix(namespace) {
+		return null;
+	}
+
+	lookupNamespaceURI(prefix) {
+		prefix = prefix || null;
+		for (let e = this.nodeType === DOCUMENT_NODE ? this.documentElement : this; e && e.nodeType === ELEMENT_NODE; e = e.parentNode) {
+			if (e.namespaceURI !== null && e.prefix === prefix) {
+				return e.namespaceURI;
+			}
+			const value = e.getAttribute(prefix === null ? "xmlns" : `xmlns:${prefix}`);
+			if (value !== null) {
+				return value || null;
+			}
+		}
+		return null;
+	}
+
+	isDefaultNamespace(namespace) {
+		return this.lookupNamespaceURI(null) === (namespace || null);
+	}
+
+	normalize() {
+		for (const c of this._children.slice()) {
+			if (c.nodeType !== TEXT_NODE) {
+				c.normalize();
+				continue;
+			}
+			const prev = c.previousSibling;
+			if (c.data === "") {
+				this.removeChild(c);
+			} else if (prev && prev.nodeType === TEXT_NODE) {
+				prev.data += c.data;
+				this.removeChild(c);
+			}
+		}
+	}
+
+	appendChild(node) {
+		return this.insertBefore(node, null);
+	}
+
+	insertBefore(node, child) {
+		if (!(node instanceof Node)) {
+			throw new TypeError("Failed to execute 'insertBefore' on 'Node': parameter 1 is not of type 'Node'.");
+		}
+		if (child != null && child.parentNode !== this) {
+			throw domException("The node before which the new node is to be inserted is not a child of this node.", "NotFoundError");
+		}
+		if (node.contains(this)) {
+			throw domException("The new child element contains the parent.", "HierarchyRequestError");
+		}
+		if (node.nodeType === DOCUMENT_NODE || (this.nodeType !== ELEMENT_NODE && this.nodeType !== DOCUMENT_NODE && this.nodeType !== DOCUMENT_FRAGMENT_NODE)) {
+			throw domException("Nodes of this type may not be inserted here.", "HierarchyRequestError");
+		}
+		if (node === child) {
+			return node;
+		}
+		const nodes = node.nodeType === DOCUMENT_FRAGMENT_NODE ? node._children.slice() : [node];
+		for (const n of nodes) {
+			if (n.parentNode) {
+				n.parentNode.removeChild(n);
+			}
+		}
+		const i = child == null ? this._children.length : this._children.indexOf(child);
+		this._children.splice(i, 0, ...nodes);
+		for (const n of nodes) {
+			n.parentNode = this;
+			adopt(n, this._document());
+		}
+		return node;
+	}
+
+	removeChild(child) {
+		const i = this._children.indexOf(child);
+		if (i < 0) {
+			throw domException("The node to be removed is not a child of this node.", "NotFoundError");
+		}
+		const doc = this._document();
+		if (doc._focused && child.contains(doc._focused)) {
+			doc._focused = null;
+		}
+		this._children.splice(i, 1);
+		child.parentNode = null;
+		return child;
+	}
+
+	replaceChild(node, child) {
+		if (!child || child.parentNode !== this) {
+			throw domException("The node to be replaced is not a child of this node.", "NotFoundError");
+		}
+		if (node !== child) {
+			this.insertBefore(node, child);
+			this.removeChild(child);
+		}
+		return child;
+	}
+
+	cloneNode(deep = false) {
+		const clone = this._clone();
+		if (deep) {
+			for (const c of this._children) {
+				clone.appendChild(c.cloneNode(true));
+			}
+		}
+		return clone;
+	}
+
+	_replaceChildren(nodes) {
+		for (const c of this._children.slice()) {
+			this.removeChild(c);
+		}
+		for (const n of nodes) {
+			this.appendChild(n);
+		}
+	}
+
+	// ParentNode mixin, used by elements, documents and fragments
+
+	get children() {
+		return Object.setPrototypeOf(this._children.filter((c) => c.nodeType === ELEMENT_NODE), HTMLCollection.prototype);
+	}
+
+	get childElementCount() {
+		return this.children.length;
+	}
+
+	get firstElementChild() {
+		return this.children[0] ?? null;
+	}
+
+	get lastElementChild() {
+		const children = this.children;
+		return children[children.length - 1] ?? null;
+	}
+
+	append(...nodes) {
+		for (const n of nodes) {
+			this.appendChild(typeof n === "string" ? this._document().createTextNode(n) : n);
+		}
+	}
+
+	prepend(...nodes) {
+		const first = this.firstChild;
+		for (const n of nodes) {
+			this.insertBefore(typeof n === "string" ? this._document().createTextNode(n) : n, first);
+		}
+	}
+
+	querySelector(selectors) {
+		return this.querySelectorAll(selectors)[0] ?? null;
+	}
+
+	querySelectorAll(selectors) {
+		const selector = parseSelector(selectors);
+		const found = [];
+		walk(this, (n) => {
+			if (n !== this && n.nodeType === ELEMENT_NODE && matchesSelector(n, selector)) {
+				found.push(n);
+			}
+		});
+		return NodeList.from(found);
+	}
+
+	getElementsByTagName(name) {
+		name = String(name).toLowerCase();
+		return this._descendants((e) => name === "*" || e.localName === name);
+	}
+
+	getElementsByClassName(names) {
+		const wanted = String(names).split(/\s+/).filter(Boolean);
+		return this._descendants((e) => wanted.length > 0 && wanted.every((c) => e.classList.contains(c)));
+	}
+
+	_descendants(test) {
+		const found = [];
+		walk(this, (n) => {
+			if (n !== this && n.nodeType === ELEMENT_NODE && test(n)) {
+				found.push(n);
+			}
+		});
+		return Object.setPrototypeOf(found, HTMLCollection.prototype);
+	}
+}
+Node.ELEMENT_NODE = ELEMENT_NODE;
+Node.TEXT_NODE = TEXT_NODE;
+Node.COMMENT_NODE = COMMENT_NODE;
+Node.DOCUMENT_NODE = DOCUMENT_NODE;
+Node.DOCUMENT_FRAGMENT_NODE = DOCUMENT_FRAGMENT_NODE;
+Node.DOCUMENT_POSITION_DISCONNECTED = 0x01;
+Node.DOCUMENT_POSITION_PRECEDING = 0x02;
+Node.DOCUMENT_POSITION_FOLLOWING = 0x04;
+Node.DOCUMENT_POSITION_CONTAINS = 0x08;
+Node.DOCUMENT_POSITION_CONTAINED_BY = 0x10;
+Node.DOCUMENT_POSITION_IMPLEMENTATION_SPECIFIC = 0x20;
+
+// walk calls fn for n and its descendants in tree order
+function walk(n, fn) {
+	fn(n);
+	for (const c of n._children) {
+		walk(c, fn);
+	}
+}
+
+function adopt(n, doc) {
+	walk(n, (d) => { d.ownerDocument = doc; });
+}
+
+class CharacterData extends Node {
+	constructor(ownerDocument, nodeType, nodeName, data) {
+		super(ownerDocument, nodeType, nodeName);
+		this.data = String(data);
+	}
+
+	get length() {
+		return this.data.length;
+	}
+
+	get nodeValue() {
+		return this.data;
+	}
+
+	set nodeValue(value) {
+		this.data = value == null ? "" : String(value);
+	}
+
+	get textContent() {
+		return this.data;
+	}
+
+	set textContent(value) {
+		this.nodeValue = value;
+	}
+
+	appendData(data) {
+		this.data += String(data);
+	}
+
+	remove() {
+		if (this.parentNode) {
+			this.parentNode.removeChild(this);
+		}
+	}
+}
+
+class Text extends CharacterData {
+	constructor(data = "", ownerDocument = globalThis.document) {
+		super(ownerDocument, TEXT_NODE, "#text", data);
+	}
+
+	get wholeText() {
+		return this.data;
+	}
+
+	_clone() {
+		return new Text(this.data, this.ownerDocument);
+	}
+}
+
+class Comment extends CharacterData {
+	constructor(data = "", ownerDocument = globalThis.document) {
+		super(ownerDocument, COMMENT_NODE, "#comment", data);
+	}
+
+	_clone() {
+		return new Comment(this.data, this.ownerDocument);
+	}
+}
+
+class DocumentFragment extends Node {
+	constructor(ownerDocument = globalThis.document) {
+		super(ownerDocument, DOCUMENT_FRAGMENT_NODE, "#document-fragment");
+	}
+
+	getElementById(id) {
+		return this.querySelectorAll("*").find((e) => e.id === id) ?? null;
+	}
+
+	_clone() {
+		return new DocumentFragment(this.ownerDocument);
+	}
+}
+
+// Elements
+
+class DOMTokenList {
+	constructor(element) {
+		this._element = element;
+	}
+
+	_tokens() {
+		return [...new Set((this._element.getAttribute("class") ?? "").split(/\s+/).filter(Boolean))];
+	}
+
+	_update(tokens) {
+		this._element.setAttribute("class", tokens.join(" "));
+	}
+
+	_check(tokens, method) {
+		for (const token of tokens.map(String)) {
+			if (token === "") {
+				throw domException(`Failed to execute '${method}' on 'DOMTokenList': The token provided must not be empty.`, "SyntaxError");
+			}
+			if (/\s/.test(token)) {
+				throw domException(`Failed to execute '${method}' on 'DOMTokenList': The token provided ('${token}') contains HTML space characters, which are not valid in tokens.`, "InvalidCharacterError");
+			}
+		}
+	}
+
+	get length() {
+		return this._tokens().length;
+	}
+
+	get value() {
+		return this._element.getAttribute("class") ?? "";
+	}
+
+	item(i) {
+		return this._tokens()[i] ?? null;
+	}
+
+	contains(token) {
+		return this._tokens().includes(String(token));
+	}
+
+	add(...tokens) {
+		this._check(tokens, "add");
+		const current = this._tokens();
+		for (const t of tokens) {
+			if (!current.includes(String(t))) {
+				current.push(String(t));
+			}
+		}
+		this._update(current);
+	}
+
+	remove(...tokens) {
+		this._check(tokens, "remove");
+		this._update(this._tokens().filter((t) => !tokens.map(String).includes(t)));
+	}
+
+	toggle(token, force) {
+		this._check([token], "toggle");
+		const has = this.contains(token);
+		const want = force === undefined ? !has : !!force;
+		if (want && !has) {
+			this.add(token);
+		} else if (!want && has) {
+			this.remove(token);
+		}
+		return want;
+	}
+
+	replace(token, newToken) {
+		this._check([token, newToken], "replace");
+		const current = this._tokens();
+		const i = current.indexOf(String(token));
+		if (i < 0) {
+			return false;
+		}
+		current[i] = String(newToken);
+		this._update([...new Set(current)]);
+		return true;
+	}
+
+	toString() {
+		return this.value;
+	}
+}
+
+function cssName(property) {
+	if (property === "cssFloat") {
+		return "float";
+	}
+	return property.replace(/[A-Z]/g, (c) => "-" + c.toLowerCase());
+}
+
+// CSSStyleDeclaration is the inline style of an element. It is kept in sync
+// with the element's style attribute, and camel-cased properties such as
+// backgroundColor are handled by a proxy.
+class CSSStyleDeclaration {
+	constructor(element) {
+		this._element = element;
+		this._decls = [];
+		this._updating = false;
+		this.parentRule = null;
+		this._parse(element.getAttribute("style") ?? "");
+		return new Proxy(this, {
+			get(target, name, receiver) {
+				if (typeof name !== "string" || name in target) {
+					return Reflect.get(target, name, receiver);
+				}
+				if (/^\d+$/.test(name)) {
+					return target.item(Number(name)) || undefined;
+				}
+				if (/^[a-z][a-zA-Z]*$/.test(name)) {
+					return target.getPropertyValue(cssName(name));
+				}
+				return undefined;
+			},
+			set(target, name, value, receiver) {
+				if (typeof name !== "string" || name in target) {
+					return Reflect.set(target, name, value, receiver);
+				}
+				if (/^[a-z][a-zA-Z]*$/.test(name)) {
+					target.setProperty(cssName(name), value);
+					return true;
+				}
+				target[name] = value;
+				return true;
+			},
+		});
+	}
+
+	_parse(text) {
+		this._decls = [];
+		for (const part of String(text).split(";")) {
+			const colon = part.indexOf(":");
+			if (colon < 0) {
+				continue;
+			}
+			let value = part.slice(colon + 1).trim();
+			let priority = "";
+			const important = /\s*!\s*important$/i.exec(value);
+			if (important) {
+				value = value.slice(0, important.index).trim();
+				priority = "important";
+			}
+			this._set(part.slice(0, colon).trim().toLowerCase(), value, priority);
+		}
+	}
+
+	_set(name, value, priority) {
+		if (!name) {
+			return;
+		}
+		const d = this._decls.find((d) => d.name === name);
+		if (value === "") {
+			this._decls = this._decls.filter((d) => d.name !== name);
+		} else if (d) {
+			d.value = value;
+			d.priority = priority;
+		} else {
+			this._decls.push({ name, value, priority });
+		}
+	}
+
+	_sync() {
+		this._updating = true;
+		this._element.setAttribute("style", this.cssText);
+		this._updating = false;
+	}
+
+	get cssText() {
+		return this._decls.map((d) => `${d.name}: ${d.value}${d.priority ? " !" + d.priority : ""};`).join(" ");
+	}
+
+	set cssText(text) {
+		this._parse(text);
+		this._sync();
+	}
+
+	get length() {
+		return this._decls.length;
+	}
+
+	item(i) {
+		return this._decls[i]?.name ?? "";
+	}
+
+	getPropertyValue(name) {
+		return this._decls.find((d) => d.name === String(name).toLowerCase())?.value ?? "";
+	}
+
+	getPropertyPriority(name) {
+		return this._decls.find((d) => d.name === String(name).toLowerCase())?.priority ?? "";
+	}
+
+	setProperty(name, value, priority = "") {
+		priority = String(priority).toLowerCase();
+		if (priority !== "" && priority !== "important") {
+			return;
+		}
+		this._set(String(name).toLowerCase(), value == null ? "" : String(value).trim(), priority);
+		this._sync();
+	}
+
+	removeProperty(name) {
+		const value = this.getPropertyValue(name);
+		this._set(String(name).toLowerCase(), "", "");
+		this._sync();
+		return value;
+	}
+}
+
+const escapeText = (s) => s.replace(/&/g, "&amp;").replace(/</g, "&lt;").replace(/>/g, "&gt;").replace(/\u00a0/g, "&nbsp;");
+const escapeAttribute = (s) => s.replace(/&/g, "&amp;").replace(/"/g, "&quot;").replace(/\u00a0/g, "&nbsp;");
+
+function serialize(n) {
+	switch (n.nodeType) {
+	case TEXT_NODE:
+		return n.parentNode && ["script", "style"].includes(n.parentNode.localName) ? n.data : escapeText(n.data);
+	case COMMENT_NODE:
+		return `<!--${n.data}-->`;
+	case ELEMENT_NODE: {
+		const name = n.prefix ? `${n.prefix}:${n.localName}` : n.localName;
+		const attrs = n._attributes.map((a) => ` ${a.name}="${escapeAttribute(a.value)}"`).join("");
+		if (n.namespaceURI === XHTML_NAMESPACE && VOID_ELEMENTS.has(n.localName)) {
+			return `<${name}${attrs}>`;
+		}
+		return `<${name}${attrs}>${n._children.map(serialize).join("")}</${name}>`;
+	}
+	default:
+		return n._children.map(serialize).join("");
+	}
+}
+
+function decodeEntities(s) {
+	const named = { amp: "&", lt: "<", gt: ">", quot: "\"", apos: "'", nbsp: "\u00a0" };
+	return s.replace(/&(#x[0-9a-f]+|#[0-9]+|[a-z]+);/gi, (entity, name) => {
+		if (name[0] === "#") {
+			return String.fromCodePoint(name[1] === "x" || name[1] === "X" ? parseInt(name.slice(2), 16) : parseInt(name.slice(1), 10));
+		}
+		return named[name.toLowerCase()] ?? entity;
+	});
+}
+
+// parseHTML parses a fragment of markup into a document fragment. It handles
+// elements, attributes, text, comments and character references, but not the
+// error recovery rules of a real HTML parser.
+function parseHTML(doc, html) {
+	const fragment = doc.createDocumentFragment();
+	const stack = [fragment];
+	const token = /<!--([\s\S]*?)(?:-->|$)|<\/([a-zA-Z][^\s/>]*)\s*>|<([a-zA-Z][^\s/>]*)((?:\s+[^\s/>=]+(?:\s*=\s*(?:"[^"]*"|'[^']*'|[^\s>]+))?)*)\s*(\/?)>|<!?[^>]*>/g;
+	const attribute = /([^\s/>=]+)(?:\s*=\s*(?:"([^"]*)"|'([^']*)'|([^\s>]+)))?/g;
+	let last = 0;
+	const text = (s) => {
+		if (s) {
+			stack[stack.length - 1].appendChild(doc.createTextNode(decodeEntities(s)));
+		}
+	};
+	for (let m; (m = token.exec(html)) !== null;) {
+		text(html.slice(last, m.index));
+		last = token.lastIndex;
+		const parent = stack[stack.length - 1];
+		if (m[1] !== undefined) {
+			parent.appendChild(doc.createComment(m[1]));
+		} else if (m[2] !== undefined) {
+			const name = m[2].toLowerCase();
+			const i = stack.findLastIndex((n) => n.localName === name);
+			if (i > 0) {
+				stack.length = i;
+			}
+		} else if (m[3] !== undefined) {
+			// svg and math elements and their descendants are foreign content
+			const name = m[3].toLowerCase();
+			const namespace = name === "svg" ? SVG_NAMESPACE : name === "math" ? MATHML_NAMESPACE : parent.namespaceURI;
+			const el = namespace && namespace !== XHTML_NAMESPACE ? doc.createElementNS(namespace, name) : doc.createElement(name);
+			for (let a; (a = attribute.exec(m[4])) !== null;) {
+				if (!el.hasAttribute(a[1])) {
+					el.setAttribute(a[1], decodeEntities(a[2] ?? a[3] ?? a[4] ?? ""));
+				}
+			}
+			parent.appendChild(el);
+			if (!m[5] && !(el.namespaceURI === XHTML_NAMESPACE && VOID_ELEMENTS.has(el.localName))) {
+				stack.push(el);
+			}
+		}
+	}
+	text(html.slice(last));
+	return fragment;
+}
+
+class Element extends Node {
+	constructor(ownerDocument, localName, namespaceURI = XHTML_NAMESPACE, prefix = null) {
+		const qualifiedName = prefix ? `${prefix}:${localName}` : localName;
+		super(ownerDocument, ELEMENT_NODE, namespaceURI === XHTML_NAMESPACE ? qualifiedName.toUpperCase() : qualifiedName);
+		this.localName = localName;
+		this.namespaceURI = namespaceURI;
+		this.prefix = prefix;
+		this._attributes = [];
+		this._style = null;
+		this._classList = null;
+	}
+
+	get tagName() {
+		return this.nodeName;
+	}
+
+	get attributes() {
+		this._attributeMap ??= new NamedNodeMap(this);
+		return this._attributeMap;
+	}
+
+	// _attributeName lower-cases the attribute names of HTML elements
+	_attributeName(name) {
+		name = String(name);
+		return this.namespaceURI === XHTML_NAMESPACE ? name.toLowerCase() : name;
+	}
+
+	_attributeChanged(name) {
+		if (name === "style" && this._style && !this._style._updating) {
+			this._style._parse(this.getAttribute("style") ?? "");
+		}
+	}
+
+	getAttributeNames() {
+		return this._attributes.map((a) => a.name);
+	}
+
+	getAttribute(name) {
+		name = this._attributeName(name);
+		return this._attributes.find((a) => a.name === name)?.value ?? null;
+	}
+
+	setAttribute(name, value) {
+		name = this._attributeName(name);
+		if (!/^[^\s"'>/=]+$/.test(name)) {
+			throw domException(`'${name}' is not a valid attribute name.`, "InvalidCharacterError");
+		}
+		value = String(value);
+		const a = this._attributes.find((a) => a.name === name);
+		if (a) {
+			a.value = value;
+		} else {
+			this._attributes.push({ name, localName: name, namespaceURI: null, prefix: null, value });
+		}
+		this._attributeChanged(name);
+	}
+
+	removeAttribute(name) {
+		name = this._attributeName(name);
+		const i = this._attributes.findIndex((a) => a.name === name);
+		if (i >= 0) {
+			this._attributes.splice(i, 1);
+			this._attributeChanged(name);
+		}
+	}
+
+	getAttributeNS(namespace, localName) {
+		namespace = namespaceArg(namespace);
+		localName = String(localName);
+		return this._attributes.find((a) => a.namespaceURI === namespace && a.localName === localName)?.value ?? null;
+	}
+
+	setAttributeNS(namespace, qualifiedName, value) {
+		namespace = namespaceArg(namespace);
+		const [prefix, localName] = validateQualifiedName(namespace, String(qualifiedName));
+		value = String(value);
+		const a = this._attributes.find((a) => a.namespaceURI === namespace && a.localName === localName);
+		if (a) {
+			a.value = value;
+		} else {
+			this._attributes.push({ name: String(qualifiedName), localName, namespaceURI: namespace, prefix, value });
+		}
+		this._attributeChanged(a ? a.name : String(qualifiedName));
+	}
+
+	removeAttributeNS(namespace, localName) {
+		namespace = namespaceArg(namespace);
+		localName = String(localName);
+		const i = this._attributes.findIndex((a) => a.namespaceURI === namespace && a.localName === localName);
+		if (i >= 0) {
+			const [a] = this._attributes.splice(i, 1);
+			this._attributeChanged(a.name);
+		}
+	}
+
+	hasAttributeNS(namespace, localName) {
+		return this.getAttributeNS(namespace, localName) !== null;
+	}
+
+	hasAttribute(name) {
+		return this.getAttribute(name) !== null;
+	}
+
+	hasAttributes() {
+		return this._attributes.length > 0;
+	}
+
+	toggleAttribute(name, force) {
+		const has = this.hasAttribute(name);
+		const want = force === undefined ? !has : !!force;
+		if (want && !has) {
+			this.setAttribute(name, "");
+		} else if (!want && has) {
+			this.removeAttribute(name);
+		}
+		return want;
+	}
+
+	get id() {
+		return this.getAttribute("id") ?? "";
+	}
+
+	set id(value) {
+		this.setAttribute("id", value);
+	}
+
+	get className() {
+		return this.getAttribute("class") ?? "";
+	}
+
+	set className(value) {
+		this.setAttribute("class", value);
+	}
+
+	get classList() {
+		this._classList ??= new DOMTokenList(this);
+		return this._classList;
+	}
+
+	get style() {
+		this._style ??= new CSSStyleDeclaration(this);
+		return this._style;
+	}
+
+	get title() {
+		return this.getAttribute("title") ?? "";
+	}
+
+	set title(value) {
+		this.setAttribute("title", value);
+	}
+
+	get hidden() {
+		return this.hasAttribute("hidden");
+	}
+
+	set hidden(value) {
+		this.toggleAttribute("hidden", !!value);
+	}
+
+	get innerHTML() {
+		return this._children.map(serialize).join("");
+	}
+
+	set innerHTML(html) {
+		this._replaceChildren([parseHTML(this._document(), String(html))]);
+	}
+
+	get outerHTML() {
+		return serialize(this);
+	}
+
+	get innerText() {
+		return this.textContent;
+	}
+
+	set innerText(value) {
+		this.textContent = value;
+	}
+
+	get previousElementSibling() {
+		for (let n = this.previousSibling; n; n = n.previousSibling) {
+			if (n.nodeType === ELEMENT_NODE) {
+				return n;
+			}
+		}
+		return null;
+	}
+
+	get nextElementSibling() {
+		for (let n = this.nextSibling; n; n = n.nextSibling) {
+			if (n.nodeType === ELEMENT_NODE) {
+				return n;
+			}
+		}
+		return null;
+	}
+
+	matches(selectors) {
+		return matchesSelector(this, parseSelector(selectors));
+	}
+
+	closest(selectors) {
+		const selector = parseSelector(selectors);
+		for (let n = this; n && n.nodeType === ELEMENT_NODE; n = n.parentNode) {
+			if (matchesSelector(n, selector)) {
+				return n;
+			}
+		}
+		return null;
+	}
+
+	remove() {
+		if (this.parentNode) {
+			this.parentNode.removeChild(this);
+		}
+	}
+
+	click() {
+		this.dispatchEvent(new MouseEvent("click", { bubbles: true, cancelable: true, composed: true }));
+	}
+
+	focus() {
+		const doc = this._document();
+		if (doc._focused === this || !this.isConnected) {
+			return;
+		}
+		if (doc._focused) {
+			doc._focused.blur();
+		}
+		doc._focused = this;
+		this.dispatchEvent(new FocusEvent("focus"));
+		this.dispatchEvent(new FocusEvent("focusin", { bubbles: true }));
+	}
+
+	blur() {
+		const doc = this._document();
+		if (doc._focused !== this) {
+			return;
+		}
+		doc._focused = null;
+		this.dispatchEvent(new FocusEvent("blur"));
+		this.dispatchEvent(new FocusEvent("focusout", { bubbles: true }));
+	}
+
+	getBoundingClientRect() {
+		return new DOMRect();
+	}
+
+	getClientRects() {
+		return [];
+	}
+
+	scrollIntoView() {}
+
+	_clone() {
+		const clone = new this.constructor(this._document(), this.localName, this.namespaceURI, this.prefix);
+		clone._attributes = this._attributes.map((a) => ({ ...a }));
+		return clone;
+	}
+}
+
+class HTMLElement extends Element {}
+
+class DOMRect {
+	constructor(x = 0, y = 0, width = 0, height = 0) {
+		this.x = x;
+		this.y = y;
+		this.width = width;
+		this.height = height;
+	}
+
+	get top() { return Math.min(this.y, this.y + this.height); }
+	get bottom() { return Math.max(this.y, this.y + this.height); }
+	get left() { return Math.min(this.x, this.x + this.width); }
+	get right() { return Math.max(this.x, this.x + this.width); }
+}
+
+// Selectors
+
+// parseSelector parses a selector list into lists of compound selectors and
+// combinators. It supports type, universal, id, class and attribute
+// selectors with the descendant, child, next-sibling and subsequent-sibling
+// combinators.
+function parseSelector(text) {
+	text = String(text);
+	const invalid = () => domException(`'${text}' is not a valid selector.`, "SyntaxError");
+	const token = /\s*([>+~,])\s*|(\s+)|(\*|[a-zA-Z][\w-]*)|#([\w-]+)|\.([\w-]+)|\[\s*([\w-]+)\s*(?:([~|^$*]?=)\s*(?:"([^"]*)"|'([^']*)'|([\w-]+))\s*)?\]/y;
+	const list = [];
+	let complex = [];
+	let compound = null;
+	let combinator = " ";
+	const start = () => {
+		if (!compound) {
+			compound = { type: null, ids: [], classes: [], attrs: [], combinator };
+			complex.push(compound);
+		}
+		return compound;
+	};
+	let pos = 0;
+	text = text.trim();
+	while (pos < text.length) {
+		token.lastIndex = pos;
+		const m = token.exec(text);
+		if (!m) {
+			throw invalid();
+		}
+		pos = token.lastIndex;
+		if (m[1] === ",") {
+			if (!compound) {
+				throw invalid();
+			}
+			list.push(complex);
+			complex = [];
+			compound = null;
+			combinator = " ";
+		} else if (m[1] !== undefined || m[2] !== undefined) {
+			if (!compound) {
+				throw invalid();
+			}
+			combinator = m[1] ?? " ";
+			compound = null;
+		} else if (m[3] !== undefined) {
+			if (compound) {
+				throw invalid();
+			}
+			start().type = m[3].toLowerCase();
+		} else if (m[4] !== undefined) {
+			start().ids.push(m[4]);
+		} else if (m[5] !== undefined) {
+			start().classes.push(m[5]);
+		} else {
+			start().attrs.push({ name: m[6].toLowerCase(), op: m[7], value: m[8] ?? m[9] ?? m[10] });
+		}
+	}
+	if (!compound) {
+		throw invalid();
+	}
+	list.push(complex);
+	return list;
+}
+
+function matchesCompound(e, c) {
+	if (c.type && c.type !== "*" && c.type !== e.localName) {
+		return false;
+	}
+	if (c.ids.some((id) => e.id !== id) || c.classes.some((cls) => !e.classList.contains(cls))) {
+		return false;
+	}
+	return c.attrs.every(({ name, op, value }) => {
+		const actual = e.getAttribute(name);
+		if (actual === null) {
+			return false;
+		}
+		switch (op) {
+		case undefined: return true;
+		case "=": return actual === value;
+		case "~=": return actual.split(/\s+/).includes(value);
+		case "|=": return actual === value || actual.startsWith(value + "-");
+		case "^=": return value !== "" && actual.startsWith(value);
+		case "$=": return value !== "" && actual.endsWith(value);
+		case "*=": return value !== "" && actual.includes(value);
+		}
+		return false;
+	});
+}
+
+function matchesComplex(e, complex, i) {
+	if (!e || e.nodeType !== ELEMENT_NODE || !matchesCompound(e, complex[i])) {
+		return false;
+	}
+	if (i === 0) {
+		return true;
+	}
+	switch (complex[i].combinator) {
+	case ">":
+		return matchesComplex(e.parentNode, complex, i - 1);
+	case "+":
+		return matchesComplex(e.previousElementSibling, complex, i - 1);
+	case "~":
+		for (let s = e.previousElementSibling; s; s = s.previousElementSibling) {
+			if (matchesComplex(s, complex, i - 1)) {
+				return true;
+			}
+		}
+		return false;
+	default:
+		for (let a = e.parentNode; a; a = a.parentNode) {
+			if (matchesComplex(a, complex, i - 1)) {
+				return true;
+			}
+		}
+		return false;
+	}
+}
+
+function matchesSelector(e, list) {
+	return list.some((complex) => matchesComplex(e, complex, complex.length - 1));
+}
+
+// Documents
+
+class Document extends Node {
+	constructor() {
+		super(null, DOCUMENT_NODE, "#document");
+		this._focused = null;
+		this.readyState = "complete";
+		this.visibilityState = "visible";
+		this.URL = "about:blank";
+		this.documentURI = "about:blank";
+		this.contentType = "text/html";
+		this.characterSet = "UTF-8";
+		this.compatMode = "CSS1Compat";
+	}
+
+	get textContent() {
+		return null;
+	}
+
+	set textContent(value) {}
+
+	get defaultView() {
+		return globalThis;
+	}
+
+	get hidden() {
+		return this.visibilityState === "hidden";
+	}
+
+	get documentElement() {
+		return this.firstElementChild;
+	}
+
+	get head() {
+		return this.documentElement?.children.find((e) => e.localName === "head") ?? null;
+	}
+
+	get body() {
+		return this.documentElement?.children.find((e) => e.localName === "body") ?? null;
+	}
+
+	get activeElement() {
+		return this._focused ?? this.body;
+	}
+
+	get title() {
+		return (this.querySelector("title")?.textContent ?? "").trim().replace(/\s+/g, " ");
+	}
+
+	set title(value) {
+		let title = this.querySelector("title");
+		if (!title) {
+			if (!this.head) {
+				return;
+			}
+			title = this.head.appendChild(this.createElement("title"));
+		}
+		title.textContent = value;
+	}
+
+	createElement(name) {
+		name = String(name);
+		if (!/^[a-zA-Z][^\s"'>/=]*$/.test(name)) {
+			throw domException(`The tag name provided ('${name}') is not a valid name.`, "InvalidCharacterError");
+		}
+		name = name.toLowerCase();
+		return new HTMLElement(this, name);
+	}
+
+	createElementNS(namespace, qualifiedName) {
+		namespace = namespaceArg(namespace);
+		const [prefix, localName] = validateQualifiedName(namespace, String(qualifiedName));
+		if (namespace === XHTML_NAMESPACE) {
+			return new HTMLElement(this, localName, namespace, prefix);
+		}
+		return new Element(this, localName, namespace, prefix);
+	}
+
+	createTextNode(data) {
+		return new Text(data, this);
+	}
+
+	createComment(data) {
+		return new Comment(data, this);
+	}
+
+	createDocumentFragment() {
+		return new DocumentFragment(this);
+	}
+
+	createEvent(type) {
+		const constructors = {
+			event: Event, events: Event, customevent: CustomEvent, mouseevent: MouseEvent, mouseevents: MouseEvent,
+			focusevent: FocusEvent, keyboardevent: KeyboardEvent, uievent: UIEvent, uievents: UIEvent,
+			inputevent: InputEvent, touchevent: TouchEvent, wheelevent: WheelEvent, pointerevent: PointerEvent,
+		};
+		const constructor = constructors[String(type).toLowerCase()];
+		if (!constructor) {
+			throw domException(`The provided event type ('${type}') is invalid.`, "NotSupportedError");
+		}
+		const event = new constructor("");
+		event.initEvent = (type, bubbles, cancelable) => {
+			event.type = String(type);
+			event.bubbles = !!bubbles;
+			event.cancelable = !!cancelable;
+		};
+		return event;
+	}
+
+	getElementById(id) {
+		let found = null;
+		walk(this, (n) => {
+			if (!found && n.nodeType === ELEMENT_NODE && n.id === String(id)) {
+				found = n;
+			}
+		});
+		return found;
+	}
+
+	hasFocus() {
+		return true;
+	}
+
+	_clone() {
+		return new Document();
+	}
+}
+
+function newDocument() {
+	const doc = new Document();
+	const html = doc.createElement("html");
+	html.appendChild(doc.createElement("head"));
+	html.appendChild(doc.createElement("body"));
+	doc.appendChild(html);
+	return doc;
+}
+
+// install makes globalThis look like a browser window containing an empty
+// HTML document. Node's own EventTarget and Event are replaced, because they
+// do not propagate events through a tree.
+function install() {
+	for (const name of ["addEventListener", "removeEventListener", "dispatchEvent", "_parentTarget", "_invoke"]) {
+		globalThis[name] = EventTarget.prototype[name];
+	}
+	globalThis._listeners = [];
+	Object.assign(globalThis, {
+		Event, UIEvent, MouseEvent, PointerEvent, WheelEvent, FocusEvent, KeyboardEvent, InputEvent,
+		TouchEvent, Touch, CustomEvent, PageTransitionEvent, EventTarget,
+		Node, CharacterData, Text, Comment, DocumentFragment, Element, HTMLElement, Document,
+		NodeList, HTMLCollection, DOMTokenList, CSSStyleDeclaration, DOMRect,
+	});
+	globalThis.window = globalThis;
+	globalThis.self = globalThis;
+	globalThis.document = newDocument();
+}
+
+module.exports = { install, Event, EventTarget };
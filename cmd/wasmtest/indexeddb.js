@@ -0,0 +1,1186 @@
+// An in-memory IndexedDB for running the web/idb tests under Node.js, in the
+// manner of the fake-indexeddb package. It is installed by runner.js after
+// the DOM, whose EventTarget and Event it builds on.
+//
+// The databases live in memory for as long as the process. Transactions on a
+// database run one at a time, and their requests run in order, each in a task
+// of its own. A transaction is active while the task that created it runs and
+// while the events of its requests are dispatched, and it commits once it is
+// inactive with no requests left.
+
+"use strict";
+
+const { Event, EventTarget } = require("./dom.js");
+
+function domException(message, name) {
+	return new DOMException(message, name);
+}
+
+// keyType orders the types of keys: numbers, dates, strings, binary and arrays
+function keyType(key) {
+	if (typeof key === "number") {
+		return 0;
+	}
+	if (key instanceof Date) {
+		return 1;
+	}
+	if (typeof key === "string") {
+		return 2;
+	}
+	return key instanceof Uint8Array ? 3 : 4;
+}
+
+function compareKeys(a, b) {
+	const ta = keyType(a);
+	const tb = keyType(b);
+	if (ta !== tb) {
+		return ta < tb ? -1 : 1;
+	}
+	if (ta === 1) {
+		a = a.getTime();
+		b = b.getTime();
+	}
+	if (ta <= 2) {
+		return a < b ? -1 : a > b ? 1 : 0;
+	}
+	for (let i = 0; i < Math.min(a.length, b.length); i++) {
+		const c = ta === 3 ? Math.sign(a[i] - b[i]) : compareKeys(a[i], b[i]);
+		if (c !== 0) {
+			return c;
+		}
+	}
+	return a.length < b.length ? -1 : a.length > b.length ? 1 : 0;
+}
+
+// toKey converts a value into a key, copying dates, binary data and arrays,
+// and throws a DataError if it is not a valid key
+function toKey(value, seen = new Set()) {
+	if (typeof value === "number" && !Number.isNaN(value)) {
+		return value;
+	}
+	if (typeof value === "string") {
+		return value;
+	}
+	if (value instanceof Date && !Number.isNaN(value.getTime())) {
+		return new Date(value.getTime());
+	}
+	if (value instanceof ArrayBuffer) {
+		return new Uint8Array(value.slice(0));
+	}
+	if (ArrayBuffer.isView(value)) {
+		return new Uint8Array(value.buffer.slice(value.byteOffset, value.byteOffset + value.byteLength));
+	}
+	if (Array.isArray(value) && !seen.has(value)) {
+		seen.add(value);
+		const key = value.map((v) => toKey(v, seen));
+		seen.delete(value);
+		return key;
+	}
+	throw domException("The parameter is not a valid key.", "DataError");
+}
+
+// cloneKey copies a key before handing it out
+function cloneKey(key) {
+	return key === undefined ? undefined : toKey(key);
+}
+
+function cloneValue(value) {
+	try {
+		return structuredClone(value);
+	} catch (err) {
+		throw domException(`Failed to execute 'put' on 'IDBObjectStore': ${err.message}`, "DataCloneError");
+	}
+}
+
+function validKeyPath(keyPath) {
+	if (Array.isArray(keyPath)) {
+		return keyPath.length > 0 && keyPath.every((p) => typeof p === "string" && validKeyPath(p));
+	}
+	return typeof keyPath === "string" && (keyPath === "" || /^[$_\p{L}][$_\p{L}\p{N}]*(\.[$_\p{L}][$_\p{L}\p{N}]*)*$/u.test(keyPath));
+}
+
+// evaluateKeyPath returns the value at the key path, or undefined if there is
+// none
+function evaluateKeyPath(value, keyPath) {
+	if (Array.isArray(keyPath)) {
+		const key = [];
+		for (const p of keyPath) {
+			const k = evaluateKeyPath(value, p);
+			if (k === undefined) {
+				return undefined;
+			}
+			key.push(k);
+		}
+		return key;
+	}
+	if (keyPath === "") {
+		return value;
+	}
+	for (const name of keyPath.split(".")) {
+		if (typeof value === "string" && name === "length") {
+			value = value.length;
+		} else if (value !== null && typeof value === "object" && Object.hasOwn(value, name)) {
+			value = value[name];
+		} else {
+			return undefined;
+		}
+	}
+	return value;
+}
+
+// injectKey stores a generated key at the key path, reporting false if the
+// value has no place for it
+function injectKey(value, keyPath, key, dryRun) {
+	const names = keyPath.split(".");
+	const last = names.pop();
+	for (const name of names) {
+		if (value === null || typeof value !== "object") {
+			return false;
+		}
+		if (!Object.hasOwn(value, name)) {
+			if (dryRun) {
+				return true;
+			}
+			value[name] = {};
+		}
+		value = value[name];
+	}
+	if (value === null || typeof value !== "object") {
+		return false;
+	}
+	if (!dryRun) {
+		value[last] = key;
+	}
+	return true;
+}
+
+// indexKeys returns the keys of a value in an index, which are several for a
+// multiEntry index of an array
+function indexKeys(index, value) {
+	const k = evaluateKeyPath(value, index.keyPath);
+	if (k === undefined) {
+		return [];
+	}
+	const keys = [];
+	for (const v of index.multiEntry && Array.isArray(k) ? k : [k]) {
+		let key;
+		try {
+			key = toKey(v);
+		} catch {
+			continue;
+		}
+		if (!keys.some((other) => compareKeys(other, key) === 0)) {
+			keys.push(key);
+		}
+	}
+	return index.multiEntry || keys.length === 1 ? keys : [];
+}
+
+class IDBKeyRange {
+	constructor(lower, upper, lowerOpen, upperOpen) {
+		this.lower = lower;
+		this.upper = upper;
+		this.lowerOpen = lowerOpen;
+		this.upperOpen = upperOpen;
+	}
+
+	static only(value) {
+		const key = toKey(value);
+		return new IDBKeyRange(key, key, false, false);
+	}
+
+	static lowerBound(lower, open = false) {
+		return new IDBKeyRange(toKey(lower), undefined, !!open, true);
+	}
+
+	static upperBound(upper, open = false) {
+		return new IDBKeyRange(undefined, toKey(upper), true, !!open);
+	}
+
+	static bound(lower, upper, lowerOpen = false, upperOpen = false) {
+		lower = toKey(lower);
+		upper = toKey(upper);
+		const c = compareKeys(lower, upper);
+		if (c > 0 || (c === 0 && (lowerOpen || upperOpen))) {
+			throw domException("Failed to execute 'bound' on 'IDBKeyRange': The lower key is greater than the upper key.", "DataError");
+		}
+		return new IDBKeyRange(lower, upper, !!lowerOpen, !!upperOpen);
+	}
+
+	includes(key) {
+		return this._contains(toKey(key));
+	}
+
+	_contains(key) {
+		if (this.lower !== undefined) {
+			const c = compareKeys(this.lower, key);
+			if (c > 0 || (c === 0 && this.lowerOpen)) {
+				return false;
+			}
+		}
+		if (this.upper !== undefined) {
+			const c = compareKeys(key, this.upper);
+			if (c > 0 || (c === 0 && this.upperOpen)) {
+				return false;
+			}
+		}
+		return true;
+	}
+}
+
+// toRange converts a key or key range into a key range, or null for all keys
+function toRange(query, required) {
+	if (query instanceof IDBKeyRange) {
+		return query;
+	}
+	if (query == null) {
+		if (required) {
+			throw domException("No key or key range specified.", "DataError");
+		}
+		return null;
+	}
+	return IDBKeyRange.only(query);
+}
+
+// stringList makes a sorted DOMStringList
+function stringList(names) {
+	const list = [...names].sort();
+	list.contains = (name) => list.includes(String(name));
+	list.item = (i) => list[i] ?? null;
+	return list;
+}
+
+// StoreData holds the records of an object store sorted by key
+class StoreData {
+	constructor(name, keyPath, autoIncrement) {
+		this.name = name;
+		this.keyPath = keyPath;
+		this.autoIncrement = autoIncrement;
+		this.records = [];
+		this.indexes = new Map();
+		// current is the next key of the key generator
+		this.current = 1;
+	}
+
+	clone() {
+		const s = new StoreData(this.name, this.keyPath, this.autoIncrement);
+		s.records = this.records.slice();
+		s.indexes = new Map(this.indexes);
+		s.current = this.current;
+		return s;
+	}
+
+	// find returns the position of the record with the key, or where it belongs
+	find(key) {
+		let lo = 0;
+		let hi = this.records.length;
+		while (lo < hi) {
+			const mid = (lo + hi) >> 1;
+			const c = compareKeys(this.records[mid].key, key);
+			if (c === 0) {
+				return { i: mid, found: true };
+			}
+			if (c < 0) {
+				lo = mid + 1;
+			} else {
+				hi = mid;
+			}
+		}
+		return { i: lo, found: false };
+	}
+
+	// put stores a record, checking the unique indexes first
+	put(key, value, noOverwrite) {
+		const { i, found } = this.find(key);
+		if (found && noOverwrite) {
+			throw domException("Key already exists in the object store.", "ConstraintError");
+		}
+		for (const index of this.indexes.values()) {
+			if (!index.unique) {
+				continue;
+			}
+			for (const k of indexKeys(index, value)) {
+				if (this.records.some((r) => compareKeys(r.key, key) !== 0 && indexKeys(index, r.value).some((other) => compareKeys(other, k) === 0))) {
+					throw domException(`Unable to add key to index '${index.name}': at least one key does not satisfy the uniqueness requirements.`, "ConstraintError");
+				}
+			}
+		}
+		const record = { key, value };
+		if (found) {
+			this.records[i] = record;
+		} else {
+			this.records.splice(i, 0, record);
+		}
+	}
+
+	// entries lists the records by key, or by index key and then key
+	entries(index) {
+		if (!index) {
+			return this.records.map((r) => ({ key: r.key, primaryKey: r.key, value: r.value }));
+		}
+		const entries = [];
+		for (const r of this.records) {
+			for (const key of indexKeys(index, r.value)) {
+				entries.push({ key, primaryKey: r.key, value: r.value });
+			}
+		}
+		return entries.sort((a, b) => compareKeys(a.key, b.key) || compareKeys(a.primaryKey, b.primaryKey));
+	}
+}
+
+// DatabaseData is a database shared by its connections
+class DatabaseData {
+	constructor(name) {
+		this.name = name;
+		this.version = 0;
+		this.stores = new Map();
+		this.connections = new Set();
+		// queue holds the transactions in the order they were created; the
+		// first one is running
+		this.queue = [];
+		// pending holds the open and delete requests waiting for the
+		// connections to close
+		this.pending = [];
+	}
+}
+
+const idbDatabases = new Map();
+
+// IDBEventTarget calls the on<type> handler properties after the listeners
+class IDBEventTarget extends EventTarget {
+	_invoke(event, capture) {
+		super._invoke(event, capture);
+		const handler = this["on" + event.type];
+		if (capture !== true && !event._stopImmediate && typeof handler === "function") {
+			event.currentTarget = this;
+			try {
+				handler.call(this, event);
+			} catch (err) {
+				console.error(err);
+			}
+		}
+	}
+}
+
+class IDBVersionChangeEvent extends Event {
+	constructor(type, init = {}) {
+		super(type, init);
+		this.oldVersion = init.oldVersion ?? 0;
+		this.newVersion = init.newVersion ?? null;
+	}
+}
+
+class IDBRequest extends IDBEventTarget {
+	constructor(source, transaction) {
+		super();
+		this.source = source;
+		this.transaction = transaction;
+		this.readyState = "pending";
+		this.onsuccess = this.onerror = null;
+		this._result = undefined;
+		this._error = null;
+	}
+
+	get result() {
+		if (this.readyState !== "done") {
+			throw domException("Failed to read the 'result' property from 'IDBRequest': The request has not finished.", "InvalidStateError");
+		}
+		return this._result;
+	}
+
+	get error() {
+		if (this.readyState !== "done") {
+			throw domException("Failed to read the 'error' property from 'IDBRequest': The request has not finished.", "InvalidStateError");
+		}
+		return this._error;
+	}
+
+	_parentTarget() {
+		return this.transaction;
+	}
+
+	_succeed(result, event = new Event("success")) {
+		this.readyState = "done";
+		this._result = result;
+		this._error = null;
+		this.dispatchEvent(event);
+	}
+
+	// _fail fires an error event, returning false if it was cancelled
+	_fail(error) {
+		this.readyState = "done";
+		this._result = undefined;
+		this._error = error;
+		return this.dispatchEvent(new Event("error", { bubbles: true, cancelable: true }));
+	}
+}
+
+class IDBOpenDBRequest extends IDBRequest {
+	constructor() {
+		super(null, null);
+		this.onblocked = this.onupgradeneeded = null;
+	}
+}
+
+class IDBTransaction extends IDBEventTarget {
+	constructor(db, names, mode) {
+		super();
+		this.db = db;
+		this.mode = mode;
+		this.durability = "default";
+		this.error = null;
+		this.oncomplete = this.onabort = this.onerror = null;
+		// _names is null for a version change transaction, which covers all
+		// object stores
+		this._names = names;
+		this._stores = new Map();
+		this._requests = [];
+		this._state = "waiting";
+		this._active = true;
+		this._committing = false;
+		this._scheduled = false;
+		this._snapshot = null;
+		setTimeout(() => {
+			this._active = false;
+			this._schedule();
+		});
+		const data = db._data;
+		data.queue.push(this);
+		if (data.queue.length === 1) {
+			this._start();
+		}
+	}
+
+	get objectStoreNames() {
+		return stringList(this._scope());
+	}
+
+	_scope() {
+		return this._names ?? this.db._data.stores.keys();
+	}
+
+	objectStore(name) {
+		if (this._state === "finished") {
+			throw domException("Failed to execute 'objectStore' on 'IDBTransaction': The transaction has finished.", "InvalidStateError");
+		}
+		name = String(name);
+		if (![...this._scope()].includes(name)) {
+			throw domException("Failed to execute 'objectStore' on 'IDBTransaction': The specified object store was not found.", "NotFoundError");
+		}
+		if (!this._stores.has(name)) {
+			this._stores.set(name, new IDBObjectStore(this, name));
+		}
+		return this._stores.get(name);
+	}
+
+	commit() {
+		if (this._state === "finished" || this._committing || !this._active) {
+			throw domException("Failed to execute 'commit' on 'IDBTransaction': The transaction is not active.", "InvalidStateError");
+		}
+		this._committing = true;
+		this._schedule();
+	}
+
+	abort() {
+		if (this._state === "finished" || this._committing) {
+			throw domException("Failed to execute 'abort' on 'IDBTransaction': The transaction has already been committed or aborted.", "InvalidStateError");
+		}
+		this._abort(null);
+	}
+
+	_parentTarget() {
+		return this.db;
+	}
+
+	// _check throws if a request cannot be made
+	_check(write) {
+		if (this._state === "finished" || !this._active || this._committing) {
+			throw domException("The transaction is not active.", "TransactionInactiveError");
+		}
+		if (write && this.mode === "readonly") {
+			throw domException("The transaction is read-only.", "ReadOnlyError");
+		}
+	}
+
+	// _enqueue queues an operation, whose result becomes the result of the
+	// request
+	_enqueue(source, operation, request = new IDBRequest(source, this)) {
+		request.readyState = "pending";
+		this._requests.push({ request, operation });
+		this._schedule();
+		return request;
+	}
+
+	_start() {
+		this._state = "running";
+		if (this.mode !== "readonly") {
+			const data = this.db._data;
+			this._snapshot = {
+				version: data.version,
+				stores: new Map([...data.stores].map(([name, s]) => [name, s.clone()])),
+			};
+		}
+		this._schedule();
+	}
+
+	_schedule() {
+		if (this._scheduled || this._state !== "running") {
+			return;
+		}
+		this._scheduled = true;
+		setTimeout(() => {
+			this._scheduled = false;
+			this._step();
+		});
+	}
+
+	// _step runs the next request, or commits if none are left
+	_step() {
+		if (this._state !== "running") {
+			return;
+		}
+		if (this._requests.length === 0) {
+			if (!this._active || this._committing) {
+				this._finish();
+				this.dispatchEvent(new Event("complete"));
+				this._oncommitted?.();
+			}
+			return;
+		}
+		const { request, operation } = this._requests.shift();
+		let result;
+		let error = null;
+		try {
+			result = operation();
+		} catch (err) {
+			error = err;
+		}
+		this._active = true;
+		if (error === null) {
+			request._succeed(result);
+			this._active = false;
+		} else {
+			const uncaught = request._fail(error);
+			this._active = false;
+			if (uncaught && this._state === "running") {
+				this._abort(error);
+				return;
+			}
+		}
+		this._schedule();
+	}
+
+	_abort(error) {
+		this._state = "finished";
+		this.error = error;
+		const data = this.db._data;
+		if (this._snapshot) {
+			data.version = this._snapshot.version;
+			data.stores = this._snapshot.stores;
+		}
+		const requests = this._requests;
+		this._requests = [];
+		setTimeout(() => {
+			for (const { request } of requests) {
+				request._fail(domException("The transaction was aborted, so the request cannot be fulfilled.", "AbortError"));
+			}
+			this._finish();
+			this.dispatchEvent(new Event("abort", { bubbles: true }));
+			this._onaborted?.();
+		});
+	}
+
+	// _finish lets the next transaction of the database start
+	_finish() {
+		this._state = "finished";
+		const queue = this.db._data.queue;
+		queue.splice(queue.indexOf(this), 1);
+		if (queue.length > 0 && queue[0]._state === "waiting") {
+			queue[0]._start();
+		}
+	}
+}
+
+class IDBDatabase extends IDBEventTarget {
+	constructor(data) {
+		super();
+		this.name = data.name;
+		this.version = data.version;
+		this.onabort = this.onclose = this.onerror = this.onversionchange = null;
+		this._data = data;
+		this._closed = false;
+		this._upgrade = null;
+		data.connections.add(this);
+	}
+
+	get objectStoreNames() {
+		return stringList(this._data.stores.keys());
+	}
+
+	// _upgrading returns the running version change transaction
+	_upgrading(method) {
+		const tx = this._upgrade;
+		if (!tx || tx._state === "finished") {
+			throw domException(`Failed to execute '${method}' on 'IDBDatabase': The database is not running a version change transaction.`, "InvalidStateError");
+		}
+		tx._check(false);
+		return tx;
+	}
+
+	createObjectStore(name, options = {}) {
+		const tx = this._upgrading("createObjectStore");
+		name = String(name);
+		const keyPath = options.keyPath ?? null;
+		const autoIncrement = !!options.autoIncrement;
+		if (keyPath !== null && !validKeyPath(keyPath)) {
+			throw domException("Failed to execute 'createObjectStore' on 'IDBDatabase': The keyPath option is not a valid key path.", "SyntaxError");
+		}
+		if (this._data.stores.has(name)) {
+			throw domException("Failed to execute 'createObjectStore' on 'IDBDatabase': An object store with the specified name already exists.", "ConstraintError");
+		}
+		if (autoIncrement && (keyPath === "" || Array.isArray(keyPath))) {
+			throw domException("Failed to execute 'createObjectStore' on 'IDBDatabase': The autoIncrement option was set but the keyPath option was empty or an array.", "InvalidAccessError");
+		}
+		this._data.stores.set(name, new StoreData(name, keyPath, autoIncrement));
+		return tx.objectStore(name);
+	}
+
+	deleteObjectStore(name) {
+		const tx = this._upgrading("deleteObjectStore");
+		name = String(name);
+		if (!this._data.stores.delete(name)) {
+			throw domException("Failed to execute 'deleteObjectStore' on 'IDBDatabase': The specified object store was not found.", "NotFoundError");
+		}
+		tx._stores.delete(name);
+	}
+
+	transaction(names, mode = "readonly") {
+		if (this._closed) {
+			throw domException("Failed to execute 'transaction' on 'IDBDatabase': The database connection is closing.", "InvalidStateError");
+		}
+		if (this._upgrade && this._upgrade._state !== "finished") {
+			throw domException("Failed to execute 'transaction' on 'IDBDatabase': A version change transaction is running.", "InvalidStateError");
+		}
+		names = [...new Set(typeof names === "string" ? [names] : [...names].map(String))];
+		for (const name of names) {
+			if (!this._data.stores.has(name)) {
+				throw domException("Failed to execute 'transaction' on 'IDBDatabase': One of the specified object stores was not found.", "NotFoundError");
+			}
+		}
+		if (names.length === 0) {
+			throw domException("Failed to execute 'transaction' on 'IDBDatabase': The storeNames parameter was empty.", "InvalidAccessError");
+		}
+		if (mode !== "readonly" && mode !== "readwrite") {
+			throw new TypeError(`Failed to execute 'transaction' on 'IDBDatabase': The provided value '${mode}' is not a valid enum value of type IDBTransactionMode.`);
+		}
+		return new IDBTransaction(this, names, mode);
+	}
+
+	close() {
+		if (this._closed) {
+			return;
+		}
+		this._closed = true;
+		const data = this._data;
+		data.connections.delete(this);
+		if (data.connections.size === 0 && data.pending.length > 0) {
+			const pending = data.pending;
+			data.pending = [];
+			setTimeout(() => pending.forEach((retry) => retry()));
+		}
+	}
+}
+
+// IDBSource holds the read operations shared by object stores and indexes
+class IDBSource {
+	get _transaction() {
+		return this instanceof IDBIndex ? this.objectStore.transaction : this.transaction;
+	}
+
+	// _entries lists the entries of the source in the range
+	_entries(range) {
+		const entries = this instanceof IDBIndex ? this.objectStore._store.entries(this._index) : this._store.entries(null);
+		return range === null ? entries : entries.filter((e) => range._contains(e.key));
+	}
+
+	_read(query, required, operation) {
+		const tx = this._transaction;
+		tx._check(false);
+		const range = toRange(query, required);
+		return tx._enqueue(this, () => operation(this._entries(range)));
+	}
+
+	get(query) {
+		return this._read(query, true, (entries) => entries.length > 0 ? structuredClone(entries[0].value) : undefined);
+	}
+
+	getKey(query) {
+		return this._read(query, true, (entries) => cloneKey(entries[0]?.primaryKey));
+	}
+
+	getAll(query, count) {
+		return this._read(query, false, (entries) => entries.slice(0, count || undefined).map((e) => structuredClone(e.value)));
+	}
+
+	getAllKeys(query, count) {
+		return this._read(query, false, (entries) => entries.slice(0, count || undefined).map((e) => cloneKey(e.primaryKey)));
+	}
+
+	count(query) {
+		return this._read(query, false, (entries) => entries.length);
+	}
+
+	openCursor(query, direction = "next") {
+		return this._openCursor(query, direction, IDBCursorWithValue);
+	}
+
+	openKeyCursor(query, direction = "next") {
+		return this._openCursor(query, direction, IDBCursor);
+	}
+
+	_openCursor(query, direction, Cursor) {
+		const tx = this._transaction;
+		tx._check(false);
+		const range = toRange(query, false);
+		if (!["next", "nextunique", "prev", "prevunique"].includes(direction)) {
+			throw new TypeError(`Failed to execute 'openCursor': The provided value '${direction}' is not a valid enum value of type IDBCursorDirection.`);
+		}
+		const request = new IDBRequest(this, tx);
+		const cursor = new Cursor(this, direction, range, request);
+		return tx._enqueue(this, () => cursor._iterate(undefined, 1), request);
+	}
+}
+
+class IDBObjectStore extends IDBSource {
+	constructor(transaction, name) {
+		super();
+		this.transaction = transaction;
+		this._name = name;
+		this._indexes = new Map();
+	}
+
+	get name() {
+		return this._name;
+	}
+
+	get _store() {
+		const store = this.transaction.db._data.stores.get(this._name);
+		if (!store) {
+			throw domException("The object store has been deleted.", "InvalidStateError");
+		}
+		return store;
+	}
+
+	get keyPath() {
+		const keyPath = this._store.keyPath;
+		return Array.isArray(keyPath) ? keyPath.slice() : keyPath;
+	}
+
+	get autoIncrement() {
+		return this._store.autoIncrement;
+	}
+
+	get indexNames() {
+		return stringList(this._store.indexes.keys());
+	}
+
+	put(value, key) {
+		return this._put(value, key, false);
+	}
+
+	add(value, key) {
+		return this._put(value, key, true);
+	}
+
+	_put(value, key, noOverwrite, request) {
+		this.transaction._check(true);
+		const store = this._store;
+		if (store.keyPath !== null && key !== undefined) {
+			throw domException("The object store uses in-line keys and the key parameter was provided.", "DataError");
+		}
+		if (store.keyPath === null && !store.autoIncrement && key === undefined) {
+			throw domException("The object store uses out-of-line keys and has no key generator and the key parameter was not provided.", "DataError");
+		}
+		if (key !== undefined) {
+			key = toKey(key);
+		}
+		value = cloneValue(value);
+		let inject = false;
+		if (store.keyPath !== null) {
+			const k = evaluateKeyPath(value, store.keyPath);
+			if (k !== undefined) {
+				try {
+					key = toKey(k);
+				} catch {
+					throw domException("Evaluating the object store's key path yielded a value that is not a valid key.", "DataError");
+				}
+			} else if (!store.autoIncrement) {
+				throw domException("Evaluating the object store's key path did not yield a value.", "DataError");
+			} else if (!injectKey(value, store.keyPath, 0, true)) {
+				throw domException("A generated key could not be inserted into the value.", "DataError");
+			} else {
+				inject = true;
+			}
+		}
+		return this.transaction._enqueue(this, () => {
+			const s = this._store;
+			let k = key;
+			if (k === undefined) {
+				if (s.current > Number.MAX_SAFE_INTEGER) {
+					throw domException("The key generator has reached its maximum value.", "ConstraintError");
+				}
+				k = s.current++;
+				if (inject) {
+					injectKey(value, s.keyPath, k, false);
+				}
+			} else if (s.autoIncrement && typeof k === "number" && k >= s.current) {
+				s.current = Math.floor(k) + 1;
+			}
+			s.put(k, value, noOverwrite);
+			return cloneKey(k);
+		}, request);
+	}
+
+	delete(query) {
+		this.transaction._check(true);
+		const range = toRange(query, true);
+		return this.transaction._enqueue(this, () => {
+			const s = this._store;
+			s.records = s.records.filter((r) => !range._contains(r.key));
+		});
+	}
+
+	clear() {
+		this.transaction._check(true);
+		return this.transaction._enqueue(this, () => {
+			this._store.records = [];
+		});
+	}
+
+	index(name) {
+		if (this.transaction._state === "finished") {
+			throw domException("Failed to execute 'index' on 'IDBObjectStore': The transaction has finished.", "InvalidStateError");
+		}
+		name = String(name);
+		if (!this._store.indexes.has(name)) {
+			throw domException("Failed to execute 'index' on 'IDBObjectStore': The specified index was not found.", "NotFoundError");
+		}
+		if (!this._indexes.has(name)) {
+			this._indexes.set(name, new IDBIndex(this, name));
+		}
+		return this._indexes.get(name);
+	}
+
+	createIndex(name, keyPath, options = {}) {
+		const tx = this.transaction;
+		if (tx.mode !== "versionchange") {
+			throw domException("Failed to execute 'createIndex' on 'IDBObjectStore': The database is not running a version change transaction.", "InvalidStateError");
+		}
+		tx._check(false);
+		name = String(name);
+		const store = this._store;
+		if (store.indexes.has(name)) {
+			throw domException(`Failed to execute 'createIndex' on 'IDBObjectStore': An index with the specified name already exists.`, "ConstraintError");
+		}
+		if (!validKeyPath(keyPath)) {
+			throw domException("Failed to execute 'createIndex' on 'IDBObjectStore': The keyPath argument contains an invalid key path.", "SyntaxError");
+		}
+		const multiEntry = !!options.multiEntry;
+		if (multiEntry && Array.isArray(keyPath)) {
+			throw domException("Failed to execute 'createIndex' on 'IDBObjectStore': The keyPath argument was an array and the multiEntry option is true.", "InvalidAccessError");
+		}
+		const index = { name, keyPath, unique: !!options.unique, multiEntry };
+		store.indexes.set(name, index);
+		if (index.unique) {
+			// The index is filled by a request of the transaction, which
+			// aborts it if the existing records break the constraint
+			tx._enqueue(this, () => {
+				const entries = this._store.entries(index);
+				if (entries.some((e, i) => i > 0 && compareKeys(entries[i - 1].key, e.key) === 0)) {
+					throw domException(`Unable to create index '${name}': the existing records do not satisfy the uniqueness requirements.`, "ConstraintError");
+				}
+			});
+		}
+		return this.index(name);
+	}
+
+	deleteIndex(name) {
+		const tx = this.transaction;
+		if (tx.mode !== "versionchange") {
+			throw domException("Failed to execute 'deleteIndex' on 'IDBObjectStore': The database is not running a version change transaction.", "InvalidStateError");
+		}
+		tx._check(false);
+		name = String(name);
+		if (!this._store.indexes.delete(name)) {
+			throw domException("Failed to execute 'deleteIndex' on 'IDBObjectStore': The specified index was not found.", "NotFoundError");
+		}
+		this._indexes.delete(name);
+	}
+}
+
+class IDBIndex extends IDBSource {
+	constructor(objectStore, name) {
+		super();
+		this.objectStore = objectStore;
+		this._name = name;
+	}
+
+	get _index() {
+		const index = this.objectStore._store.indexes.get(this._name);
+		if (!index) {
+			throw domException("The index has been deleted.", "InvalidStateError");
+		}
+		return index;
+	}
+
+	get name() { return this._name; }
+	get keyPath() { return this._index.keyPath; }
+	get unique() { return this._index.unique; }
+	get multiEntry() { return this._index.multiEntry; }
+}
+
+class IDBCursor {
+	constructor(source, direction, range, request) {
+		this.source = source;
+		this.direction = direction;
+		this.request = request;
+		this.key = undefined;
+		this.primaryKey = undefined;
+		this._range = range;
+		this._position = null;
+		this._gotValue = false;
+	}
+
+	get _transaction() {
+		return this.source._transaction;
+	}
+
+	get _effectiveStore() {
+		return this.source instanceof IDBIndex ? this.source.objectStore : this.source;
+	}
+
+	// _iterate moves the cursor count times, to the first entry at or past
+	// key if one is given. It returns the cursor, or null past the end.
+	_iterate(key, count) {
+		const forward = this.direction.startsWith("next");
+		const unique = this.direction.endsWith("unique");
+		const entries = this.source._entries(this._range);
+		if (!forward) {
+			entries.reverse();
+		}
+		const sign = forward ? 1 : -1;
+		let position = this._position;
+		for (let n = 0; n < count && position !== undefined; n++) {
+			let found = entries.find((e) => {
+				if (key !== undefined && sign * compareKeys(e.key, key) < 0) {
+					return false;
+				}
+				if (position === null) {
+					return true;
+				}
+				const c = sign * compareKeys(e.key, position.key);
+				return c > 0 || (c === 0 && !unique && sign * compareKeys(e.primaryKey, position.primaryKey) > 0);
+			});
+			if (found && unique && !forward) {
+				// The first record with the key
+				found = entries.findLast((e) => compareKeys(e.key, found.key) === 0);
+			}
+			position = found;
+			key = undefined;
+		}
+		this._position = position;
+		if (position === undefined) {
+			this.key = this.primaryKey = undefined;
+			if (this instanceof IDBCursorWithValue) {
+				this.value = undefined;
+			}
+			return null;
+		}
+		this.key = cloneKey(position.key);
+		this.primaryKey = cloneKey(position.primaryKey);
+		if (this instanceof IDBCursorWithValue) {
+			this.value = structuredClone(position.value);
+		}
+		this._gotValue = true;
+		return this;
+	}
+
+	_checkIterable(method) {
+		this._transaction._check(false);
+		if (!this._gotValue) {
+			throw domException(`Failed to execute '${method}' on 'IDBCursor': The cursor is being iterated or has iterated past its end.`, "InvalidStateError");
+		}
+	}
+
+	continue(key) {
+		this._checkIterable("continue");
+		if (key !== undefined) {
+			key = toKey(key);
+			const c = compareKeys(key, this.key);
+			if (this.direction.startsWith("next") ? c <= 0 : c >= 0) {
+				throw domException("Failed to execute 'continue' on 'IDBCursor': The parameter is not past the cursor's position.", "DataError");
+			}
+		}
+		this._gotValue = false;
+		this._transaction._enqueue(this.source, () => this._iterate(key, 1), this.request);
+	}
+
+	advance(count) {
+		if (!Number.isInteger(count) || count <= 0) {
+			throw new TypeError("Failed to execute 'advance' on 'IDBCursor': A count argument with value 0 (zero) was supplied, must be greater than 0.");
+		}
+		this._checkIterable("advance");
+		this._gotValue = false;
+		this._transaction._enqueue(this.source, () => this._iterate(undefined, count), this.request);
+	}
+
+	update(value) {
+		this._transaction._check(true);
+		this._checkIterable("update");
+		const store = this._effectiveStore;
+		if (store._store.keyPath !== null) {
+			const k = evaluateKeyPath(value, store._store.keyPath);
+			let same = false;
+			try {
+				same = k !== undefined && compareKeys(toKey(k), this.primaryKey) === 0;
+			} catch {
+				// Not a valid key
+			}
+			if (!same) {
+				throw domException("Failed to execute 'update' on 'IDBCursor': The effective object store of this cursor uses in-line keys and evaluating the key path of the value parameter results in a different value than the cursor's effective key.", "DataError");
+			}
+			return store._put(value, undefined, false, new IDBRequest(this, this._transaction));
+		}
+		return store._put(value, this.primaryKey, false, new IDBRequest(this, this._transaction));
+	}
+
+	delete() {
+		this._transaction._check(true);
+		this._checkIterable("delete");
+		const key = this.primaryKey;
+		const store = this._effectiveStore;
+		return this._transaction._enqueue(this, () => {
+			const s = store._store;
+			const { i, found } = s.find(key);
+			if (found) {
+				s.records.splice(i, 1);
+			}
+		});
+	}
+}
+
+class IDBCursorWithValue extends IDBCursor {
+	constructor(source, direction, range, request) {
+		super(source, direction, range, request);
+		this.value = undefined;
+	}
+}
+
+// waitForConnections asks the other connections to close before a version
+// change or deletion. It returns false if they are still open, in which
+// case retry is called once they all close.
+function waitForConnections(data, request, newVersion, retry) {
+	for (const db of [...data.connections]) {
+		db.dispatchEvent(new IDBVersionChangeEvent("versionchange", { oldVersion: data.version, newVersion }));
+	}
+	if (data.connections.size === 0) {
+		return true;
+	}
+	request.dispatchEvent(new IDBVersionChangeEvent("blocked", { oldVersion: data.version, newVersion }));
+	data.pending.push(retry);
+	return false;
+}
+
+function openDatabase(request, name, version) {
+	let data = idbDatabases.get(name);
+	if (!data) {
+		data = new DatabaseData(name);
+		idbDatabases.set(name, data);
+	}
+	version ??= Math.max(data.version, 1);
+	if (version < data.version) {
+		request._fail(domException(`The requested version (${version}) is less than the existing version (${data.version}).`, "VersionError"));
+		return;
+	}
+	if (version === data.version) {
+		request._succeed(new IDBDatabase(data));
+		return;
+	}
+	if (!waitForConnections(data, request, version, () => openDatabase(request, name, version))) {
+		return;
+	}
+
+	const oldVersion = data.version;
+	const db = new IDBDatabase(data);
+	const tx = new IDBTransaction(db, null, "versionchange");
+	db._upgrade = tx;
+	data.version = db.version = version;
+	request.transaction = tx;
+	tx._oncommitted = () => {
+		request.transaction = null;
+		request._succeed(db);
+	};
+	tx._onaborted = () => {
+		db.version = oldVersion;
+		db.close();
+		request.transaction = null;
+		request._fail(domException("The version change transaction was aborted.", "AbortError"));
+	};
+	request.readyState = "done";
+	request._result = db;
+	tx._active = true;
+	request.dispatchEvent(new IDBVersionChangeEvent("upgradeneeded", { oldVersion, newVersion: version }));
+	tx._active = false;
+}
+
+function deleteDatabase(request, name) {
+	const data = idbDatabases.get(name);
+	if (data && !waitForConnections(data, request, null, () => deleteDatabase(request, name))) {
+		return;
+	}
+	idbDatabases.delete(name);
+	request._succeed(undefined, new IDBVersionChangeEvent("success", { oldVersion: data?.version ?? 0, newVersion: null }));
+}
+
+class IDBFactory {
+	open(name, version) {
+		if (arguments.length < 1) {
+			throw new TypeError("Failed to execute 'open' on 'IDBFactory': 1 argument required, but only 0 present.");
+		}
+		if (version !== undefined && (!Number.isInteger(version) || version < 1 || version > Number.MAX_SAFE_INTEGER)) {
+			throw new TypeError("Failed to execute 'open' on 'IDBFactory': The version provided must be a positive integer.");
+		}
+		const request = new IDBOpenDBRequest();
+		setTimeout(() => openDatabase(request, String(name), version));
+		return request;
+	}
+
+	deleteDatabase(name) {
+		if (arguments.length < 1) {
+			throw new TypeError("Failed to execute 'deleteDatabase' on 'IDBFactory': 1 argument required, but only 0 present.");
+		}
+		const request = new IDBOpenDBRequest();
+		setTimeout(() => deleteDatabase(request, String(name)));
+		return request;
+	}
+
+	databases() {
+		return Promise.resolve([...idbDatabases.values()].filter((d) => d.version > 0).map((d) => ({ name: d.name, version: d.version })));
+	}
+
+	cmp(a, b) {
+		return compareKeys(toKey(a), toKey(b));
+	}
+}
+
+// install sets up an IndexedDB without databases
+function install() {
+	idbDatabases.clear();
+	Object.assign(globalThis, {
+		IDBFactory, IDBDatabase, IDBTransaction, IDBRequest, IDBOpenDBRequest, IDBObjectStore, IDBIndex,
+		IDBCursor, IDBCursorWithValue, IDBKeyRange, IDBVersionChangeEvent,
+		indexedDB: new IDBFactory(),
+	});
+}
+
+module.exports = { install };
@@ -0,0 +1,209 @@
+// Command wasmtest runs the tests of packages compiled for js/wasm under
+// Node.js, with a lightweight DOM installed so the dom package can be tested
+// without a browser, or in headless Chrome.
+//
+// Given packages, wasmtest builds and runs their tests with go test. Flags
+// such as -run and -v are passed through:
+//
+//	go run ./cmd/wasmtest -v -run TestDOM ./js ./dom/test
+//
+// Given a test binary, wasmtest runs it like go_js_wasm_exec, so it can also
+// be used as the -exec program of go test:
+//
+//	go build -o wasmtest ./cmd/wasmtest
+//	GOOS=js GOARCH=wasm go test -exec="$PWD/wasmtest" ./...
+//
+// Node.js is found in PATH, or at the path in the NODE environment variable.
+// The exit code is the exit code of the tests.
+//
+// The DOM installed under Node.js is deliberately minimal: the node tree,
+// attributes, inline styles, selectors and events, following the DOM
+// standard, and in-memory Web Storage and IndexedDB. Tests of other APIs
+// skip themselves under Node.js. If the WASMTEST_BROWSER environment
+// variable is set to the path of Chrome or Chromium, the tests run in a page
+// of the headless browser instead, with its full DOM, storage and IndexedDB:
+//
+//	WASMTEST_BROWSER=/usr/bin/chromium go run ./cmd/wasmtest ./...
+//
+// The browser is killed if the tests have not finished shortly after their
+// -timeout. Of the host environment, only the variables read by the Go
+// runtime, such as GODEBUG, are passed to the page.
+package main
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+//go:embed runner.js dom.js storage.js indexeddb.js
+var scripts embed.FS
+
+// testFlags are the flags of test binaries that may be given without their
+// "test." prefix when running a binary directly
+var testFlags = map[string]bool{
+	"bench": true, "benchmem": true, "benchtime": true, "count": true, "cpu": true,
+	"failfast": true, "list": true, "parallel": true, "run": true, "short": true,
+	"shuffle": true, "skip": true, "timeout": true, "v": true,
+}
+
+func main() {
+	code, err := run(os.Args[1:])
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "wasmtest:", err)
+	}
+	os.Exit(code)
+}
+
+func run(args []string) (int, error) {
+	if len(args) > 0 && isWasm(args[0]) {
+		return runBinary(args[0], args[1:])
+	}
+	return runPackages(args)
+}
+
+// isWasm reports whether path is a WebAssembly binary
+func isWasm(path string) bool {
+	f, err := os.Open(path)
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+	magic := make([]byte, 4)
+	if _, err := io.ReadFull(f, magic); err != nil {
+		return false
+	}
+	return bytes.Equal(magic, []byte("\x00asm"))
+}
+
+// runPackages runs go test for js/wasm with wasmtest as the -exec program
+func runPackages(args []string) (int, error) {
+	self, err := os.Executable()
+	if err != nil {
+		return 1, err
+	}
+	cmd := exec.Command("go", append([]string{"test", "-exec=" + quote(self)}, args...)...)
+	cmd.Env = append(os.Environ(), "GOOS=js", "GOARCH=wasm")
+	return execute(cmd)
+}
+
+// runBinary runs a wasm binary under Node.js with the DOM installed, or in
+// the browser given by WASMTEST_BROWSER
+func runBinary(binary string, args []string) (int, error) {
+	wasmExec, err := wasmExecPath()
+	if err != nil {
+		return 1, err
+	}
+	if browser := os.Getenv("WASMTEST_BROWSER"); browser != "" {
+		binary, err = filepath.Abs(binary)
+		if err != nil {
+			return 1, err
+		}
+		return runBrowser(browser, binary, wasmExec, expandTestFlags(args))
+	}
+	node, err := nodePath()
+	if err != nil {
+		return 1, err
+	}
+
+	dir, err := os.MkdirTemp("", "wasmtest")
+	if err != nil {
+		return 1, err
+	}
+	defer os.RemoveAll(dir)
+	for _, name := range []string{"runner.js", "dom.js", "storage.js", "indexeddb.js"} {
+		data, err := scripts.ReadFile(name)
+		if err != nil {
+			return 1, err
+		}
+		if err := os.WriteFile(filepath.Join(dir, name), data, 0o644); err != nil {
+			return 1, err
+		}
+	}
+
+	binary, err = filepath.Abs(binary)
+	if err != nil {
+		return 1, err
+	}
+	// Increase the V8 stack size like go_js_wasm_exec does
+	nodeArgs := []string{"--stack-size=8192", filepath.Join(dir, "runner.js"), wasmExec, binary}
+	cmd := exec.Command(node, append(nodeArgs, expandTestFlags(args)...)...)
+	return execute(cmd)
+}
+
+// expandTestFlags adds the "test." prefix to test flags given without it
+func expandTestFlags(args []string) []string {
+	expanded := make([]string, len(args))
+	for i, arg := range args {
+		expanded[i] = arg
+		name := strings.TrimLeft(arg, "-")
+		if name == arg {
+			continue
+		}
+		if eq := strings.IndexByte(name, '='); eq >= 0 {
+			name = name[:eq]
+		}
+		if testFlags[name] {
+			expanded[i] = "-test." + strings.TrimLeft(arg, "-")
+		}
+	}
+	return expanded
+}
+
+// execute runs cmd connected to the standard streams and returns its exit code
+func execute(cmd *exec.Cmd) (int, error) {
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	err := cmd.Run()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.ExitCode(), nil
+	}
+	if err != nil {
+		return 1, err
+	}
+	return 0, nil
+}
+
+func nodePath() (string, error) {
+	if node := os.Getenv("NODE"); node != "" {
+		return node, nil
+	}
+	node, err := exec.LookPath("node")
+	if err != nil {
+		return "", errors.New("node not found in PATH; install Node.js or set NODE")
+	}
+	return node, nil
+}
+
+// wasmExecPath finds wasm_exec.js in the Go installation. It moved from
+// misc/wasm to lib/wasm in Go 1.24.
+func wasmExecPath() (string, error) {
+	goroot := runtime.GOROOT()
+	if out, err := exec.Command("go", "env", "GOROOT").Output(); err == nil {
+		goroot = strings.TrimSpace(string(out))
+	}
+	for _, dir := range []string{"lib", "misc"} {
+		path := filepath.Join(goroot, dir, "wasm", "wasm_exec.js")
+		if _, err := os.Stat(path); err == nil {
+			return path, nil
+		}
+	}
+	return "", fmt.Errorf("wasm_exec.js not found in %s", goroot)
+}
+
+// quote quotes path for go test's -exec flag if it contains spaces
+func quote(path string) string {
+	if strings.ContainsAny(path, " \t") {
+		return "'" + path + "'"
+	}
+	return path
+}
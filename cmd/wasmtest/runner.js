@@ -0,0 +1,45 @@
+// Runs a Go program compiled for js/wasm under Node.js, like the
+// wasm_exec_node.js that ships with Go, after installing the DOM from dom.js,
+// Web Storage from storage.js and the IndexedDB from indexeddb.js.
+//
+// usage: node runner.js [wasm_exec.js] [wasm binary] [arguments]
+
+"use strict";
+
+if (process.argv.length < 4) {
+	console.error("usage: node runner.js [wasm_exec.js] [wasm binary] [arguments]");
+	process.exit(1);
+}
+
+globalThis.require = require;
+globalThis.fs = require("fs");
+globalThis.path = require("path");
+globalThis.TextEncoder = require("util").TextEncoder;
+globalThis.TextDecoder = require("util").TextDecoder;
+
+globalThis.performance ??= require("perf_hooks").performance;
+
+globalThis.crypto ??= require("crypto");
+
+require("./dom.js").install();
+require("./storage.js").install();
+require("./indexeddb.js").install();
+require(process.argv[2]);
+
+const go = new Go();
+go.argv = process.argv.slice(3);
+go.env = Object.assign({ TMPDIR: require("os").tmpdir() }, process.env);
+go.exit = process.exit;
+WebAssembly.instantiate(fs.readFileSync(process.argv[3]), go.importObject).then((result) => {
+	process.on("exit", (code) => { // Node.js exits if no event handler is pending
+		if (code === 0 && !go.exited) {
+			// deadlock, make Go print error and stack traces
+			go._pendingEvent = { id: 0 };
+			go._resume();
+		}
+	});
+	return go.run(result.instance);
+}).catch((err) => {
+	console.error(err);
+	process.exit(1);
+});
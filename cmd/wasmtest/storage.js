@@ -0,0 +1,93 @@
+// An in-memory Web Storage for running the web/storage tests under Node.js.
+// It is installed by runner.js after the DOM, whose Event it builds on.
+
+"use strict";
+
+const { Event } = require("./dom.js");
+
+// Each storage area holds up to STORAGE_QUOTA UTF-16 code units of keys and
+// values, like the 5 MiB per origin of browsers
+const STORAGE_QUOTA = 5 * 1024 * 1024;
+
+// Storage keeps its items in a Map, so keys are listed in the order they were
+// added
+class Storage {
+	constructor() {
+		this._items = new Map();
+		this._size = 0;
+	}
+
+	get length() {
+		return this._items.size;
+	}
+
+	key(index) {
+		if (arguments.length < 1) {
+			throw new TypeError("Failed to execute 'key' on 'Storage': 1 argument required, but only 0 present.");
+		}
+		const keys = [...this._items.keys()];
+		index = Math.trunc(Number(index));
+		return index >= 0 && index < keys.length ? keys[index] : null;
+	}
+
+	getItem(key) {
+		if (arguments.length < 1) {
+			throw new TypeError("Failed to execute 'getItem' on 'Storage': 1 argument required, but only 0 present.");
+		}
+		key = String(key);
+		return this._items.has(key) ? this._items.get(key) : null;
+	}
+
+	setItem(key, value) {
+		if (arguments.length < 2) {
+			throw new TypeError(`Failed to execute 'setItem' on 'Storage': 2 arguments required, but only ${arguments.length} present.`);
+		}
+		key = String(key);
+		value = String(value);
+		const old = this._items.get(key);
+		const size = this._size + value.length - (old === undefined ? -key.length : old.length);
+		if (size > STORAGE_QUOTA) {
+			throw new DOMException(`Failed to execute 'setItem' on 'Storage': Setting the value of '${key}' exceeded the quota.`, "QuotaExceededError");
+		}
+		this._items.set(key, value);
+		this._size = size;
+	}
+
+	removeItem(key) {
+		if (arguments.length < 1) {
+			throw new TypeError("Failed to execute 'removeItem' on 'Storage': 1 argument required, but only 0 present.");
+		}
+		key = String(key);
+		if (this._items.has(key)) {
+			this._size -= key.length + this._items.get(key).length;
+			this._items.delete(key);
+		}
+	}
+
+	clear() {
+		this._items.clear();
+		this._size = 0;
+	}
+}
+
+class StorageEvent extends Event {
+	constructor(type, init = {}) {
+		super(type, init);
+		this.key = init.key ?? null;
+		this.oldValue = init.oldValue ?? null;
+		this.newValue = init.newValue ?? null;
+		this.url = String(init.url ?? "");
+		this.storageArea = init.storageArea ?? null;
+	}
+}
+
+// install sets up empty localStorage and sessionStorage areas
+function install() {
+	Object.assign(globalThis, {
+		Storage, StorageEvent,
+		localStorage: new Storage(),
+		sessionStorage: new Storage(),
+	});
+}
+
+module.exports = { install };
@@ -0,0 +1,78 @@
+package dom
+
+import (
+	"sort"
+)
+
+// Attr is an attribute of an element
+type Attr struct {
+	// Name is the qualified name, such as "xlink:href"
+	Name      string
+	LocalName string
+	// NamespaceURI and Prefix are empty for attributes without a namespace
+	NamespaceURI string
+	Prefix       string
+	Value        string
+}
+
+// Attributes returns the element's attributes in order
+func (e *Element) Attributes() []Attr {
+	return backend().Attributes(e.Value)
+}
+
+// GetAttributeNames returns the qualified names of the element's attributes in order
+func (e *Element) GetAttributeNames() []string {
+	attrs := e.Attributes()
+	names := make([]string, len(attrs))
+	for i, attr := range attrs {
+		names[i] = attr.Name
+	}
+	return names
+}
+
+// SetAttributes sets several attributes in the order of their names.
+// It stops at the first invalid name and returns its error.
+func (e *Element) SetAttributes(attributes map[string]string) error {
+	names := make([]string, 0, len(attributes))
+	for name := range attributes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if err := backend().SetAttribute(e.Value, name, attributes[name]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ToggleAttribute adds the attribute with an empty value if force is true and
+// removes it otherwise, reporting whether it is now present
+func (e *Element) ToggleAttribute(name string, force bool) (bool, error) {
+	return backend().ToggleAttribute(e.Value, name, force)
+}
+
+// GetAttributeNS returns the value of the attribute with the given namespace
+// and local name, or an empty string if it is not set
+func (e *Element) GetAttributeNS(namespace, localName string) string {
+	value, _ := backend().GetAttributeNS(e.Value, namespace, localName)
+	return value
+}
+
+// SetAttributeNS sets the attribute with the given namespace and qualified
+// name. It returns an error if the name is invalid or its prefix does not fit
+// the namespace.
+func (e *Element) SetAttributeNS(namespace, qualifiedName, value string) error {
+	return backend().SetAttributeNS(e.Value, namespace, qualifiedName, value)
+}
+
+// RemoveAttributeNS removes the attribute with the given namespace and local name
+func (e *Element) RemoveAttributeNS(namespace, localName string) {
+	backend().RemoveAttributeNS(e.Value, namespace, localName)
+}
+
+// HasAttributeNS checks if the attribute with the given namespace and local name exists
+func (e *Element) HasAttributeNS(namespace, localName string) bool {
+	_, ok := backend().GetAttributeNS(e.Value, namespace, localName)
+	return ok
+}
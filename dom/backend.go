@@ -0,0 +1,159 @@
+package dom
+
+// Backend implements the DOM behind Document, Node, Element, Style and Event.
+// When compiled for js/wasm the package uses the browser's DOM through
+// syscall/js. Elsewhere there is no DOM until SetBackend installs one, such as
+// the in-memory DOM of package memdom, so code using the package can be tested
+// with a plain go test.
+//
+// Objects are the backend's handles to nodes, events, style declarations,
+// token lists and abort controllers. A nil Object stands for null, such as
+// the parent of a detached node. Methods returning an error fail where the
+// DOM throws an exception, for example for an invalid name or selector or an
+// insertion that would break the tree.
+type Backend interface {
+	// Document returns the global document
+	Document() Object
+
+	CreateElement(doc Object, tagName string) (Object, error)
+	CreateElementNS(doc Object, namespace, qualifiedName string) (Object, error)
+	CreateTextNode(doc Object, text string) Object
+	CreateDocumentFragment(doc Object) Object
+	GetElementByID(doc Object, id string) Object
+	Body(doc Object) Object
+	Head(doc Object) Object
+	Title(doc Object) string
+	SetTitle(doc Object, title string)
+	URL(doc Object) string
+	ReadyState(doc Object) string
+
+	// QuerySelector and QuerySelectorAll search the descendants of a
+	// document, fragment or element
+	QuerySelector(root Object, selector string) (Object, error)
+	QuerySelectorAll(root Object, selector string) ([]Object, error)
+
+	NodeType(node Object) int
+	NodeName(node Object) string
+	// NodeValue returns the node value, or an empty string if it is null
+	NodeValue(node Object) string
+	SetNodeValue(node Object, value string)
+	ParentNode(node Object) Object
+	ParentElement(node Object) Object
+	ChildNodes(node Object) []Object
+	FirstChild(node Object) Object
+	LastChild(node Object) Object
+	PreviousSibling(node Object) Object
+	NextSibling(node Object) Object
+	OwnerDocument(node Object) Object
+	CloneNode(node Object, deep bool) Object
+	CompareDocumentPosition(node, other Object) int
+	Contains(node, other Object) bool
+	IsEqualNode(node, other Object) bool
+	IsSameNode(node, other Object) bool
+	InsertBefore(parent, node, child Object) error
+	ReplaceChild(parent, node, child Object) error
+	RemoveChild(parent, child Object) error
+	AppendChild(parent, child Object) error
+	Normalize(node Object)
+	// LookupNamespaceURI and LookupPrefix return an empty string for null,
+	// and take one for a missing prefix or namespace
+	LookupNamespaceURI(node Object, prefix string) string
+	LookupPrefix(node Object, namespace string) string
+	BaseURI(node Object) string
+	// TextContent returns the text content, or an empty string if it is null
+	TextContent(node Object) string
+	SetTextContent(node Object, text string)
+
+	TagName(element Object) string
+	// NamespaceURI and Prefix return an empty string if they are null
+	NamespaceURI(element Object) string
+	Prefix(element Object) string
+	LocalName(element Object) string
+	InnerHTML(element Object) string
+	SetInnerHTML(element Object, html string)
+	Attributes(element Object) []Attr
+	// GetAttribute and GetAttributeNS report whether the attribute is set
+	GetAttribute(element Object, name string) (string, bool)
+	SetAttribute(element Object, name, value string) error
+	RemoveAttribute(element Object, name string)
+	ToggleAttribute(element Object, name string, force bool) (bool, error)
+	GetAttributeNS(element Object, namespace, localName string) (string, bool)
+	SetAttributeNS(element Object, namespace, qualifiedName, value string) error
+	RemoveAttributeNS(element Object, namespace, localName string)
+	Closest(element Object, selector string) (Object, error)
+	// ClassList returns the DOMTokenList of the element's classes
+	ClassList(element Object) Object
+	// Style returns the CSSStyleDeclaration of the element's inline style
+	Style(element Object) Object
+	Focus(element Object)
+	Blur(element Object)
+	Click(element Object)
+
+	// The token list methods implement DOMTokenList
+	AddTokens(list Object, tokens []string) error
+	RemoveTokens(list Object, tokens []string) error
+	ToggleToken(list Object, token string) (bool, error)
+	ToggleTokenForce(list Object, token string, force bool) (bool, error)
+	ReplaceToken(list Object, oldToken, newToken string) (bool, error)
+	ContainsToken(list Object, token string) bool
+	TokenCount(list Object) int
+	// Token returns the token at index i, reporting whether there is one
+	Token(list Object, i int) (string, bool)
+	TokenListValue(list Object) string
+
+	// The style methods implement CSSStyleDeclaration
+	SetStyleProperty(style Object, name, value, priority string)
+	StylePropertyValue(style Object, name string) string
+	StylePropertyPriority(style Object, name string) string
+	RemoveStyleProperty(style Object, name string)
+	CSSText(style Object) string
+	SetCSSText(style Object, text string)
+	StyleLength(style Object) int
+	StyleItem(style Object, i int) string
+
+	NewEvent(eventType string, init EventInit) Object
+	EventType(event Object) string
+	Target(event Object) Object
+	CurrentTarget(event Object) Object
+	EventPhase(event Object) int
+	Bubbles(event Object) bool
+	Cancelable(event Object) bool
+	TimeStamp(event Object) float64
+	DefaultPrevented(event Object) bool
+	IsTrusted(event Object) bool
+	// CancelBubble reports whether propagation of the event has been stopped
+	CancelBubble(event Object) bool
+	StopPropagation(event Object)
+	StopImmediatePropagation(event Object)
+	PreventDefault(event Object)
+
+	// AddEventListener calls handler with the events of the given type
+	// dispatched at target, which may be any node or an abort signal, and
+	// returns a function that removes the listener and releases what it
+	// holds. A listener with Once set must still be removed.
+	AddEventListener(target Object, eventType string, handler func(event Object), opts AddEventListenerOptions) (remove func())
+	DispatchEvent(target, event Object) bool
+
+	// NewAbortController returns a new abort controller and its signal, an
+	// event target that fires an abort event when Abort is called
+	NewAbortController() (controller, signal Object)
+	Abort(controller Object)
+	Aborted(signal Object) bool
+}
+
+// active is the backend in use
+var active = defaultBackend()
+
+// SetBackend replaces the DOM used by the package. Objects of the previous
+// backend must not be used afterwards.
+func SetBackend(b Backend) {
+	active = b
+}
+
+// backend returns the backend in use
+func backend() Backend {
+	if active == nil {
+		panic("dom: no backend; call SetBackend, for example with memdom.Install")
+	}
+	return active
+}
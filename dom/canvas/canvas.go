@@ -0,0 +1,197 @@
+//go:build js && wasm
+// +build js,wasm
+
+// Package canvas provides typed wrappers for canvas elements and their 2D
+// rendering context. Pixels move between the canvas and Go as image.RGBA
+// values, copied straight into the image's pixel buffer.
+//
+// The package is only available when compiled for js/wasm.
+package canvas
+
+import (
+	"github.com/abdorrahmani/go-wasm/dom"
+	"github.com/abdorrahmani/go-wasm/js"
+)
+
+// CanvasElement is a <canvas> element
+type CanvasElement struct {
+	dom.Element
+}
+
+// Blob is the image data passed to ToBlob callbacks
+type Blob struct {
+	Value *js.Value
+}
+
+// ImageSource is an element that can be drawn onto a canvas or used as a
+// pattern: a canvas, image or video element
+type ImageSource interface {
+	imageSource() *js.Value
+}
+
+// New creates a <canvas> element with the given size in pixels
+func New(doc *dom.Document, width, height int) *CanvasElement {
+	c := &CanvasElement{Element: *doc.CreateElement("canvas")}
+	c.SetWidth(width)
+	c.SetHeight(height)
+	return c
+}
+
+// AsCanvas returns e as a canvas, or nil if it is not a canvas element
+func AsCanvas(e *dom.Element) *CanvasElement {
+	if !isHTMLElement(e, "canvas") {
+		return nil
+	}
+	return &CanvasElement{Element: *e}
+}
+
+// isHTMLElement reports whether e is the HTML element with the given local name
+func isHTMLElement(e *dom.Element, localName string) bool {
+	return e != nil && e.GetNamespaceURI() == dom.XHTMLNamespace && e.GetLocalName() == localName
+}
+
+func (c *CanvasElement) imageSource() *js.Value {
+	return c.Value
+}
+
+// GetWidth returns the width of the bitmap in pixels
+func (c *CanvasElement) GetWidth() int {
+	return c.Value.Get("width").MustInt()
+}
+
+// SetWidth sets the width of the bitmap in pixels, clearing it
+func (c *CanvasElement) SetWidth(width int) {
+	c.Value.Set("width", width)
+}
+
+// GetHeight returns the height of the bitmap in pixels
+func (c *CanvasElement) GetHeight() int {
+	return c.Value.Get("height").MustInt()
+}
+
+// SetHeight sets the height of the bitmap in pixels, clearing it
+func (c *CanvasElement) SetHeight(height int) {
+	c.Value.Set("height", height)
+}
+
+// GetContext2D returns the canvas' 2D rendering context. It fails if the
+// canvas already has a context of another type.
+func (c *CanvasElement) GetContext2D() (*Context2D, error) {
+	context, err := c.Value.CallE("getContext", "2d")
+	if err != nil {
+		return nil, err
+	}
+	if context.IsNull() {
+		return nil, &js.Error{Name: "InvalidStateError", Message: "the canvas has a context of another type"}
+	}
+	return &Context2D{Value: context}, nil
+}
+
+// ToDataURL encodes the bitmap as a data URL in the given image format, such
+// as "image/png", falling back to PNG if the format is empty or unsupported
+func (c *CanvasElement) ToDataURL(mimeType string) string {
+	if mimeType == "" {
+		return c.Value.Call("toDataURL").MustString()
+	}
+	return c.Value.Call("toDataURL", mimeType).MustString()
+}
+
+// ToBlob encodes the bitmap in the given image format and calls fn with the
+// result, or with nil if the canvas is empty. fn may run after ToBlob returns.
+func (c *CanvasElement) ToBlob(mimeType string, fn func(*Blob)) error {
+	callback := js.NewOnceCallback(func(args []*js.Value) {
+		if len(args) == 0 || args[0].IsNull() || args[0].IsUndefined() {
+			fn(nil)
+			return
+		}
+		fn(&Blob{Value: args[0]})
+	})
+	args := []interface{}{callback.Value()}
+	if mimeType != "" {
+		args = append(args, mimeType)
+	}
+	if _, err := c.Value.CallE("toBlob", args...); err != nil {
+		callback.Release()
+		return err
+	}
+	return nil
+}
+
+// GetSize returns the size of the blob in bytes
+func (b *Blob) GetSize() int {
+	return b.Value.Get("size").MustInt()
+}
+
+// GetType returns the MIME type of the blob
+func (b *Blob) GetType() string {
+	return b.Value.Get("type").MustString()
+}
+
+// ImageElement is an <img> element
+type ImageElement struct {
+	dom.Element
+}
+
+// AsImage returns e as an image, or nil if it is not an img element
+func AsImage(e *dom.Element) *ImageElement {
+	if !isHTMLElement(e, "img") {
+		return nil
+	}
+	return &ImageElement{Element: *e}
+}
+
+func (i *ImageElement) imageSource() *js.Value {
+	return i.Value
+}
+
+// GetSrc returns the URL of the image
+func (i *ImageElement) GetSrc() string {
+	return i.Value.Get("src").TryString("")
+}
+
+// SetSrc sets the URL of the image, starting to load it
+func (i *ImageElement) SetSrc(src string) {
+	i.Value.Set("src", src)
+}
+
+// GetComplete reports whether the image has finished loading
+func (i *ImageElement) GetComplete() bool {
+	return i.Value.Get("complete").TryBool(false)
+}
+
+// GetNaturalWidth returns the intrinsic width of the image, or 0 if it is not loaded
+func (i *ImageElement) GetNaturalWidth() int {
+	return i.Value.Get("naturalWidth").TryInt(0)
+}
+
+// GetNaturalHeight returns the intrinsic height of the image, or 0 if it is not loaded
+func (i *ImageElement) GetNaturalHeight() int {
+	return i.Value.Get("naturalHeight").TryInt(0)
+}
+
+// VideoElement is a <video> element, drawn using its current frame
+type VideoElement struct {
+	dom.Element
+}
+
+// AsVideo returns e as a video, or nil if it is not a video element
+func AsVideo(e *dom.Element) *VideoElement {
+	if !isHTMLElement(e, "video") {
+		return nil
+	}
+	return &VideoElement{Element: *e}
+}
+
+func (v *VideoElement) imageSource() *js.Value {
+	return v.Value
+}
+
+// GetVideoWidth returns the intrinsic width of the video, or 0 if it is not loaded
+func (v *VideoElement) GetVideoWidth() int {
+	return v.Value.Get("videoWidth").TryInt(0)
+}
+
+// GetVideoHeight returns the intrinsic height of the video, or 0 if it is not loaded
+func (v *VideoElement) GetVideoHeight() int {
+	return v.Value.Get("videoHeight").TryInt(0)
+}
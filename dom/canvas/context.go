@@ -0,0 +1,379 @@
+//go:build js && wasm
+// +build js,wasm
+
+package canvas
+
+import (
+	"strconv"
+
+	"github.com/abdorrahmani/go-wasm/dom"
+	"github.com/abdorrahmani/go-wasm/js"
+)
+
+// Context2D is the 2D rendering context of a canvas
+type Context2D struct {
+	Value *js.Value
+}
+
+// Matrix is a 2D affine transform mapping (x, y) to
+// (A*x + C*y + E, B*x + D*y + F)
+type Matrix struct {
+	A, B, C, D, E, F float64
+}
+
+// Identity is the transform that leaves points unchanged
+var Identity = Matrix{A: 1, D: 1}
+
+// FillRule decides which points are inside a path that crosses itself
+type FillRule string
+
+// Fill rules
+const (
+	NonZero FillRule = "nonzero"
+	EvenOdd FillRule = "evenodd"
+)
+
+// LineCap is the shape of the ends of stroked lines
+type LineCap string
+
+// Line caps
+const (
+	ButtCap   LineCap = "butt"
+	RoundCap  LineCap = "round"
+	SquareCap LineCap = "square"
+)
+
+// LineJoin is the shape of the corners of stroked lines
+type LineJoin string
+
+// Line joins
+const (
+	MiterJoin LineJoin = "miter"
+	RoundJoin LineJoin = "round"
+	BevelJoin LineJoin = "bevel"
+)
+
+// TextMetrics holds the dimensions of measured text in pixels
+type TextMetrics struct {
+	Width                    float64
+	ActualBoundingBoxLeft    float64
+	ActualBoundingBoxRight   float64
+	ActualBoundingBoxAscent  float64
+	ActualBoundingBoxDescent float64
+	FontBoundingBoxAscent    float64
+	FontBoundingBoxDescent   float64
+}
+
+// GetCanvas returns the canvas the context draws onto
+func (c *Context2D) GetCanvas() *CanvasElement {
+	return AsCanvas(&dom.Element{Value: c.Value.Get("canvas")})
+}
+
+// Save pushes the drawing state, including the transform, styles and
+// clipping region, onto a stack
+func (c *Context2D) Save() {
+	c.Value.Call("save")
+}
+
+// Restore pops the drawing state saved by the last Save
+func (c *Context2D) Restore() {
+	c.Value.Call("restore")
+}
+
+// Translate moves the origin of the transform
+func (c *Context2D) Translate(x, y float64) {
+	c.Value.Call("translate", x, y)
+}
+
+// Scale scales the transform
+func (c *Context2D) Scale(x, y float64) {
+	c.Value.Call("scale", x, y)
+}
+
+// Rotate rotates the transform clockwise by angle radians
+func (c *Context2D) Rotate(angle float64) {
+	c.Value.Call("rotate", angle)
+}
+
+// Transform multiplies the current transform by m
+func (c *Context2D) Transform(m Matrix) {
+	c.Value.Call("transform", m.A, m.B, m.C, m.D, m.E, m.F)
+}
+
+// SetTransform replaces the current transform
+func (c *Context2D) SetTransform(m Matrix) {
+	c.Value.Call("setTransform", m.A, m.B, m.C, m.D, m.E, m.F)
+}
+
+// GetTransform returns the current transform
+func (c *Context2D) GetTransform() Matrix {
+	m := c.Value.Call("getTransform")
+	return Matrix{
+		A: m.Get("a").MustFloat(), B: m.Get("b").MustFloat(),
+		C: m.Get("c").MustFloat(), D: m.Get("d").MustFloat(),
+		E: m.Get("e").MustFloat(), F: m.Get("f").MustFloat(),
+	}
+}
+
+// ResetTransform sets the transform to Identity
+func (c *Context2D) ResetTransform() {
+	c.Value.Call("resetTransform")
+}
+
+// BeginPath starts a new, empty path
+func (c *Context2D) BeginPath() {
+	c.Value.Call("beginPath")
+}
+
+// ClosePath adds a line back to the start of the current subpath
+func (c *Context2D) ClosePath() {
+	c.Value.Call("closePath")
+}
+
+// MoveTo starts a new subpath at (x, y)
+func (c *Context2D) MoveTo(x, y float64) {
+	c.Value.Call("moveTo", x, y)
+}
+
+// LineTo adds a line to (x, y)
+func (c *Context2D) LineTo(x, y float64) {
+	c.Value.Call("lineTo", x, y)
+}
+
+// QuadraticCurveTo adds a quadratic Bézier curve to (x, y)
+func (c *Context2D) QuadraticCurveTo(cpx, cpy, x, y float64) {
+	c.Value.Call("quadraticCurveTo", cpx, cpy, x, y)
+}
+
+// BezierCurveTo adds a cubic Bézier curve to (x, y)
+func (c *Context2D) BezierCurveTo(cp1x, cp1y, cp2x, cp2y, x, y float64) {
+	c.Value.Call("bezierCurveTo", cp1x, cp1y, cp2x, cp2y, x, y)
+}
+
+// Arc adds a circular arc centered at (x, y) from the start to the end angle,
+// in radians. It fails if the radius is negative.
+func (c *Context2D) Arc(x, y, radius, startAngle, endAngle float64, counterclockwise bool) error {
+	_, err := c.Value.CallE("arc", x, y, radius, startAngle, endAngle, counterclockwise)
+	return err
+}
+
+// ArcTo adds an arc with the given radius that is tangent to the line from
+// the current point to (x1, y1) and the line from (x1, y1) to (x2, y2).
+// It fails if the radius is negative.
+func (c *Context2D) ArcTo(x1, y1, x2, y2, radius float64) error {
+	_, err := c.Value.CallE("arcTo", x1, y1, x2, y2, radius)
+	return err
+}
+
+// Ellipse adds an elliptical arc centered at (x, y), rotated by rotation
+// radians. It fails if a radius is negative.
+func (c *Context2D) Ellipse(x, y, radiusX, radiusY, rotation, startAngle, endAngle float64, counterclockwise bool) error {
+	_, err := c.Value.CallE("ellipse", x, y, radiusX, radiusY, rotation, startAngle, endAngle, counterclockwise)
+	return err
+}
+
+// Rect adds a closed rectangular subpath
+func (c *Context2D) Rect(x, y, width, height float64) {
+	c.Value.Call("rect", x, y, width, height)
+}
+
+// withRule appends rule to args unless it is empty, which means NonZero
+func withRule(rule FillRule, args ...interface{}) []interface{} {
+	if rule != "" {
+		args = append(args, string(rule))
+	}
+	return args
+}
+
+// Fill fills the current path with the fill style
+func (c *Context2D) Fill(rule FillRule) {
+	c.Value.Call("fill", withRule(rule)...)
+}
+
+// Stroke strokes the current path with the stroke style
+func (c *Context2D) Stroke() {
+	c.Value.Call("stroke")
+}
+
+// Clip intersects the clipping region with the current path
+func (c *Context2D) Clip(rule FillRule) {
+	c.Value.Call("clip", withRule(rule)...)
+}
+
+// IsPointInPath reports whether the point (x, y), in canvas pixels, is inside
+// the current path
+func (c *Context2D) IsPointInPath(x, y float64, rule FillRule) bool {
+	return c.Value.Call("isPointInPath", withRule(rule, x, y)...).MustBool()
+}
+
+// FillRect fills a rectangle with the fill style
+func (c *Context2D) FillRect(x, y, width, height float64) {
+	c.Value.Call("fillRect", x, y, width, height)
+}
+
+// StrokeRect strokes a rectangle with the stroke style
+func (c *Context2D) StrokeRect(x, y, width, height float64) {
+	c.Value.Call("strokeRect", x, y, width, height)
+}
+
+// ClearRect makes the pixels of a rectangle transparent black
+func (c *Context2D) ClearRect(x, y, width, height float64) {
+	c.Value.Call("clearRect", x, y, width, height)
+}
+
+// GetLineWidth returns the width of stroked lines
+func (c *Context2D) GetLineWidth() float64 {
+	return c.Value.Get("lineWidth").MustFloat()
+}
+
+// SetLineWidth sets the width of stroked lines. Widths that are not positive
+// are ignored.
+func (c *Context2D) SetLineWidth(width float64) {
+	c.Value.Set("lineWidth", width)
+}
+
+// GetLineCap returns the shape of the ends of stroked lines
+func (c *Context2D) GetLineCap() LineCap {
+	return LineCap(c.Value.Get("lineCap").MustString())
+}
+
+// SetLineCap sets the shape of the ends of stroked lines
+func (c *Context2D) SetLineCap(lineCap LineCap) {
+	c.Value.Set("lineCap", string(lineCap))
+}
+
+// GetLineJoin returns the shape of the corners of stroked lines
+func (c *Context2D) GetLineJoin() LineJoin {
+	return LineJoin(c.Value.Get("lineJoin").MustString())
+}
+
+// SetLineJoin sets the shape of the corners of stroked lines
+func (c *Context2D) SetLineJoin(lineJoin LineJoin) {
+	c.Value.Set("lineJoin", string(lineJoin))
+}
+
+// GetMiterLimit returns the longest miter join, relative to half the line width
+func (c *Context2D) GetMiterLimit() float64 {
+	return c.Value.Get("miterLimit").MustFloat()
+}
+
+// SetMiterLimit sets the longest miter join; longer ones are beveled
+func (c *Context2D) SetMiterLimit(limit float64) {
+	c.Value.Set("miterLimit", limit)
+}
+
+// GetLineDash returns the lengths of the dashes and gaps of stroked lines
+func (c *Context2D) GetLineDash() []float64 {
+	values := c.Value.Call("getLineDash")
+	segments := make([]float64, values.MustLength())
+	for i := range segments {
+		segments[i] = values.Get(strconv.Itoa(i)).MustFloat()
+	}
+	return segments
+}
+
+// SetLineDash sets the lengths of the dashes and gaps of stroked lines, or
+// draws solid lines if segments is empty. A list with an odd number of
+// lengths is repeated.
+func (c *Context2D) SetLineDash(segments []float64) {
+	values := make([]interface{}, len(segments))
+	for i, s := range segments {
+		values[i] = s
+	}
+	c.Value.Call("setLineDash", values)
+}
+
+// GetLineDashOffset returns how far into the dash pattern lines start
+func (c *Context2D) GetLineDashOffset() float64 {
+	return c.Value.Get("lineDashOffset").MustFloat()
+}
+
+// SetLineDashOffset sets how far into the dash pattern lines start
+func (c *Context2D) SetLineDashOffset(offset float64) {
+	c.Value.Set("lineDashOffset", offset)
+}
+
+// GetGlobalAlpha returns the opacity applied to everything drawn
+func (c *Context2D) GetGlobalAlpha() float64 {
+	return c.Value.Get("globalAlpha").MustFloat()
+}
+
+// SetGlobalAlpha sets the opacity applied to everything drawn, from 0 to 1
+func (c *Context2D) SetGlobalAlpha(alpha float64) {
+	c.Value.Set("globalAlpha", alpha)
+}
+
+// GetGlobalCompositeOperation returns how drawing combines with the bitmap
+func (c *Context2D) GetGlobalCompositeOperation() string {
+	return c.Value.Get("globalCompositeOperation").MustString()
+}
+
+// SetGlobalCompositeOperation sets how drawing combines with the bitmap, such
+// as "source-over" or "multiply"
+func (c *Context2D) SetGlobalCompositeOperation(operation string) {
+	c.Value.Set("globalCompositeOperation", operation)
+}
+
+// GetImageSmoothingEnabled reports whether scaled images are smoothed
+func (c *Context2D) GetImageSmoothingEnabled() bool {
+	return c.Value.Get("imageSmoothingEnabled").MustBool()
+}
+
+// SetImageSmoothingEnabled sets whether scaled images are smoothed
+func (c *Context2D) SetImageSmoothingEnabled(enabled bool) {
+	c.Value.Set("imageSmoothingEnabled", enabled)
+}
+
+// GetFont returns the CSS font used for text
+func (c *Context2D) GetFont() string {
+	return c.Value.Get("font").MustString()
+}
+
+// SetFont sets the CSS font used for text, such as "16px sans-serif"
+func (c *Context2D) SetFont(font string) {
+	c.Value.Set("font", font)
+}
+
+// GetTextAlign returns the horizontal alignment of text
+func (c *Context2D) GetTextAlign() string {
+	return c.Value.Get("textAlign").MustString()
+}
+
+// SetTextAlign sets the horizontal alignment of text, such as "left" or "center"
+func (c *Context2D) SetTextAlign(align string) {
+	c.Value.Set("textAlign", align)
+}
+
+// GetTextBaseline returns the vertical alignment of text
+func (c *Context2D) GetTextBaseline() string {
+	return c.Value.Get("textBaseline").MustString()
+}
+
+// SetTextBaseline sets the vertical alignment of text, such as "top" or "middle"
+func (c *Context2D) SetTextBaseline(baseline string) {
+	c.Value.Set("textBaseline", baseline)
+}
+
+// FillText draws text at (x, y) with the fill style
+func (c *Context2D) FillText(text string, x, y float64) {
+	c.Value.Call("fillText", text, x, y)
+}
+
+// StrokeText draws the outline of text at (x, y) with the stroke style
+func (c *Context2D) StrokeText(text string, x, y float64) {
+	c.Value.Call("strokeText", text, x, y)
+}
+
+// MeasureText returns the dimensions of text in the current font
+func (c *Context2D) MeasureText(text string) TextMetrics {
+	m := c.Value.Call("measureText", text)
+	return TextMetrics{
+		Width:                    m.Get("width").MustFloat(),
+		ActualBoundingBoxLeft:    m.Get("actualBoundingBoxLeft").MustFloat(),
+		ActualBoundingBoxRight:   m.Get("actualBoundingBoxRight").MustFloat(),
+		ActualBoundingBoxAscent:  m.Get("actualBoundingBoxAscent").MustFloat(),
+		ActualBoundingBoxDescent: m.Get("actualBoundingBoxDescent").MustFloat(),
+		FontBoundingBoxAscent:    m.Get("fontBoundingBoxAscent").TryFloat(0),
+		FontBoundingBoxDescent:   m.Get("fontBoundingBoxDescent").TryFloat(0),
+	}
+}
@@ -0,0 +1,87 @@
+//go:build js && wasm
+// +build js,wasm
+
+package canvas
+
+import (
+	"image"
+
+	"github.com/abdorrahmani/go-wasm/js"
+)
+
+// GetImageData returns the pixels of r, in canvas pixels and ignoring the
+// transform. Pixels outside the canvas are transparent black. The pixels are
+// copied straight into the image and premultiplied in place.
+func (c *Context2D) GetImageData(r image.Rectangle) (*image.RGBA, error) {
+	if r.Empty() {
+		return image.NewRGBA(r), nil
+	}
+	data, err := c.Value.CallE("getImageData", r.Min.X, r.Min.Y, r.Dx(), r.Dy())
+	if err != nil {
+		return nil, err
+	}
+	img := image.NewRGBA(r)
+	js.CopyBytesToGo(img.Pix, data.Get("data"))
+	for i := 0; i < len(img.Pix); i += 4 {
+		if a := uint32(img.Pix[i+3]); a < 0xff {
+			for j := i; j < i+3; j++ {
+				img.Pix[j] = uint8((uint32(img.Pix[j])*a + 0x7f) / 0xff)
+			}
+		}
+	}
+	return img, nil
+}
+
+// PutImageData replaces the pixels of the canvas at (dx, dy) with img,
+// ignoring the transform, global alpha and clipping region
+func (c *Context2D) PutImageData(img *image.RGBA, dx, dy int) error {
+	w, h := img.Rect.Dx(), img.Rect.Dy()
+	if w == 0 || h == 0 {
+		return nil
+	}
+	pix := make([]byte, w*h*4)
+	for y := 0; y < h; y++ {
+		row := img.Pix[y*img.Stride : y*img.Stride+w*4]
+		for i := 0; i < len(row); i += 4 {
+			j := y*w*4 + i
+			a := uint32(row[i+3])
+			pix[j+3] = row[i+3]
+			for k := 0; k < 3; k++ {
+				switch a {
+				case 0:
+				case 0xff:
+					pix[j+k] = row[i+k]
+				default:
+					// Colors above alpha are invalid, and clamp to 0xff
+					pix[j+k] = uint8(min((uint32(row[i+k])*0xff+a/2)/a, 0xff))
+				}
+			}
+		}
+	}
+	data, err := js.Global().Get("ImageData").NewE(w, h)
+	if err != nil {
+		return err
+	}
+	js.CopyBytesToJS(data.Get("data"), pix)
+	_, err = c.Value.CallE("putImageData", data, dx, dy)
+	return err
+}
+
+// DrawImage draws source at its natural size with its top-left corner at (dx, dy)
+func (c *Context2D) DrawImage(source ImageSource, dx, dy float64) error {
+	_, err := c.Value.CallE("drawImage", source.imageSource(), dx, dy)
+	return err
+}
+
+// DrawImageScaled draws source scaled to fill the given rectangle
+func (c *Context2D) DrawImageScaled(source ImageSource, dx, dy, dw, dh float64) error {
+	_, err := c.Value.CallE("drawImage", source.imageSource(), dx, dy, dw, dh)
+	return err
+}
+
+// DrawImageRegion draws the rectangle (sx, sy, sw, sh) of source scaled to
+// fill the rectangle (dx, dy, dw, dh)
+func (c *Context2D) DrawImageRegion(source ImageSource, sx, sy, sw, sh, dx, dy, dw, dh float64) error {
+	_, err := c.Value.CallE("drawImage", source.imageSource(), sx, sy, sw, sh, dx, dy, dw, dh)
+	return err
+}
@@ -0,0 +1,122 @@
+//go:build js && wasm
+// +build js,wasm
+
+package canvas
+
+import (
+	"github.com/abdorrahmani/go-wasm/js"
+)
+
+// Style is a fill or stroke style: a Color, *Gradient or *Pattern
+type Style interface {
+	styleValue() interface{}
+}
+
+// Color is a CSS color, such as "red", "#ff0000" or "rgba(255, 0, 0, 0.5)"
+type Color string
+
+func (c Color) styleValue() interface{} {
+	return string(c)
+}
+
+// Gradient is a linear or radial color gradient
+type Gradient struct {
+	Value *js.Value
+}
+
+func (g *Gradient) styleValue() interface{} {
+	return g.Value
+}
+
+// AddColorStop adds a color at offset, from 0 at the start of the gradient to
+// 1 at its end. It fails if the offset is out of range or the color is invalid.
+func (g *Gradient) AddColorStop(offset float64, color Color) error {
+	_, err := g.Value.CallE("addColorStop", offset, string(color))
+	return err
+}
+
+// Pattern is an image repeated to fill or stroke shapes
+type Pattern struct {
+	Value *js.Value
+}
+
+func (p *Pattern) styleValue() interface{} {
+	return p.Value
+}
+
+// Repetition is how a pattern repeats its image
+type Repetition string
+
+// Pattern repetitions
+const (
+	Repeat   Repetition = "repeat"
+	RepeatX  Repetition = "repeat-x"
+	RepeatY  Repetition = "repeat-y"
+	NoRepeat Repetition = "no-repeat"
+)
+
+// wrapStyle wraps a fillStyle or strokeStyle value
+func wrapStyle(v *js.Value) Style {
+	if v.Type() == js.TypeString {
+		return Color(v.MustString())
+	}
+	if v.Get("addColorStop").Type() == js.TypeFunction {
+		return &Gradient{Value: v}
+	}
+	return &Pattern{Value: v}
+}
+
+// GetFillStyle returns the style used to fill shapes. Colors are serialized,
+// so setting "red" returns "#ff0000".
+func (c *Context2D) GetFillStyle() Style {
+	return wrapStyle(c.Value.Get("fillStyle"))
+}
+
+// SetFillStyle sets the style used to fill shapes. Invalid colors are ignored.
+func (c *Context2D) SetFillStyle(style Style) {
+	c.Value.Set("fillStyle", style.styleValue())
+}
+
+// GetStrokeStyle returns the style used to stroke lines
+func (c *Context2D) GetStrokeStyle() Style {
+	return wrapStyle(c.Value.Get("strokeStyle"))
+}
+
+// SetStrokeStyle sets the style used to stroke lines. Invalid colors are ignored.
+func (c *Context2D) SetStrokeStyle(style Style) {
+	c.Value.Set("strokeStyle", style.styleValue())
+}
+
+// CreateLinearGradient creates a gradient along the line from (x0, y0) to (x1, y1)
+func (c *Context2D) CreateLinearGradient(x0, y0, x1, y1 float64) (*Gradient, error) {
+	v, err := c.Value.CallE("createLinearGradient", x0, y0, x1, y1)
+	if err != nil {
+		return nil, err
+	}
+	return &Gradient{Value: v}, nil
+}
+
+// CreateRadialGradient creates a gradient between the circle centered at
+// (x0, y0) with radius r0 and the circle centered at (x1, y1) with radius r1.
+// It fails if a radius is negative.
+func (c *Context2D) CreateRadialGradient(x0, y0, r0, x1, y1, r1 float64) (*Gradient, error) {
+	v, err := c.Value.CallE("createRadialGradient", x0, y0, r0, x1, y1, r1)
+	if err != nil {
+		return nil, err
+	}
+	return &Gradient{Value: v}, nil
+}
+
+// CreatePattern creates a pattern from the current image of source. It
+// returns nil without an error if source has no image yet, such as an image
+// that has not loaded.
+func (c *Context2D) CreatePattern(source ImageSource, repetition Repetition) (*Pattern, error) {
+	v, err := c.Value.CallE("createPattern", source.imageSource(), string(repetition))
+	if err != nil {
+		return nil, err
+	}
+	if v.IsNull() {
+		return nil, nil
+	}
+	return &Pattern{Value: v}, nil
+}
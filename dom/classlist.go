@@ -0,0 +1,72 @@
+package dom
+
+// ClassList wraps the DOMTokenList of an element's classes.
+// Methods taking tokens return an error if a token is empty or contains whitespace.
+type ClassList struct {
+	Value Object
+}
+
+// ClassList returns the element's classes
+func (e *Element) ClassList() *ClassList {
+	return &ClassList{
+		Value: backend().ClassList(e.Value),
+	}
+}
+
+// Add adds the given classes
+func (c *ClassList) Add(tokens ...string) error {
+	return backend().AddTokens(c.Value, tokens)
+}
+
+// Remove removes the given classes
+func (c *ClassList) Remove(tokens ...string) error {
+	return backend().RemoveTokens(c.Value, tokens)
+}
+
+// Toggle removes the class if present and adds it otherwise.
+// It reports whether the class is now present.
+func (c *ClassList) Toggle(token string) (bool, error) {
+	return backend().ToggleToken(c.Value, token)
+}
+
+// ToggleForce adds the class if force is true and removes it otherwise.
+// It reports whether the class is now present.
+func (c *ClassList) ToggleForce(token string, force bool) (bool, error) {
+	return backend().ToggleTokenForce(c.Value, token, force)
+}
+
+// Replace replaces oldToken with newToken, reporting whether oldToken was present
+func (c *ClassList) Replace(oldToken, newToken string) (bool, error) {
+	return backend().ReplaceToken(c.Value, oldToken, newToken)
+}
+
+// Contains reports whether the class is present
+func (c *ClassList) Contains(token string) bool {
+	return backend().ContainsToken(c.Value, token)
+}
+
+// Length returns the number of classes
+func (c *ClassList) Length() int {
+	return backend().TokenCount(c.Value)
+}
+
+// Item returns the class at index i, or an empty string if i is out of range
+func (c *ClassList) Item(i int) string {
+	token, _ := backend().Token(c.Value, i)
+	return token
+}
+
+// Values returns the classes in order
+func (c *ClassList) Values() []string {
+	length := c.Length()
+	values := make([]string, length)
+	for i := 0; i < length; i++ {
+		values[i], _ = backend().Token(c.Value, i)
+	}
+	return values
+}
+
+// String returns the classes as a space-separated string
+func (c *ClassList) String() string {
+	return backend().TokenListValue(c.Value)
+}
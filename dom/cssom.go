@@ -0,0 +1,142 @@
+//go:build js && wasm
+// +build js,wasm
+
+package dom
+
+import (
+	"github.com/abdorrahmani/go-wasm/js"
+)
+
+// wrapCSSRule wraps v in a CSSRule, returning nil when v is null or undefined
+func wrapCSSRule(v *js.Value) *CSSRule {
+	if isNullish(v) {
+		return nil
+	}
+	return &CSSRule{Value: v}
+}
+
+// wrapStyleSheet wraps v in a StyleSheet, returning nil when v is null or undefined
+func wrapStyleSheet(v *js.Value) *StyleSheet {
+	if isNullish(v) {
+		return nil
+	}
+	return &StyleSheet{Value: v}
+}
+
+// wrapMediaList wraps v in a MediaList, returning nil when v is null or undefined
+func wrapMediaList(v *js.Value) *MediaList {
+	if isNullish(v) {
+		return nil
+	}
+	return &MediaList{Value: v}
+}
+
+// GetParentRule returns the parent CSS rule, or nil if there is none
+func (s *Style) GetParentRule() *CSSRule {
+	return wrapCSSRule(s.Value.Get("parentRule"))
+}
+
+// CSSRule represents a CSS rule
+type CSSRule struct {
+	Value *js.Value
+}
+
+// GetType returns the type of the CSS rule
+func (r *CSSRule) GetType() int {
+	return r.Value.Get("type").MustInt()
+}
+
+// GetCSSText gets the CSS rule as a string
+func (r *CSSRule) GetCSSText() string {
+	return r.Value.Get("cssText").MustString()
+}
+
+// SetCSSText sets the CSS rule from a string
+func (r *CSSRule) SetCSSText(text string) {
+	r.Value.Set("cssText", text)
+}
+
+// GetParentStyleSheet returns the parent style sheet, or nil if there is none
+func (r *CSSRule) GetParentStyleSheet() *StyleSheet {
+	return wrapStyleSheet(r.Value.Get("parentStyleSheet"))
+}
+
+// StyleSheet represents a CSS style sheet
+type StyleSheet struct {
+	Value *js.Value
+}
+
+// GetType returns the type of the style sheet
+func (s *StyleSheet) GetType() string {
+	return s.Value.Get("type").MustString()
+}
+
+// GetHref returns the URL of the style sheet, or an empty string for inline style sheets
+func (s *StyleSheet) GetHref() string {
+	return s.Value.Get("href").TryString("")
+}
+
+// GetOwnerNode returns the node that owns the style sheet, or nil if there is none
+func (s *StyleSheet) GetOwnerNode() *Node {
+	return wrapNode(s.Value.Get("ownerNode"))
+}
+
+// GetParentStyleSheet returns the parent style sheet, or nil if there is none
+func (s *StyleSheet) GetParentStyleSheet() *StyleSheet {
+	return wrapStyleSheet(s.Value.Get("parentStyleSheet"))
+}
+
+// GetTitle returns the title of the style sheet, or an empty string if it has none
+func (s *StyleSheet) GetTitle() string {
+	return s.Value.Get("title").TryString("")
+}
+
+// GetMedia returns the media list of the style sheet, or nil if there is none
+func (s *StyleSheet) GetMedia() *MediaList {
+	return wrapMediaList(s.Value.Get("media"))
+}
+
+// GetDisabled returns true if the style sheet is disabled
+func (s *StyleSheet) GetDisabled() bool {
+	return s.Value.Get("disabled").MustBool()
+}
+
+// SetDisabled sets whether the style sheet is disabled
+func (s *StyleSheet) SetDisabled(disabled bool) {
+	s.Value.Set("disabled", disabled)
+}
+
+// MediaList represents a list of media queries
+type MediaList struct {
+	Value *js.Value
+}
+
+// GetLength returns the number of media queries
+func (m *MediaList) GetLength() int {
+	return m.Value.Get("length").MustInt()
+}
+
+// GetItem returns a media query by index
+func (m *MediaList) GetItem(index int) string {
+	return m.Value.Call("item", index).MustString()
+}
+
+// GetMediaText gets all media queries as a string
+func (m *MediaList) GetMediaText() string {
+	return m.Value.Get("mediaText").MustString()
+}
+
+// SetMediaText sets all media queries from a string
+func (m *MediaList) SetMediaText(text string) {
+	m.Value.Set("mediaText", text)
+}
+
+// AppendMedium adds a media query
+func (m *MediaList) AppendMedium(medium string) {
+	m.Value.Call("appendMedium", medium)
+}
+
+// DeleteMedium removes a media query
+func (m *MediaList) DeleteMedium(medium string) {
+	m.Value.Call("deleteMedium", medium)
+}
@@ -0,0 +1,168 @@
+package dom
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// ErrNoData is wrapped by the errors of the typed getters of Dataset when the
+// data attribute is not present
+var ErrNoData = errors.New("data attribute is not present")
+
+// Dataset provides access to the data-* attributes of an element, like
+// HTMLElement.dataset. Names are camel-cased: the name "userId" refers to the
+// data-user-id attribute.
+type Dataset struct {
+	element *Element
+}
+
+// Dataset returns the element's data attributes
+func (e *Element) Dataset() *Dataset {
+	return &Dataset{element: e}
+}
+
+// dataAttribute converts a camel-cased name to its data-* attribute name
+func dataAttribute(name string) string {
+	var b strings.Builder
+	b.WriteString("data-")
+	for _, r := range name {
+		if unicode.IsUpper(r) {
+			b.WriteByte('-')
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// datasetName converts a data-* attribute name to its camel-cased name
+func datasetName(attribute string) string {
+	var b strings.Builder
+	upper := false
+	for _, r := range strings.TrimPrefix(attribute, "data-") {
+		switch {
+		case r == '-':
+			if upper {
+				b.WriteByte('-')
+			}
+			upper = true
+			continue
+		case upper && unicode.IsLower(r):
+			r = unicode.ToUpper(r)
+		case upper:
+			b.WriteByte('-')
+		}
+		upper = false
+		b.WriteRune(r)
+	}
+	if upper {
+		b.WriteByte('-')
+	}
+	return b.String()
+}
+
+// Keys returns the camel-cased names of the element's data attributes
+func (d *Dataset) Keys() []string {
+	var keys []string
+	for _, attribute := range d.element.GetAttributeNames() {
+		if strings.HasPrefix(attribute, "data-") {
+			keys = append(keys, datasetName(attribute))
+		}
+	}
+	return keys
+}
+
+// Has reports whether the data attribute is present
+func (d *Dataset) Has(name string) bool {
+	return d.element.HasAttribute(dataAttribute(name))
+}
+
+// Get returns the value of the data attribute and whether it is present
+func (d *Dataset) Get(name string) (string, bool) {
+	return backend().GetAttribute(d.element.Value, dataAttribute(name))
+}
+
+// Set sets the data attribute
+func (d *Dataset) Set(name, value string) {
+	d.element.SetAttribute(dataAttribute(name), value)
+}
+
+// Delete removes the data attribute
+func (d *Dataset) Delete(name string) {
+	d.element.RemoveAttribute(dataAttribute(name))
+}
+
+// lookup returns the value of the data attribute, or an error wrapping
+// ErrNoData if it is not present
+func (d *Dataset) lookup(name string) (string, error) {
+	value, ok := d.Get(name)
+	if !ok {
+		return "", fmt.Errorf("%s: %w", dataAttribute(name), ErrNoData)
+	}
+	return value, nil
+}
+
+// GetInt parses the data attribute as an integer. The error wraps ErrNoData
+// if the attribute is not present.
+func (d *Dataset) GetInt(name string) (int, error) {
+	value, err := d.lookup(name)
+	if err != nil {
+		return 0, err
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", dataAttribute(name), err)
+	}
+	return n, nil
+}
+
+// SetInt sets the data attribute to an integer
+func (d *Dataset) SetInt(name string, value int) {
+	d.Set(name, strconv.Itoa(value))
+}
+
+// GetBool parses the data attribute as a boolean, as strconv.ParseBool does.
+// The error wraps ErrNoData if the attribute is not present.
+func (d *Dataset) GetBool(name string) (bool, error) {
+	value, err := d.lookup(name)
+	if err != nil {
+		return false, err
+	}
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", dataAttribute(name), err)
+	}
+	return b, nil
+}
+
+// SetBool sets the data attribute to "true" or "false"
+func (d *Dataset) SetBool(name string, value bool) {
+	d.Set(name, strconv.FormatBool(value))
+}
+
+// GetJSON decodes the JSON-encoded data attribute into target. The error
+// wraps ErrNoData if the attribute is not present.
+func (d *Dataset) GetJSON(name string, target interface{}) error {
+	value, err := d.lookup(name)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal([]byte(value), target); err != nil {
+		return fmt.Errorf("%s: %w", dataAttribute(name), err)
+	}
+	return nil
+}
+
+// SetJSON sets the data attribute to the JSON encoding of value
+func (d *Dataset) SetJSON(name string, value interface{}) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	d.Set(name, string(data))
+	return nil
+}
@@ -0,0 +1,63 @@
+package dom
+
+// DelegatedEvent is an event received by a delegated listener
+type DelegatedEvent struct {
+	Event
+	// DelegateTarget is the element matching the listener's selector
+	DelegateTarget *Element
+}
+
+// On adds a single listener on the element that calls handler for events
+// whose target is a descendant matching selector, such as the items of a list.
+// The handler is called for each matching element from the target up to, but
+// not including, this element, until it calls StopPropagation. It returns an
+// error if the selector is invalid.
+func (e *Element) On(eventType, selector string, handler func(*DelegatedEvent)) (*Listener, error) {
+	return delegate(e.Value, eventType, selector, handler)
+}
+
+// On adds a single listener on the document that calls handler for events
+// whose target matches selector or has an ancestor matching it.
+// See Element.On.
+func (d *Document) On(eventType, selector string, handler func(*DelegatedEvent)) (*Listener, error) {
+	return delegate(d.Value, eventType, selector, handler)
+}
+
+// delegate registers a delegated listener on root
+func delegate(root Object, eventType, selector string, handler func(*DelegatedEvent)) (*Listener, error) {
+	b := backend()
+	// Validate the selector now rather than on every event
+	fragment := b.CreateDocumentFragment(b.Document())
+	if _, err := b.QuerySelector(fragment, selector); err != nil {
+		return nil, err
+	}
+
+	return addEventListener(root, eventType, func(event *Event) {
+		target := b.Target(event.Value)
+		if !isNullish(target) && b.NodeType(target) != ElementNode {
+			// Events can target text nodes, which have no closest method
+			target = b.ParentElement(target)
+		}
+		if isNullish(target) || b.NodeType(target) != ElementNode {
+			return
+		}
+
+		for match, _ := b.Closest(target, selector); !isNullish(match); {
+			if b.IsSameNode(match, root) || !b.Contains(root, match) {
+				return
+			}
+			handler(&DelegatedEvent{
+				Event:          *event,
+				DelegateTarget: &Element{Value: match},
+			})
+			if b.CancelBubble(event.Value) {
+				return
+			}
+			parent := b.ParentElement(match)
+			if isNullish(parent) {
+				return
+			}
+			match, _ = b.Closest(parent, selector)
+		}
+	}, AddEventListenerOptions{}), nil
+}
@@ -1,99 +1,138 @@
-//go:build js && wasm
-// +build js,wasm
-
 package dom
 
 import (
 	"fmt"
-
-	"github.com/abdorrahmani/go-wasm/js"
 )
 
 // Document represents the DOM document
 type Document struct {
-	Value *js.Value
+	Value Object
 }
 
 // Global returns the global document object
 func Global() *Document {
 	return &Document{
-		Value: js.Global().Get("document"),
+		Value: backend().Document(),
 	}
 }
 
-// CreateElement creates a new element with the given tag name
+// CreateElement creates a new element with the given tag name.
+// It panics if the name is invalid.
 func (d *Document) CreateElement(tagName string) *Element {
-	return &Element{
-		Value: d.Value.Call("createElement", tagName),
+	value, err := backend().CreateElement(d.Value, tagName)
+	if err != nil {
+		panic(err)
 	}
+	return &Element{Value: value}
 }
 
-// GetElementByID returns an element by its ID
-func (d *Document) GetElementByID(id string) *Element {
-	return &Element{
-		Value: d.Value.Call("getElementById", id),
+// Namespaces for CreateElementNS and the namespaced attribute methods
+const (
+	XHTMLNamespace  = "http://www.w3.org/1999/xhtml"
+	SVGNamespace    = "http://www.w3.org/2000/svg"
+	MathMLNamespace = "http://www.w3.org/1998/Math/MathML"
+	XLinkNamespace  = "http://www.w3.org/1999/xlink"
+	XMLNamespace    = "http://www.w3.org/XML/1998/namespace"
+	XMLNSNamespace  = "http://www.w3.org/2000/xmlns/"
+)
+
+// CreateElementNS creates a new element with the given namespace and qualified
+// name, such as an SVG element. It returns an error if the name is invalid or
+// its prefix does not fit the namespace.
+func (d *Document) CreateElementNS(namespace, qualifiedName string) (*Element, error) {
+	value, err := backend().CreateElementNS(d.Value, namespace, qualifiedName)
+	if err != nil {
+		return nil, err
 	}
+	return &Element{Value: value}, nil
 }
 
-// QuerySelector returns the first element matching the selector
-func (d *Document) QuerySelector(selector string) *Element {
-	return &Element{
-		Value: d.Value.Call("querySelector", selector),
+// GetElementByID returns an element by its ID, or nil if there is none
+func (d *Document) GetElementByID(id string) *Element {
+	return wrapElement(backend().GetElementByID(d.Value, id))
+}
+
+// QuerySelector returns the first element matching the selector, or nil if none matches.
+// An invalid selector is reported as an error instead of a panic.
+func (d *Document) QuerySelector(selector string) (*Element, error) {
+	value, err := backend().QuerySelector(d.Value, selector)
+	if err != nil {
+		return nil, fmt.Errorf("querySelector %q: %w", selector, err)
 	}
+	return wrapElement(value), nil
 }
 
-// QuerySelectorAll returns all elements matching the selector
-func (d *Document) QuerySelectorAll(selector string) []*Element {
-	value := d.Value.Call("querySelectorAll", selector)
-	length := value.MustLength()
-	elements := make([]*Element, length)
-	for i := 0; i < length; i++ {
+// QuerySelectorAll returns all elements matching the selector.
+// An invalid selector is reported as an error instead of a panic.
+func (d *Document) QuerySelectorAll(selector string) ([]*Element, error) {
+	values, err := backend().QuerySelectorAll(d.Value, selector)
+	if err != nil {
+		return nil, fmt.Errorf("querySelectorAll %q: %w", selector, err)
+	}
+	elements := make([]*Element, len(values))
+	for i, value := range values {
 		elements[i] = &Element{
-			Value: value.Get(fmt.Sprintf("%d", i)),
+			Value: value,
 		}
 	}
-	return elements
+	return elements, nil
 }
 
 // CreateTextNode creates a new text node
 func (d *Document) CreateTextNode(text string) *Node {
 	return &Node{
-		Value: d.Value.Call("createTextNode", text),
+		Value: backend().CreateTextNode(d.Value, text),
 	}
 }
 
-// GetBody returns the document body element
+// GetBody returns the document body element, or nil if there is none
 func (d *Document) GetBody() *Element {
-	return &Element{
-		Value: d.Value.Get("body"),
-	}
+	return wrapElement(backend().Body(d.Value))
 }
 
-// GetHead returns the document head element
+// GetHead returns the document head element, or nil if there is none
 func (d *Document) GetHead() *Element {
-	return &Element{
-		Value: d.Value.Get("head"),
-	}
+	return wrapElement(backend().Head(d.Value))
+}
+
+// AddEventListener adds an event listener and returns a handle that removes it
+func (d *Document) AddEventListener(eventType string, handler func(*Event)) *Listener {
+	return addEventListener(d.Value, eventType, handler, AddEventListenerOptions{})
+}
+
+// AddEventListenerWithOptions adds an event listener configured by opts
+func (d *Document) AddEventListenerWithOptions(eventType string, handler func(*Event), opts AddEventListenerOptions) *Listener {
+	return addEventListener(d.Value, eventType, handler, opts)
+}
+
+// RemoveEventListener removes a listener returned by AddEventListener
+func (d *Document) RemoveEventListener(listener *Listener) {
+	listener.Remove()
+}
+
+// DispatchEvent dispatches an event
+func (d *Document) DispatchEvent(event *Event) bool {
+	return backend().DispatchEvent(d.Value, event.Value)
 }
 
 // Title returns the document title
 func (d *Document) Title() string {
-	return d.Value.Get("title").MustString()
+	return backend().Title(d.Value)
 }
 
 // SetTitle sets the document title
 func (d *Document) SetTitle(title string) {
-	d.Value.Set("title", title)
+	backend().SetTitle(d.Value, title)
 }
 
 // URL returns the current document URL
 func (d *Document) URL() string {
-	return d.Value.Get("URL").MustString()
+	return backend().URL(d.Value)
 }
 
 // ReadyState returns the document's ready state
 func (d *Document) ReadyState() string {
-	return d.Value.Get("readyState").MustString()
+	return backend().ReadyState(d.Value)
 }
 
 // IsReady returns true if the document is fully loaded
@@ -1,287 +1,248 @@
-//go:build js && wasm
-// +build js,wasm
-
 package dom
 
 import (
 	"fmt"
-
-	"github.com/abdorrahmani/go-wasm/js"
 )
 
 // Element represents a DOM element
 type Element struct {
-	Value *js.Value
-}
-
-// DOMRect represents a rectangle with position and dimensions
-type DOMRect struct {
-	Value *js.Value
+	Value Object
 }
 
 // GetID returns the element's ID
 func (e *Element) GetID() string {
-	return e.Value.Get("id").MustString()
+	return e.GetAttribute("id")
 }
 
 // SetID sets the element's ID
 func (e *Element) SetID(id string) {
-	e.Value.Set("id", id)
+	e.SetAttribute("id", id)
 }
 
 // GetClassName returns the element's class name
 func (e *Element) GetClassName() string {
-	return e.Value.Get("className").MustString()
+	return e.GetAttribute("class")
 }
 
 // SetClassName sets the element's class name
 func (e *Element) SetClassName(className string) {
-	e.Value.Set("className", className)
+	e.SetAttribute("class", className)
 }
 
 // GetInnerHTML returns the element's inner HTML
 func (e *Element) GetInnerHTML() string {
-	return e.Value.Get("innerHTML").MustString()
+	return backend().InnerHTML(e.Value)
 }
 
 // SetInnerHTML sets the element's inner HTML
 func (e *Element) SetInnerHTML(html string) {
-	e.Value.Set("innerHTML", html)
+	backend().SetInnerHTML(e.Value, html)
 }
 
 // GetTextContent returns the element's text content
 func (e *Element) GetTextContent() string {
-	return e.Value.Get("textContent").MustString()
+	return backend().TextContent(e.Value)
 }
 
 // SetTextContent sets the element's text content
 func (e *Element) SetTextContent(text string) {
-	e.Value.Set("textContent", text)
+	backend().SetTextContent(e.Value, text)
 }
 
-// GetAttribute returns the value of an attribute
+// GetAttribute returns the value of an attribute, or an empty string if it is not set
 func (e *Element) GetAttribute(name string) string {
-	return e.Value.Call("getAttribute", name).MustString()
+	value, _ := backend().GetAttribute(e.Value, name)
+	return value
 }
 
-// SetAttribute sets an attribute value
+// SetAttribute sets an attribute value. It panics if the name is invalid.
 func (e *Element) SetAttribute(name, value string) {
-	e.Value.Call("setAttribute", name, value)
+	if err := backend().SetAttribute(e.Value, name, value); err != nil {
+		panic(err)
+	}
 }
 
 // RemoveAttribute removes an attribute
 func (e *Element) RemoveAttribute(name string) {
-	e.Value.Call("removeAttribute", name)
+	backend().RemoveAttribute(e.Value, name)
 }
 
 // HasAttribute checks if an attribute exists
 func (e *Element) HasAttribute(name string) bool {
-	return e.Value.Call("hasAttribute", name).MustBool()
+	_, ok := backend().GetAttribute(e.Value, name)
+	return ok
 }
 
 // GetStyle returns the element's style object
 func (e *Element) GetStyle() *Style {
 	return &Style{
-		Value: e.Value.Get("style"),
+		Value: backend().Style(e.Value),
 	}
 }
 
 // AppendChild appends a child node
 func (e *Element) AppendChild(child *Node) error {
-	if e.Value == nil || e.Value.Raw().IsNull() || e.Value.Raw().IsUndefined() {
+	if isNullish(e.Value) {
 		return fmt.Errorf("element is nil or undefined/null")
 	}
-	if child == nil || child.Value == nil || child.Value.Raw().IsNull() || child.Value.Raw().IsUndefined() {
+	if child == nil || isNullish(child.Value) {
 		return fmt.Errorf("child node is nil or undefined/null")
 	}
-	e.Value.Call("appendChild", child.Value.Raw())
+	if err := backend().AppendChild(e.Value, child.Value); err != nil {
+		return fmt.Errorf("appendChild: %w", err)
+	}
 	return nil
 }
 
 // RemoveChild removes a child node
 func (e *Element) RemoveChild(child *Node) error {
-	if e.Value == nil || e.Value.Raw().IsNull() || e.Value.Raw().IsUndefined() {
+	if isNullish(e.Value) {
 		return fmt.Errorf("element is nil or undefined/null")
 	}
-	if child == nil || child.Value == nil || child.Value.Raw().IsNull() || child.Value.Raw().IsUndefined() {
+	if child == nil || isNullish(child.Value) {
 		return fmt.Errorf("child node is nil or undefined/null")
 	}
-	e.Value.Call("removeChild", child.Value)
+	if err := backend().RemoveChild(e.Value, child.Value); err != nil {
+		return fmt.Errorf("removeChild: %w", err)
+	}
 	return nil
 }
 
 // GetChildNodes returns all child nodes
 func (e *Element) GetChildNodes() []*Node {
-	value := e.Value.Get("childNodes")
-	length := value.MustLength()
-	nodes := make([]*Node, length)
-	for i := 0; i < length; i++ {
-		nodes[i] = &Node{
-			Value: value.Get(fmt.Sprintf("%d", i)),
-		}
-	}
-	return nodes
+	return nodeList(backend().ChildNodes(e.Value))
 }
 
-// GetFirstChild returns the first child node
+// GetFirstChild returns the first child node, or nil if there is none
 func (e *Element) GetFirstChild() *Node {
-	return &Node{
-		Value: e.Value.Get("firstChild"),
-	}
+	return wrapNode(backend().FirstChild(e.Value))
 }
 
-// GetLastChild returns the last child node
+// GetLastChild returns the last child node, or nil if there is none
 func (e *Element) GetLastChild() *Node {
-	return &Node{
-		Value: e.Value.Get("lastChild"),
-	}
+	return wrapNode(backend().LastChild(e.Value))
 }
 
-// GetParentNode returns the parent node
+// GetParentNode returns the parent node, or nil if there is none
 func (e *Element) GetParentNode() *Node {
-	return &Node{
-		Value: e.Value.Get("parentNode"),
-	}
+	return wrapNode(backend().ParentNode(e.Value))
 }
 
-// GetNextSibling returns the next sibling node
+// GetNextSibling returns the next sibling node, or nil if there is none
 func (e *Element) GetNextSibling() *Node {
-	return &Node{
-		Value: e.Value.Get("nextSibling"),
-	}
+	return wrapNode(backend().NextSibling(e.Value))
 }
 
-// GetPreviousSibling returns the previous sibling node
+// GetPreviousSibling returns the previous sibling node, or nil if there is none
 func (e *Element) GetPreviousSibling() *Node {
-	return &Node{
-		Value: e.Value.Get("previousSibling"),
-	}
-}
-
-// AddEventListener adds an event listener
-func (e *Element) AddEventListener(eventType string, handler func(*Event)) {
-	callback := js.NewCallback(func(args []*js.Value) {
-		handler(&Event{
-			Value: args[0],
-		})
-	})
-	e.Value.Call("addEventListener", eventType, callback)
+	return wrapNode(backend().PreviousSibling(e.Value))
 }
 
-// RemoveEventListener removes an event listener
-func (e *Element) RemoveEventListener(eventType string, handler func(*Event)) {
-	callback := js.NewCallback(func(args []*js.Value) {
-		handler(&Event{
-			Value: args[0],
-		})
-	})
-	e.Value.Call("removeEventListener", eventType, callback)
+// AddEventListener adds an event listener and returns a handle that removes it
+func (e *Element) AddEventListener(eventType string, handler func(*Event)) *Listener {
+	return addEventListener(e.Value, eventType, handler, AddEventListenerOptions{})
 }
 
-// DispatchEvent dispatches an event
-func (e *Element) DispatchEvent(event *Event) bool {
-	return e.Value.Call("dispatchEvent", event.Value).MustBool()
+// AddEventListenerWithOptions adds an event listener configured by opts
+func (e *Element) AddEventListenerWithOptions(eventType string, handler func(*Event), opts AddEventListenerOptions) *Listener {
+	return addEventListener(e.Value, eventType, handler, opts)
 }
 
-// GetBoundingClientRect returns the element's bounding rectangle
-func (e *Element) GetBoundingClientRect() *DOMRect {
-	return &DOMRect{
-		Value: e.Value.Call("getBoundingClientRect"),
-	}
+// RemoveEventListener removes a listener returned by AddEventListener
+func (e *Element) RemoveEventListener(listener *Listener) {
+	listener.Remove()
 }
 
-// ScrollIntoView scrolls the element into view
-func (e *Element) ScrollIntoView() {
-	e.Value.Call("scrollIntoView")
+// DispatchEvent dispatches an event
+func (e *Element) DispatchEvent(event *Event) bool {
+	return backend().DispatchEvent(e.Value, event.Value)
 }
 
 // Focus sets focus to the element
 func (e *Element) Focus() {
-	e.Value.Call("focus")
+	backend().Focus(e.Value)
 }
 
 // Blur removes focus from the element
 func (e *Element) Blur() {
-	e.Value.Call("blur")
+	backend().Blur(e.Value)
 }
 
 // Click simulates a click on the element
 func (e *Element) Click() {
-	e.Value.Call("click")
+	backend().Click(e.Value)
 }
 
 // GetTagName returns the element's tag name
 func (e *Element) GetTagName() string {
-	return e.Value.Get("tagName").MustString()
+	return backend().TagName(e.Value)
 }
 
-// GetNamespaceURI returns the element's namespace URI
+// GetNamespaceURI returns the element's namespace URI, or an empty string if it is null
 func (e *Element) GetNamespaceURI() string {
-	return e.Value.Get("namespaceURI").MustString()
+	return backend().NamespaceURI(e.Value)
 }
 
-// GetPrefix returns the element's prefix
+// GetPrefix returns the element's prefix, or an empty string if it is null
 func (e *Element) GetPrefix() string {
-	return e.Value.Get("prefix").MustString()
+	return backend().Prefix(e.Value)
 }
 
 // GetLocalName returns the element's local name
 func (e *Element) GetLocalName() string {
-	return e.Value.Get("localName").MustString()
+	return backend().LocalName(e.Value)
 }
 
 // GetBaseURI returns the element's base URI
 func (e *Element) GetBaseURI() string {
-	return e.Value.Get("baseURI").MustString()
+	return backend().BaseURI(e.Value)
 }
 
-// GetOwnerDocument returns the element's owner document
+// GetOwnerDocument returns the element's owner document, or nil if there is none
 func (e *Element) GetOwnerDocument() *Document {
-	return &Document{
-		Value: e.Value.Get("ownerDocument"),
-	}
+	return wrapDocument(backend().OwnerDocument(e.Value))
 }
 
 // GetNodeType returns the element's node type
 func (e *Element) GetNodeType() int {
-	return e.Value.Get("nodeType").MustInt()
+	return backend().NodeType(e.Value)
 }
 
 // GetNodeName returns the element's node name
 func (e *Element) GetNodeName() string {
-	return e.Value.Get("nodeName").MustString()
+	return backend().NodeName(e.Value)
 }
 
-// GetNodeValue returns the element's node value
+// GetNodeValue returns the element's node value, or an empty string if it is null
 func (e *Element) GetNodeValue() string {
-	return e.Value.Get("nodeValue").MustString()
+	return backend().NodeValue(e.Value)
 }
 
 // SetNodeValue sets the element's node value
 func (e *Element) SetNodeValue(value string) {
-	e.Value.Set("nodeValue", value)
+	backend().SetNodeValue(e.Value, value)
 }
 
 // CloneNode creates a copy of the element
 func (e *Element) CloneNode(deep bool) *Element {
 	return &Element{
-		Value: e.Value.Call("cloneNode", deep),
+		Value: backend().CloneNode(e.Value, deep),
 	}
 }
 
 // CompareDocumentPosition compares the position of two nodes
 func (e *Element) CompareDocumentPosition(other *Element) int {
-	return e.Value.Call("compareDocumentPosition", other.Value).MustInt()
+	return backend().CompareDocumentPosition(e.Value, other.Value)
 }
 
 // Contains checks if the element contains another element
 func (e *Element) Contains(other *Element) bool {
-	return e.Value.Call("contains", other.Value).MustBool()
+	return backend().Contains(e.Value, other.Value)
 }
 
 // HasChildNodes checks if the element has child nodes
 func (e *Element) HasChildNodes() bool {
-	return e.Value.Call("hasChildNodes").MustBool()
+	return backend().FirstChild(e.Value) != nil
 }
 
 // InsertBefore inserts a node before a reference node
@@ -295,91 +256,55 @@ func (e *Element) InsertBefore(newNode, referenceNode *Node) error {
 	if referenceNode == nil || referenceNode.Value == nil {
 		return fmt.Errorf("reference node is nil")
 	}
-	e.Value.Call("insertBefore", newNode.Value, referenceNode.Value)
+	if err := backend().InsertBefore(e.Value, newNode.Value, referenceNode.Value); err != nil {
+		return fmt.Errorf("insertBefore: %w", err)
+	}
 	return nil
 }
 
 // ReplaceChild replaces a child node
 func (e *Element) ReplaceChild(newNode, oldNode *Node) error {
-	if e.Value == nil || e.Value.Raw().IsNull() || e.Value.Raw().IsUndefined() {
+	if isNullish(e.Value) {
 		return fmt.Errorf("element is nil or undefined/null")
 	}
-	if newNode == nil || newNode.Value == nil || newNode.Value.Raw().IsNull() || newNode.Value.Raw().IsUndefined() {
+	if newNode == nil || isNullish(newNode.Value) {
 		return fmt.Errorf("new node is nil or undefined/null")
 	}
-	if oldNode == nil || oldNode.Value == nil || oldNode.Value.Raw().IsNull() || oldNode.Value.Raw().IsUndefined() {
+	if oldNode == nil || isNullish(oldNode.Value) {
 		return fmt.Errorf("old node is nil or undefined/null")
 	}
-	e.Value.Call("replaceChild", newNode.Value.Raw(), oldNode.Value.Raw())
+	if err := backend().ReplaceChild(e.Value, newNode.Value, oldNode.Value); err != nil {
+		return fmt.Errorf("replaceChild: %w", err)
+	}
 	return nil
 }
 
 // Normalize normalizes the element's text nodes
 func (e *Element) Normalize() {
-	e.Value.Call("normalize")
+	backend().Normalize(e.Value)
 }
 
 // IsDefaultNamespace checks if the element is in the default namespace
 func (e *Element) IsDefaultNamespace(namespaceURI string) bool {
-	return e.Value.Call("isDefaultNamespace", namespaceURI).MustBool()
+	return backend().LookupNamespaceURI(e.Value, "") == namespaceURI
 }
 
-// LookupNamespaceURI looks up the namespace URI for a prefix
+// LookupNamespaceURI looks up the namespace URI for a prefix, or an empty string if there is none
 func (e *Element) LookupNamespaceURI(prefix string) string {
-	return e.Value.Call("lookupNamespaceURI", prefix).MustString()
+	return backend().LookupNamespaceURI(e.Value, prefix)
 }
 
-// LookupPrefix looks up the prefix for a namespace URI
+// LookupPrefix looks up the prefix for a namespace URI, or an empty string if there is none
 func (e *Element) LookupPrefix(namespaceURI string) string {
-	return e.Value.Call("lookupPrefix", namespaceURI).MustString()
+	return backend().LookupPrefix(e.Value, namespaceURI)
 }
 
 // IsEqualNode checks if two nodes are equal
 func (e *Element) IsEqualNode(other *Element) bool {
-	return e.Value.Call("isEqualNode", other.Value).MustBool()
+	return backend().IsEqualNode(e.Value, other.Value)
 }
 
 // IsSameNode checks if two nodes are the same
 func (e *Element) IsSameNode(other *Element) bool {
-	return e.Value.Call("isSameNode", other.Value).MustBool()
-}
-
-// GetTop returns the top position
-func (r *DOMRect) GetTop() float64 {
-	return r.Value.Get("top").MustFloat()
-}
-
-// GetRight returns the right position
-func (r *DOMRect) GetRight() float64 {
-	return r.Value.Get("right").MustFloat()
-}
-
-// GetBottom returns the bottom position
-func (r *DOMRect) GetBottom() float64 {
-	return r.Value.Get("bottom").MustFloat()
-}
-
-// GetLeft returns the left position
-func (r *DOMRect) GetLeft() float64 {
-	return r.Value.Get("left").MustFloat()
-}
-
-// GetWidth returns the width
-func (r *DOMRect) GetWidth() float64 {
-	return r.Value.Get("width").MustFloat()
-}
-
-// GetHeight returns the height
-func (r *DOMRect) GetHeight() float64 {
-	return r.Value.Get("height").MustFloat()
-}
-
-// GetX returns the x position
-func (r *DOMRect) GetX() float64 {
-	return r.Value.Get("x").MustFloat()
-}
-
-// GetY returns the y position
-func (r *DOMRect) GetY() float64 {
-	return r.Value.Get("y").MustFloat()
+	return backend().IsSameNode(e.Value, other.Value)
 }
@@ -0,0 +1,137 @@
+//go:build js && wasm
+// +build js,wasm
+
+package form
+
+import (
+	"reflect"
+	"sort"
+
+	"github.com/abdorrahmani/go-wasm/dom"
+)
+
+// Binding keeps a struct in sync with the controls of a form
+type Binding struct {
+	container *dom.Element
+	target    reflect.Value
+	fields    map[string]field
+	listeners []*dom.Listener
+	errors    map[string]*FieldError
+	onChange  func(name string, err error)
+}
+
+// Bind populates the controls in container from the struct v points to, then
+// updates the struct whenever one of the controls fires an input or change
+// event. Each update also checks the constraints of the changed controls; the
+// errors are available from Err and Errors until the field becomes valid.
+// Call Sync after changing the struct in Go, and Unbind to stop updating it.
+func Bind(container *dom.Element, v interface{}) (*Binding, error) {
+	target, fs, err := structFields(v)
+	if err != nil {
+		return nil, err
+	}
+	if err := Populate(container, v); err != nil {
+		return nil, err
+	}
+
+	b := &Binding{
+		container: container,
+		target:    target,
+		fields:    make(map[string]field, len(fs)),
+		errors:    make(map[string]*FieldError),
+	}
+	for _, f := range fs {
+		b.fields[f.name] = f
+	}
+	for _, eventType := range []string{"input", "change"} {
+		listener, err := container.On(eventType, controlSelector, b.handle)
+		if err != nil {
+			b.Unbind()
+			return nil, err
+		}
+		b.listeners = append(b.listeners, listener)
+	}
+	return b, nil
+}
+
+// handle updates the field bound to the control that fired an event
+func (b *Binding) handle(e *dom.DelegatedEvent) {
+	f, ok := b.fields[e.DelegateTarget.GetAttribute("name")]
+	if !ok {
+		return
+	}
+	err := b.update(f, controls(b.container)[f.name])
+	if b.onChange != nil {
+		b.onChange(f.name, err)
+	}
+}
+
+// update decodes and validates the controls of a field, recording its error
+func (b *Binding) update(f field, named []*dom.Element) error {
+	err := decodeField(fieldByIndex(b.target, f.index), named)
+	if err == nil {
+		err = validate(named)
+	}
+	if err == nil {
+		delete(b.errors, f.name)
+		return nil
+	}
+	fieldErr := &FieldError{Field: f.fieldName, Name: f.name, Err: err}
+	b.errors[f.name] = fieldErr
+	return fieldErr
+}
+
+// OnChange sets a function called after a control updates the struct, with
+// the name of the control and the field's error, or nil if the field is valid
+func (b *Binding) OnChange(fn func(name string, err error)) {
+	b.onChange = fn
+}
+
+// Sync populates the controls from the struct after it was changed in Go
+func (b *Binding) Sync() error {
+	return Populate(b.container, b.target.Addr().Interface())
+}
+
+// Validate updates every field from its controls, like an input event on each
+// of them, and returns Errors for the invalid fields
+func (b *Binding) Validate() error {
+	byName := controls(b.container)
+	for name, f := range b.fields {
+		if named, ok := byName[name]; ok {
+			b.update(f, named)
+		}
+	}
+	if errs := b.Errors(); len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// Err returns the error of the field bound to the controls with the given
+// name, or nil if it is valid
+func (b *Binding) Err(name string) error {
+	if err, ok := b.errors[name]; ok {
+		return err
+	}
+	return nil
+}
+
+// Errors returns the errors of the invalid fields, sorted by control name
+func (b *Binding) Errors() Errors {
+	errs := make(Errors, 0, len(b.errors))
+	for _, err := range b.errors {
+		errs = append(errs, err)
+	}
+	sort.Slice(errs, func(i, j int) bool {
+		return errs[i].Name < errs[j].Name
+	})
+	return errs
+}
+
+// Unbind stops updating the struct
+func (b *Binding) Unbind() {
+	for _, listener := range b.listeners {
+		listener.Remove()
+	}
+	b.listeners = nil
+}
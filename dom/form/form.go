@@ -0,0 +1,581 @@
+//go:build js && wasm
+// +build js,wasm
+
+// Package form decodes the controls of a form into Go structs and populates
+// forms from them. Struct fields are matched to controls by name: a field
+// tagged `form:"email"` holds the value of the controls named email, an
+// untagged field uses its own name, and fields tagged `form:"-"` are ignored.
+// Embedded structs and pointers to structs are flattened, and the pointers
+// are set to new structs when decoding into them. Two fields with the same
+// name are an error.
+//
+// Fields can be strings, booleans, numbers, time.Time, *dom.File and slices of
+// these. Checkboxes decode into booleans, or into slices holding the values of
+// the checked boxes of the same name. Radio groups decode into the value of the
+// checked button, and multiple selects into slices of the selected values.
+//
+// The package is only available when compiled for js/wasm.
+package form
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/abdorrahmani/go-wasm/dom"
+)
+
+// controlSelector matches the elements whose values are decoded
+const controlSelector = "input, select, textarea"
+
+var (
+	timeType = reflect.TypeOf(time.Time{})
+	fileType = reflect.TypeOf((*dom.File)(nil))
+)
+
+// FieldError describes a field whose controls could not be decoded or do not
+// satisfy their constraints
+type FieldError struct {
+	// Field is the name of the struct field
+	Field string
+	// Name is the name of the controls
+	Name string
+	Err  error
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("form: field %s (%q): %v", e.Field, e.Name, e.Err)
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// Errors lists the errors of several fields
+type Errors []*FieldError
+
+func (e Errors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+// ValidationError reports a control that does not satisfy its constraints,
+// such as a required control without a value
+type ValidationError struct {
+	Message  string
+	Validity dom.ValidityState
+}
+
+func (e *ValidationError) Error() string {
+	return e.Message
+}
+
+// field is a struct field bound to the controls with the given name
+type field struct {
+	name      string
+	fieldName string
+	index     []int
+}
+
+// fields returns the fields of struct type t, flattening embedded structs and
+// pointers to structs
+func fields(t reflect.Type, index []int) ([]field, error) {
+	var result []field
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("form")
+		if tag == "-" {
+			continue
+		}
+		fieldIndex := append(append([]int(nil), index...), i)
+		if t, ok := embeddedStruct(f); ok && tag == "" {
+			if f.Type.Kind() == reflect.Ptr && !f.IsExported() {
+				// The pointer could not be set to a new struct
+				return nil, fmt.Errorf("form: embedded field %s is a pointer to an unexported struct type", f.Name)
+			}
+			embedded, err := fields(t, fieldIndex)
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, embedded...)
+			continue
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if !supported(f.Type) {
+			return nil, fmt.Errorf("form: field %s has unsupported type %s", f.Name, f.Type)
+		}
+		name := tag
+		if name == "" {
+			name = f.Name
+		}
+		result = append(result, field{name: name, fieldName: f.Name, index: fieldIndex})
+	}
+	return result, nil
+}
+
+// embeddedStruct returns the struct type of an embedded struct or pointer to
+// a struct, other than time.Time
+func embeddedStruct(f reflect.StructField) (reflect.Type, bool) {
+	t := f.Type
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t, f.Anonymous && t.Kind() == reflect.Struct && t != timeType
+}
+
+// supported reports whether a field of type t can be decoded
+func supported(t reflect.Type) bool {
+	if t.Kind() == reflect.Slice {
+		t = t.Elem()
+	}
+	if t == timeType || t == fileType {
+		return true
+	}
+	switch t.Kind() {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// structFields returns the struct v points to and its fields
+func structFields(v interface{}) (reflect.Value, []field, error) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return reflect.Value{}, nil, fmt.Errorf("form: target must be a non-nil pointer to a struct, got %T", v)
+	}
+	rv = rv.Elem()
+	fs, err := fields(rv.Type(), nil)
+	if err != nil {
+		return reflect.Value{}, nil, err
+	}
+	byName := make(map[string]field, len(fs))
+	for _, f := range fs {
+		if other, ok := byName[f.name]; ok {
+			return reflect.Value{}, nil, fmt.Errorf("form: fields %s and %s have the same name %q", other.fieldName, f.fieldName, f.name)
+		}
+		byName[f.name] = f
+	}
+	return rv, fs, nil
+}
+
+// fieldByIndex returns the field of struct v with the given index, setting
+// the nil pointers to embedded structs on the way to new structs
+func fieldByIndex(v reflect.Value, index []int) reflect.Value {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v
+}
+
+// controls returns the controls in container grouped by name
+func controls(container *dom.Element) map[string][]*dom.Element {
+	list := container.Value.Call("querySelectorAll", controlSelector)
+	length := list.MustLength()
+	byName := make(map[string][]*dom.Element)
+	for i := 0; i < length; i++ {
+		control := &dom.Element{Value: list.Get(strconv.Itoa(i))}
+		if name := control.GetAttribute("name"); name != "" {
+			byName[name] = append(byName[name], control)
+		}
+	}
+	return byName
+}
+
+// inputType returns the type of an input, or the local name of other controls
+func inputType(control *dom.Element) string {
+	if input := control.AsInput(); input != nil {
+		return input.GetType()
+	}
+	return control.GetLocalName()
+}
+
+// isDisabled reports whether a control is disabled, so that it is not submitted
+func isDisabled(control *dom.Element) bool {
+	return control.Value.Get("disabled").TryBool(false)
+}
+
+// Decode stores the values of the controls in container, which is usually a
+// form, in the struct v points to. Disabled controls are skipped, like when a
+// form is submitted, and fields without controls are left unchanged. It
+// returns Errors if some values cannot be parsed; the other fields are still
+// decoded.
+func Decode(container *dom.Element, v interface{}) error {
+	rv, fs, err := structFields(v)
+	if err != nil {
+		return err
+	}
+	byName := controls(container)
+	var errs Errors
+	for _, f := range fs {
+		named, ok := byName[f.name]
+		if !ok {
+			continue
+		}
+		if err := decodeField(fieldByIndex(rv, f.index), named); err != nil {
+			errs = append(errs, &FieldError{Field: f.fieldName, Name: f.name, Err: err})
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// decodeField stores the values of controls in v. v is left unchanged if a
+// value cannot be parsed.
+func decodeField(v reflect.Value, controls []*dom.Element) error {
+	var enabled []*dom.Element
+	for _, control := range controls {
+		if !isDisabled(control) {
+			enabled = append(enabled, control)
+		}
+	}
+	if len(enabled) == 0 {
+		return nil
+	}
+
+	t := v.Type()
+	switch {
+	case t == fileType:
+		files := selectedFiles(enabled)
+		if len(files) == 0 {
+			v.Set(reflect.Zero(t))
+		} else {
+			v.Set(reflect.ValueOf(files[0]))
+		}
+		return nil
+	case t.Kind() == reflect.Slice && t.Elem() == fileType:
+		v.Set(reflect.ValueOf(selectedFiles(enabled)).Convert(t))
+		return nil
+	case t.Kind() == reflect.Bool && allCheckboxes(enabled):
+		v.SetBool(len(values(enabled)) > 0)
+		return nil
+	}
+
+	kind := inputType(enabled[0])
+	vals := values(enabled)
+	if t.Kind() == reflect.Slice {
+		s := reflect.MakeSlice(t, len(vals), len(vals))
+		for i, value := range vals {
+			if err := parse(s.Index(i), kind, value); err != nil {
+				return err
+			}
+		}
+		v.Set(s)
+		return nil
+	}
+	value := ""
+	if len(vals) > 0 {
+		value = vals[0]
+	}
+	decoded := reflect.New(t).Elem()
+	if err := parse(decoded, kind, value); err != nil {
+		return err
+	}
+	v.Set(decoded)
+	return nil
+}
+
+// values returns the values the controls would submit with their form
+func values(controls []*dom.Element) []string {
+	var result []string
+	for _, control := range controls {
+		if input := control.AsInput(); input != nil {
+			switch input.GetType() {
+			case "checkbox", "radio":
+				if input.GetChecked() {
+					result = append(result, input.GetValue())
+				}
+			case "file", "submit", "reset", "button", "image":
+			default:
+				result = append(result, input.GetValue())
+			}
+		} else if s := control.AsSelect(); s != nil {
+			for _, option := range s.GetSelectedOptions() {
+				result = append(result, option.GetValue())
+			}
+		} else if textArea := control.AsTextArea(); textArea != nil {
+			result = append(result, textArea.GetValue())
+		}
+	}
+	return result
+}
+
+// allCheckboxes reports whether all of the controls are checkboxes
+func allCheckboxes(controls []*dom.Element) bool {
+	for _, control := range controls {
+		if inputType(control) != "checkbox" {
+			return false
+		}
+	}
+	return true
+}
+
+// selectedFiles returns the files selected in the controls
+func selectedFiles(controls []*dom.Element) []*dom.File {
+	var files []*dom.File
+	for _, control := range controls {
+		if input := control.AsInput(); input != nil {
+			files = append(files, input.GetFiles()...)
+		}
+	}
+	return files
+}
+
+// parse stores the value of a control of the given type in v.
+// Empty values are stored as the zero value.
+func parse(v reflect.Value, kind, value string) error {
+	if value == "" {
+		v.Set(reflect.Zero(v.Type()))
+		return nil
+	}
+	if v.Type() == timeType {
+		t, err := parseTime(kind, value)
+		if err != nil {
+			return err
+		}
+		v.Set(reflect.ValueOf(t))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(value)
+	case reflect.Bool:
+		if value == "on" {
+			v.SetBool(true)
+			break
+		}
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(value, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(value, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(value, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	}
+	return nil
+}
+
+// timeLayouts are the formats of the date and time input types
+var timeLayouts = map[string][]string{
+	"date":           {"2006-01-02"},
+	"datetime-local": {"2006-01-02T15:04", "2006-01-02T15:04:05"},
+	"month":          {"2006-01"},
+	"time":           {"15:04", "15:04:05"},
+}
+
+// parseTime parses the value of a date or time input in UTC. Values of other
+// controls must be in RFC 3339 format.
+func parseTime(kind, value string) (time.Time, error) {
+	layouts, ok := timeLayouts[kind]
+	if !ok {
+		return time.Parse(time.RFC3339, value)
+	}
+	var err error
+	for _, layout := range layouts {
+		var t time.Time
+		if t, err = time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, err
+}
+
+// formatTime formats t as the value of a control of the given type
+func formatTime(kind string, t time.Time) string {
+	switch kind {
+	case "date":
+		return t.Format("2006-01-02")
+	case "month":
+		return t.Format("2006-01")
+	case "time", "datetime-local":
+		layout := timeLayouts[kind][0]
+		if t.Second() != 0 || t.Nanosecond() != 0 {
+			layout += ":05.999"
+		}
+		return t.Format(layout)
+	}
+	return t.Format(time.RFC3339)
+}
+
+// format formats v as the value of a control of the given type
+func format(v reflect.Value, kind string) string {
+	if v.Type() == timeType {
+		t := v.Interface().(time.Time)
+		if t.IsZero() {
+			return ""
+		}
+		return formatTime(kind, t)
+	}
+	switch v.Kind() {
+	case reflect.String:
+		return v.String()
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10)
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'f', -1, v.Type().Bits())
+	}
+	return ""
+}
+
+// Populate sets the controls in container from the struct v points to. It
+// checks the checkboxes, radio buttons and options whose values the fields
+// hold, and sets the values of the other controls. File inputs are skipped,
+// since their files cannot be set.
+func Populate(container *dom.Element, v interface{}) error {
+	rv, fs, err := structFields(v)
+	if err != nil {
+		return err
+	}
+	byName := controls(container)
+	var errs Errors
+	for _, f := range fs {
+		fv, err := rv.FieldByIndexErr(f.index)
+		if err != nil {
+			// The fields of a nil embedded struct are zero
+			fv = reflect.Zero(rv.Type().FieldByIndex(f.index).Type)
+		}
+		if err := populateField(fv, byName[f.name]); err != nil {
+			errs = append(errs, &FieldError{Field: f.fieldName, Name: f.name, Err: err})
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// populateField sets controls from v
+func populateField(v reflect.Value, controls []*dom.Element) error {
+	t := v.Type()
+	if t == fileType || (t.Kind() == reflect.Slice && t.Elem() == fileType) {
+		return nil
+	}
+	items := []reflect.Value{v}
+	if t.Kind() == reflect.Slice {
+		items = make([]reflect.Value, v.Len())
+		for i := range items {
+			items[i] = v.Index(i)
+		}
+	}
+	formatted := func(kind string) map[string]bool {
+		set := make(map[string]bool, len(items))
+		for _, item := range items {
+			set[format(item, kind)] = true
+		}
+		return set
+	}
+
+	// Text controls of the same name take the items in order
+	next := 0
+	for _, control := range controls {
+		kind := inputType(control)
+		switch kind {
+		case "checkbox":
+			input := control.AsInput()
+			if t.Kind() == reflect.Bool {
+				input.SetChecked(v.Bool())
+			} else {
+				input.SetChecked(formatted(kind)[input.GetValue()])
+			}
+		case "radio":
+			input := control.AsInput()
+			input.SetChecked(formatted(kind)[input.GetValue()])
+		case "select":
+			set := formatted(kind)
+			for _, option := range control.AsSelect().GetOptions() {
+				option.SetSelected(set[option.GetValue()])
+			}
+		case "file", "submit", "reset", "button", "image":
+		default:
+			value := ""
+			if next < len(items) {
+				value = format(items[next], kind)
+			}
+			next++
+			var err error
+			if textArea := control.AsTextArea(); textArea != nil {
+				err = textArea.SetValue(value)
+			} else {
+				err = control.AsInput().SetValue(value)
+			}
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Validate checks the constraints of the controls bound to the fields of the
+// struct v points to, and returns Errors holding a ValidationError for each
+// field with an invalid control. It does not fire invalid events.
+func Validate(container *dom.Element, v interface{}) error {
+	_, fs, err := structFields(v)
+	if err != nil {
+		return err
+	}
+	byName := controls(container)
+	var errs Errors
+	for _, f := range fs {
+		if err := validate(byName[f.name]); err != nil {
+			errs = append(errs, &FieldError{Field: f.fieldName, Name: f.name, Err: err})
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// validate returns a ValidationError for the first invalid control
+func validate(controls []*dom.Element) error {
+	for _, element := range controls {
+		control := &dom.FormControl{Element: *element}
+		if !control.GetWillValidate() {
+			continue
+		}
+		if validity := control.GetValidity(); !validity.Valid {
+			return &ValidationError{Message: control.GetValidationMessage(), Validity: validity}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,189 @@
+//go:build js && wasm
+// +build js,wasm
+
+package dom
+
+import (
+	"strconv"
+)
+
+// FormControl holds the properties and validation methods shared by the
+// elements that can be part of a form
+type FormControl struct {
+	Element
+}
+
+// ValidityState describes why a form control fails its constraints
+type ValidityState struct {
+	ValueMissing    bool
+	TypeMismatch    bool
+	PatternMismatch bool
+	TooLong         bool
+	TooShort        bool
+	RangeUnderflow  bool
+	RangeOverflow   bool
+	StepMismatch    bool
+	BadInput        bool
+	CustomError     bool
+	Valid           bool
+}
+
+// FormElement wraps a form element
+type FormElement struct {
+	Element
+}
+
+// isHTMLElement reports whether e is the HTML element with the given local name
+func (e *Element) isHTMLElement(localName string) bool {
+	return e != nil && e.GetNamespaceURI() == XHTMLNamespace && e.GetLocalName() == localName
+}
+
+// AsForm returns the element as a form, or nil if it is not a form element
+func (e *Element) AsForm() *FormElement {
+	if !e.isHTMLElement("form") {
+		return nil
+	}
+	return &FormElement{Element: *e}
+}
+
+// GetName returns the control's name
+func (c *FormControl) GetName() string {
+	return c.Value.Get("name").MustString()
+}
+
+// SetName sets the control's name
+func (c *FormControl) SetName(name string) {
+	c.Value.Set("name", name)
+}
+
+// GetDisabled reports whether the control is disabled
+func (c *FormControl) GetDisabled() bool {
+	return c.Value.Get("disabled").MustBool()
+}
+
+// SetDisabled sets whether the control is disabled
+func (c *FormControl) SetDisabled(disabled bool) {
+	c.Value.Set("disabled", disabled)
+}
+
+// GetRequired reports whether the control must have a value
+func (c *FormControl) GetRequired() bool {
+	return c.Value.Get("required").TryBool(false)
+}
+
+// SetRequired sets whether the control must have a value
+func (c *FormControl) SetRequired(required bool) {
+	c.Value.Set("required", required)
+}
+
+// GetForm returns the form the control belongs to, or nil if there is none
+func (c *FormControl) GetForm() *FormElement {
+	form := c.Value.Get("form")
+	if isNullish(form) {
+		return nil
+	}
+	return &FormElement{Element: Element{Value: form}}
+}
+
+// GetWillValidate reports whether the control is checked by constraint validation
+func (c *FormControl) GetWillValidate() bool {
+	return c.Value.Get("willValidate").MustBool()
+}
+
+// GetValidity returns the control's validity state
+func (c *FormControl) GetValidity() ValidityState {
+	v := c.Value.Get("validity")
+	return ValidityState{
+		ValueMissing:    v.Get("valueMissing").TryBool(false),
+		TypeMismatch:    v.Get("typeMismatch").TryBool(false),
+		PatternMismatch: v.Get("patternMismatch").TryBool(false),
+		TooLong:         v.Get("tooLong").TryBool(false),
+		TooShort:        v.Get("tooShort").TryBool(false),
+		RangeUnderflow:  v.Get("rangeUnderflow").TryBool(false),
+		RangeOverflow:   v.Get("rangeOverflow").TryBool(false),
+		StepMismatch:    v.Get("stepMismatch").TryBool(false),
+		BadInput:        v.Get("badInput").TryBool(false),
+		CustomError:     v.Get("customError").TryBool(false),
+		Valid:           v.Get("valid").TryBool(true),
+	}
+}
+
+// GetValidationMessage returns the message describing why the control is invalid
+func (c *FormControl) GetValidationMessage() string {
+	return c.Value.Get("validationMessage").MustString()
+}
+
+// CheckValidity reports whether the control is valid, firing an invalid event if it is not
+func (c *FormControl) CheckValidity() bool {
+	return c.Value.Call("checkValidity").MustBool()
+}
+
+// ReportValidity is like CheckValidity, but also reports problems to the user
+func (c *FormControl) ReportValidity() bool {
+	return c.Value.Call("reportValidity").MustBool()
+}
+
+// SetCustomValidity marks the control as invalid with the given message.
+// An empty message makes the control valid again.
+func (c *FormControl) SetCustomValidity(message string) {
+	c.Value.Call("setCustomValidity", message)
+}
+
+// GetName returns the form's name
+func (f *FormElement) GetName() string {
+	return f.Value.Get("name").MustString()
+}
+
+// GetNoValidate reports whether the form is submitted without validation
+func (f *FormElement) GetNoValidate() bool {
+	return f.Value.Get("noValidate").MustBool()
+}
+
+// SetNoValidate sets whether the form is submitted without validation
+func (f *FormElement) SetNoValidate(noValidate bool) {
+	f.Value.Set("noValidate", noValidate)
+}
+
+// Elements returns the form's controls in tree order
+func (f *FormElement) Elements() []*FormControl {
+	value := f.Value.Get("elements")
+	length := value.MustLength()
+	controls := make([]*FormControl, length)
+	for i := 0; i < length; i++ {
+		controls[i] = &FormControl{
+			Element: Element{Value: value.Get(strconv.Itoa(i))},
+		}
+	}
+	return controls
+}
+
+// Submit submits the form without firing a submit event or validating it
+func (f *FormElement) Submit() {
+	f.Value.Call("submit")
+}
+
+// RequestSubmit validates the form and fires a submit event, as if submitter
+// had been clicked. submitter may be nil; otherwise it must belong to the form.
+func (f *FormElement) RequestSubmit(submitter *Element) error {
+	var args []interface{}
+	if submitter != nil {
+		args = append(args, submitter.Value)
+	}
+	_, err := f.Value.CallE("requestSubmit", args...)
+	return err
+}
+
+// Reset fires a reset event and restores the default values of the form's controls
+func (f *FormElement) Reset() {
+	f.Value.Call("reset")
+}
+
+// CheckValidity reports whether all of the form's controls are valid
+func (f *FormElement) CheckValidity() bool {
+	return f.Value.Call("checkValidity").MustBool()
+}
+
+// ReportValidity is like CheckValidity, but also reports problems to the user
+func (f *FormElement) ReportValidity() bool {
+	return f.Value.Call("reportValidity").MustBool()
+}
@@ -0,0 +1,185 @@
+//go:build js && wasm
+// +build js,wasm
+
+package dom
+
+// onMouseEvent registers a listener that receives the event as a MouseEvent
+func (e *Element) onMouseEvent(eventType string, handler func(*MouseEvent)) *Listener {
+	return e.AddEventListener(eventType, func(event *Event) {
+		handler(&MouseEvent{Event: *event})
+	})
+}
+
+// onKeyboardEvent registers a listener that receives the event as a KeyboardEvent
+func (e *Element) onKeyboardEvent(eventType string, handler func(*KeyboardEvent)) *Listener {
+	return e.AddEventListener(eventType, func(event *Event) {
+		handler(&KeyboardEvent{Event: *event})
+	})
+}
+
+// onInputEvent registers a listener that receives the event as an InputEvent
+func (e *Element) onInputEvent(eventType string, handler func(*InputEvent)) *Listener {
+	return e.AddEventListener(eventType, func(event *Event) {
+		handler(&InputEvent{Event: *event})
+	})
+}
+
+// onPointerEvent registers a listener that receives the event as a PointerEvent
+func (e *Element) onPointerEvent(eventType string, handler func(*PointerEvent)) *Listener {
+	return e.AddEventListener(eventType, func(event *Event) {
+		handler(&PointerEvent{MouseEvent: MouseEvent{Event: *event}})
+	})
+}
+
+// onFocusEvent registers a listener that receives the event as a FocusEvent
+func (e *Element) onFocusEvent(eventType string, handler func(*FocusEvent)) *Listener {
+	return e.AddEventListener(eventType, func(event *Event) {
+		handler(&FocusEvent{Event: *event})
+	})
+}
+
+// onTouchEvent registers a listener that receives the event as a TouchEvent
+func (e *Element) onTouchEvent(eventType string, handler func(*TouchEvent)) *Listener {
+	return e.AddEventListener(eventType, func(event *Event) {
+		handler(&TouchEvent{Event: *event})
+	})
+}
+
+// OnClick adds a click listener
+func (e *Element) OnClick(handler func(*MouseEvent)) *Listener {
+	return e.onMouseEvent("click", handler)
+}
+
+// OnDblClick adds a dblclick listener
+func (e *Element) OnDblClick(handler func(*MouseEvent)) *Listener {
+	return e.onMouseEvent("dblclick", handler)
+}
+
+// OnMouseDown adds a mousedown listener
+func (e *Element) OnMouseDown(handler func(*MouseEvent)) *Listener {
+	return e.onMouseEvent("mousedown", handler)
+}
+
+// OnMouseUp adds a mouseup listener
+func (e *Element) OnMouseUp(handler func(*MouseEvent)) *Listener {
+	return e.onMouseEvent("mouseup", handler)
+}
+
+// OnMouseMove adds a mousemove listener
+func (e *Element) OnMouseMove(handler func(*MouseEvent)) *Listener {
+	return e.onMouseEvent("mousemove", handler)
+}
+
+// OnMouseEnter adds a mouseenter listener
+func (e *Element) OnMouseEnter(handler func(*MouseEvent)) *Listener {
+	return e.onMouseEvent("mouseenter", handler)
+}
+
+// OnMouseLeave adds a mouseleave listener
+func (e *Element) OnMouseLeave(handler func(*MouseEvent)) *Listener {
+	return e.onMouseEvent("mouseleave", handler)
+}
+
+// OnKeyDown adds a keydown listener
+func (e *Element) OnKeyDown(handler func(*KeyboardEvent)) *Listener {
+	return e.onKeyboardEvent("keydown", handler)
+}
+
+// OnKeyUp adds a keyup listener
+func (e *Element) OnKeyUp(handler func(*KeyboardEvent)) *Listener {
+	return e.onKeyboardEvent("keyup", handler)
+}
+
+// OnInput adds an input listener
+func (e *Element) OnInput(handler func(*InputEvent)) *Listener {
+	return e.onInputEvent("input", handler)
+}
+
+// OnBeforeInput adds a beforeinput listener
+func (e *Element) OnBeforeInput(handler func(*InputEvent)) *Listener {
+	return e.onInputEvent("beforeinput", handler)
+}
+
+// OnPointerDown adds a pointerdown listener
+func (e *Element) OnPointerDown(handler func(*PointerEvent)) *Listener {
+	return e.onPointerEvent("pointerdown", handler)
+}
+
+// OnPointerUp adds a pointerup listener
+func (e *Element) OnPointerUp(handler func(*PointerEvent)) *Listener {
+	return e.onPointerEvent("pointerup", handler)
+}
+
+// OnPointerMove adds a pointermove listener
+func (e *Element) OnPointerMove(handler func(*PointerEvent)) *Listener {
+	return e.onPointerEvent("pointermove", handler)
+}
+
+// OnPointerEnter adds a pointerenter listener
+func (e *Element) OnPointerEnter(handler func(*PointerEvent)) *Listener {
+	return e.onPointerEvent("pointerenter", handler)
+}
+
+// OnPointerLeave adds a pointerleave listener
+func (e *Element) OnPointerLeave(handler func(*PointerEvent)) *Listener {
+	return e.onPointerEvent("pointerleave", handler)
+}
+
+// OnPointerCancel adds a pointercancel listener
+func (e *Element) OnPointerCancel(handler func(*PointerEvent)) *Listener {
+	return e.onPointerEvent("pointercancel", handler)
+}
+
+// OnWheel adds a wheel listener
+func (e *Element) OnWheel(handler func(*WheelEvent)) *Listener {
+	return e.AddEventListener("wheel", func(event *Event) {
+		handler(&WheelEvent{MouseEvent: MouseEvent{Event: *event}})
+	})
+}
+
+// OnFocus adds a focus listener
+func (e *Element) OnFocus(handler func(*FocusEvent)) *Listener {
+	return e.onFocusEvent("focus", handler)
+}
+
+// OnBlur adds a blur listener
+func (e *Element) OnBlur(handler func(*FocusEvent)) *Listener {
+	return e.onFocusEvent("blur", handler)
+}
+
+// OnFocusIn adds a focusin listener
+func (e *Element) OnFocusIn(handler func(*FocusEvent)) *Listener {
+	return e.onFocusEvent("focusin", handler)
+}
+
+// OnFocusOut adds a focusout listener
+func (e *Element) OnFocusOut(handler func(*FocusEvent)) *Listener {
+	return e.onFocusEvent("focusout", handler)
+}
+
+// OnTouchStart adds a touchstart listener
+func (e *Element) OnTouchStart(handler func(*TouchEvent)) *Listener {
+	return e.onTouchEvent("touchstart", handler)
+}
+
+// OnTouchMove adds a touchmove listener
+func (e *Element) OnTouchMove(handler func(*TouchEvent)) *Listener {
+	return e.onTouchEvent("touchmove", handler)
+}
+
+// OnTouchEnd adds a touchend listener
+func (e *Element) OnTouchEnd(handler func(*TouchEvent)) *Listener {
+	return e.onTouchEvent("touchend", handler)
+}
+
+// OnTouchCancel adds a touchcancel listener
+func (e *Element) OnTouchCancel(handler func(*TouchEvent)) *Listener {
+	return e.onTouchEvent("touchcancel", handler)
+}
+
+// OnCustomEvent adds a listener for a custom event type
+func (e *Element) OnCustomEvent(eventType string, handler func(*CustomEvent)) *Listener {
+	return e.AddEventListener(eventType, func(event *Event) {
+		handler(&CustomEvent{Event: *event})
+	})
+}
@@ -0,0 +1,191 @@
+//go:build js && wasm
+// +build js,wasm
+
+package dom
+
+import (
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/abdorrahmani/go-wasm/js"
+)
+
+// TextControl holds the value and selection methods shared by inputs and textareas
+type TextControl struct {
+	FormControl
+}
+
+// InputElement wraps an input element
+type InputElement struct {
+	TextControl
+}
+
+// TextAreaElement wraps a textarea element
+type TextAreaElement struct {
+	TextControl
+}
+
+// File describes a file selected in a file input
+type File struct {
+	Value *js.Value
+}
+
+// AsInput returns the element as an input, or nil if it is not an input element
+func (e *Element) AsInput() *InputElement {
+	if !e.isHTMLElement("input") {
+		return nil
+	}
+	return &InputElement{TextControl{FormControl{Element: *e}}}
+}
+
+// AsTextArea returns the element as a textarea, or nil if it is not a textarea element
+func (e *Element) AsTextArea() *TextAreaElement {
+	if !e.isHTMLElement("textarea") {
+		return nil
+	}
+	return &TextAreaElement{TextControl{FormControl{Element: *e}}}
+}
+
+// GetValue returns the control's current value
+func (c *TextControl) GetValue() string {
+	return c.Value.Get("value").MustString()
+}
+
+// SetValue sets the control's current value
+func (c *TextControl) SetValue(value string) error {
+	return c.Value.SetE("value", value)
+}
+
+// GetDefaultValue returns the value the control has after its form is reset
+func (c *TextControl) GetDefaultValue() string {
+	return c.Value.Get("defaultValue").MustString()
+}
+
+// SetDefaultValue sets the value the control has after its form is reset
+func (c *TextControl) SetDefaultValue(value string) {
+	c.Value.Set("defaultValue", value)
+}
+
+// GetSelectionStart returns the start of the selected text.
+// It reports false if the control does not support selection.
+func (c *TextControl) GetSelectionStart() (int, bool) {
+	start := c.Value.Get("selectionStart")
+	if isNullish(start) {
+		return 0, false
+	}
+	return start.MustInt(), true
+}
+
+// GetSelectionEnd returns the end of the selected text.
+// It reports false if the control does not support selection.
+func (c *TextControl) GetSelectionEnd() (int, bool) {
+	end := c.Value.Get("selectionEnd")
+	if isNullish(end) {
+		return 0, false
+	}
+	return end.MustInt(), true
+}
+
+// SetSelectionRange selects the text between start and end.
+// It returns an error if the control does not support selection.
+func (c *TextControl) SetSelectionRange(start, end int) error {
+	_, err := c.Value.CallE("setSelectionRange", start, end)
+	return err
+}
+
+// Select selects all of the control's text
+func (c *TextControl) Select() {
+	c.Value.Call("select")
+}
+
+// GetType returns the input's type, such as "text" or "checkbox"
+func (i *InputElement) GetType() string {
+	return i.Value.Get("type").MustString()
+}
+
+// SetType sets the input's type
+func (i *InputElement) SetType(inputType string) {
+	i.Value.Set("type", inputType)
+}
+
+// GetChecked reports whether a checkbox or radio button is checked
+func (i *InputElement) GetChecked() bool {
+	return i.Value.Get("checked").MustBool()
+}
+
+// SetChecked checks or unchecks a checkbox or radio button.
+// Checking a radio button unchecks the others in its group.
+func (i *InputElement) SetChecked(checked bool) {
+	i.Value.Set("checked", checked)
+}
+
+// GetValueAsNumber returns the value of a number, range, date or time input
+// as a number, or NaN if the input has no numeric value
+func (i *InputElement) GetValueAsNumber() float64 {
+	return i.Value.Get("valueAsNumber").TryFloat(math.NaN())
+}
+
+// SetValueAsNumber sets the value of a number, range, date or time input
+func (i *InputElement) SetValueAsNumber(value float64) error {
+	return i.Value.SetE("valueAsNumber", value)
+}
+
+// GetValueAsDate returns the value of a date, time or month input in UTC.
+// It reports false if the input has no date value.
+func (i *InputElement) GetValueAsDate() (time.Time, bool) {
+	date := i.Value.Get("valueAsDate")
+	if isNullish(date) {
+		return time.Time{}, false
+	}
+	var t time.Time
+	if err := date.Decode(&t); err != nil {
+		return time.Time{}, false
+	}
+	return t.UTC(), true
+}
+
+// SetValueAsDate sets the value of a date, time or month input
+func (i *InputElement) SetValueAsDate(value time.Time) error {
+	date, err := js.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return i.Value.SetE("valueAsDate", date)
+}
+
+// GetFiles returns the files selected in a file input, or nil if the input is not a file input
+func (i *InputElement) GetFiles() []*File {
+	value := i.Value.Get("files")
+	if isNullish(value) {
+		return nil
+	}
+	length := value.MustLength()
+	files := make([]*File, length)
+	for j := 0; j < length; j++ {
+		files[j] = &File{
+			Value: value.Get(strconv.Itoa(j)),
+		}
+	}
+	return files
+}
+
+// GetName returns the file's name
+func (f *File) GetName() string {
+	return f.Value.Get("name").MustString()
+}
+
+// GetSize returns the file's size in bytes
+func (f *File) GetSize() int {
+	return f.Value.Get("size").MustInt()
+}
+
+// GetType returns the file's MIME type
+func (f *File) GetType() string {
+	return f.Value.Get("type").MustString()
+}
+
+// GetLastModified returns the time the file was last modified
+func (f *File) GetLastModified() time.Time {
+	return time.UnixMilli(int64(f.Value.Get("lastModified").MustFloat()))
+}
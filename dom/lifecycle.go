@@ -0,0 +1,204 @@
+//go:build js && wasm
+// +build js,wasm
+
+package dom
+
+import (
+	"context"
+	"sync"
+
+	"github.com/abdorrahmani/go-wasm/js"
+)
+
+// Ready states of a document
+const (
+	ReadyStateLoading     = "loading"
+	ReadyStateInteractive = "interactive"
+	ReadyStateComplete    = "complete"
+)
+
+// VisibilityState tells whether the page is visible to the user
+type VisibilityState string
+
+// Visibility states
+const (
+	VisibilityVisible VisibilityState = "visible"
+	VisibilityHidden  VisibilityState = "hidden"
+)
+
+// onceEvent returns a channel that is closed by the first eventType event at
+// target, or a closed channel if done is already true. The listener is nil in
+// that case; otherwise removing it abandons the wait.
+func onceEvent(target *js.Value, eventType string, done bool) (<-chan struct{}, *Listener) {
+	ch := make(chan struct{})
+	if done {
+		close(ch)
+		return ch, nil
+	}
+	l := addEventListener(target, eventType, func(*Event) {
+		close(ch)
+	}, AddEventListenerOptions{Once: true})
+	return ch, l
+}
+
+// sharedEvent is a channel closed by the first event of a type at a target,
+// shared by every caller waiting for that event
+type sharedEvent struct {
+	target    *js.Value
+	eventType string
+	ch        <-chan struct{}
+}
+
+var (
+	sharedEventsMu sync.Mutex
+	sharedEvents   []*sharedEvent
+)
+
+// sharedOnceEvent is like onceEvent, but callers waiting for the same event
+// at the same target share one channel and one listener, which cannot be
+// removed
+func sharedOnceEvent(target *js.Value, eventType string, done bool) <-chan struct{} {
+	if done {
+		ch, _ := onceEvent(target, eventType, true)
+		return ch
+	}
+	sharedEventsMu.Lock()
+	defer sharedEventsMu.Unlock()
+	for _, e := range sharedEvents {
+		if e.eventType == eventType && e.target.Raw().Equal(target.Raw()) {
+			return e.ch
+		}
+	}
+	ch := make(chan struct{})
+	shared := &sharedEvent{target: target, eventType: eventType, ch: ch}
+	sharedEvents = append(sharedEvents, shared)
+	addEventListener(target, eventType, func(*Event) {
+		sharedEventsMu.Lock()
+		for i, e := range sharedEvents {
+			if e == shared {
+				sharedEvents = append(sharedEvents[:i], sharedEvents[i+1:]...)
+				break
+			}
+		}
+		sharedEventsMu.Unlock()
+		close(ch)
+	}, AddEventListenerOptions{Once: true})
+	return ch
+}
+
+// waitEvent waits until ch is closed or ctx is done, removing l in the latter case
+func waitEvent(ctx context.Context, ch <-chan struct{}, l *Listener) error {
+	select {
+	case <-ch:
+		return nil
+	case <-ctx.Done():
+		if l != nil {
+			l.Remove()
+		}
+		return ctx.Err()
+	}
+}
+
+// Ready returns a channel that is closed once the document has been parsed,
+// which is when its ready state leaves "loading" and DOMContentLoaded fires.
+// The channel is already closed if that has happened. Calls before then
+// return the same channel, so a loop may call Ready without adding listeners;
+// use WhenReady to give up waiting.
+func (d *Document) Ready() <-chan struct{} {
+	return sharedOnceEvent(d.Value, "readystatechange", d.ReadyState() != ReadyStateLoading)
+}
+
+// WhenReady waits until the document has been parsed or ctx is done. It
+// returns the error of ctx in the latter case.
+func (d *Document) WhenReady(ctx context.Context) error {
+	ch, l := onceEvent(d.Value, "readystatechange", d.ReadyState() != ReadyStateLoading)
+	return waitEvent(ctx, ch, l)
+}
+
+// OnReadyStateChange calls handler with the new ready state every time it changes
+func (d *Document) OnReadyStateChange(handler func(state string)) *Listener {
+	return addEventListener(d.Value, "readystatechange", func(*Event) {
+		handler(d.ReadyState())
+	}, AddEventListenerOptions{})
+}
+
+// GetVisibilityState returns whether the page is visible
+func (d *Document) GetVisibilityState() VisibilityState {
+	return VisibilityState(d.Value.Get("visibilityState").MustString())
+}
+
+// IsHidden returns true if the page is hidden, for example in a background tab
+func (d *Document) IsHidden() bool {
+	return d.Value.Get("hidden").MustBool()
+}
+
+// OnVisibilityChange calls handler with the new state every time the page is
+// hidden or shown again
+func (d *Document) OnVisibilityChange(handler func(state VisibilityState)) *Listener {
+	return addEventListener(d.Value, "visibilitychange", func(*Event) {
+		handler(d.GetVisibilityState())
+	}, AddEventListenerOptions{})
+}
+
+// Loaded returns a channel that is closed once the page and all its resources,
+// such as images and stylesheets, have loaded. The channel is already closed
+// if that has happened. Like Ready, calls before then return the same channel.
+func (w *Window) Loaded() <-chan struct{} {
+	return sharedOnceEvent(w.Value, "load", w.GetDocument().ReadyState() == ReadyStateComplete)
+}
+
+// WhenLoaded waits until the page has loaded or ctx is done. It returns the
+// error of ctx in the latter case.
+func (w *Window) WhenLoaded(ctx context.Context) error {
+	ch, l := onceEvent(w.Value, "load", w.GetDocument().ReadyState() == ReadyStateComplete)
+	return waitEvent(ctx, ch, l)
+}
+
+// PageTransitionEvent is fired when the page is shown or hidden as the user
+// navigates to or away from it
+type PageTransitionEvent struct {
+	Event
+}
+
+// GetPersisted returns true if the page is restored from or stored in the
+// back/forward cache
+func (e *PageTransitionEvent) GetPersisted() bool {
+	return e.Value.Get("persisted").MustBool()
+}
+
+// OnPageShow calls handler when the page is shown, including when it is
+// restored from the back/forward cache
+func (w *Window) OnPageShow(handler func(*PageTransitionEvent)) *Listener {
+	return addEventListener(w.Value, "pageshow", func(e *Event) {
+		handler(&PageTransitionEvent{Event: *e})
+	}, AddEventListenerOptions{})
+}
+
+// OnPageHide calls handler when the user navigates away from the page. Unlike
+// beforeunload, this is the last event that reliably fires, so it is the
+// place to save state.
+func (w *Window) OnPageHide(handler func(*PageTransitionEvent)) *Listener {
+	return addEventListener(w.Value, "pagehide", func(e *Event) {
+		handler(&PageTransitionEvent{Event: *e})
+	}, AddEventListenerOptions{})
+}
+
+// BeforeUnloadEvent is fired when the page is about to be unloaded
+type BeforeUnloadEvent struct {
+	Event
+}
+
+// Prompt asks the browser to let the user confirm leaving the page, for
+// example because of unsaved changes
+func (e *BeforeUnloadEvent) Prompt() {
+	e.PreventDefault()
+	// Older browsers only prompt when returnValue is set
+	e.Value.Set("returnValue", true)
+}
+
+// OnBeforeUnload calls handler when the page is about to be unloaded
+func (w *Window) OnBeforeUnload(handler func(*BeforeUnloadEvent)) *Listener {
+	return addEventListener(w.Value, "beforeunload", func(e *Event) {
+		handler(&BeforeUnloadEvent{Event: *e})
+	}, AddEventListenerOptions{})
+}
@@ -0,0 +1,170 @@
+package dom
+
+import (
+	"sync"
+)
+
+// AddEventListenerOptions configures how an event listener is registered
+type AddEventListenerOptions struct {
+	// Capture registers the listener for the capturing phase
+	Capture bool
+	// Once removes the listener after it has been invoked once
+	Once bool
+	// Passive indicates that the listener will never call PreventDefault
+	Passive bool
+	// Signal removes the listener when the signal is aborted
+	Signal *AbortSignal
+}
+
+// Listener is a handle to a registered event listener
+type Listener struct {
+	eventType string
+	remove    func()
+	signal    *AbortSignal
+	mu        sync.Mutex
+	removed   bool
+}
+
+// addEventListener registers handler on any event target and returns its handle
+func addEventListener(target Object, eventType string, handler func(*Event), opts AddEventListenerOptions) *Listener {
+	l := &Listener{
+		eventType: eventType,
+		signal:    opts.Signal,
+	}
+	if opts.Signal != nil && opts.Signal.Aborted() {
+		// The browser ignores listeners registered with an aborted signal
+		l.removed = true
+		return l
+	}
+
+	l.remove = backend().AddEventListener(target, eventType, func(event Object) {
+		if opts.Once {
+			defer l.Remove()
+		}
+		handler(&Event{
+			Value: event,
+		})
+	}, opts)
+	if opts.Signal != nil {
+		opts.Signal.track(l)
+	}
+	return l
+}
+
+// Type returns the event type the listener is registered for
+func (l *Listener) Type() string {
+	return l.eventType
+}
+
+// Removed reports whether the listener has been removed
+func (l *Listener) Removed() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.removed
+}
+
+// Remove detaches the listener from its target and releases its callback.
+// Calling Remove more than once is a no-op.
+func (l *Listener) Remove() {
+	l.mu.Lock()
+	if l.removed {
+		l.mu.Unlock()
+		return
+	}
+	l.removed = true
+	l.mu.Unlock()
+
+	l.remove()
+	if l.signal != nil {
+		l.signal.untrack(l)
+	}
+}
+
+// AbortController wraps a DOM AbortController.
+// Listeners registered with its signal are all removed by a single Abort call.
+type AbortController struct {
+	Value  Object
+	signal *AbortSignal
+}
+
+// NewAbortController creates a new AbortController
+func NewAbortController() *AbortController {
+	value, signal := backend().NewAbortController()
+	return &AbortController{
+		Value: value,
+		signal: &AbortSignal{
+			Value: signal,
+		},
+	}
+}
+
+// Signal returns the controller's signal
+func (c *AbortController) Signal() *AbortSignal {
+	return c.signal
+}
+
+// Abort aborts the signal, removing every listener registered with it
+func (c *AbortController) Abort() {
+	backend().Abort(c.Value)
+	c.signal.removeAll()
+}
+
+// AbortSignal wraps a DOM AbortSignal
+type AbortSignal struct {
+	Value     Object
+	mu        sync.Mutex
+	listeners map[*Listener]struct{}
+	// removeOnAbort removes the listener for the abort event, if any
+	removeOnAbort func()
+}
+
+// Aborted returns true if the signal has been aborted
+func (s *AbortSignal) Aborted() bool {
+	return backend().Aborted(s.Value)
+}
+
+// track records a listener so its callback is released when the signal aborts
+func (s *AbortSignal) track(l *Listener) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.listeners == nil {
+		s.listeners = make(map[*Listener]struct{})
+	}
+	s.listeners[l] = struct{}{}
+	if s.removeOnAbort == nil {
+		// The signal can also be aborted from JavaScript, so listen for it
+		s.removeOnAbort = backend().AddEventListener(s.Value, "abort", func(Object) {
+			s.removeAll()
+		}, AddEventListenerOptions{Once: true})
+	}
+}
+
+// untrack forgets a removed listener and drops the abort hook once no listener is left
+func (s *AbortSignal) untrack(l *Listener) {
+	s.mu.Lock()
+	delete(s.listeners, l)
+	var removeOnAbort func()
+	if len(s.listeners) == 0 && s.removeOnAbort != nil {
+		removeOnAbort = s.removeOnAbort
+		s.removeOnAbort = nil
+	}
+	s.mu.Unlock()
+
+	if removeOnAbort != nil {
+		removeOnAbort()
+	}
+}
+
+// removeAll removes every listener registered with the signal
+func (s *AbortSignal) removeAll() {
+	s.mu.Lock()
+	listeners := make([]*Listener, 0, len(s.listeners))
+	for l := range s.listeners {
+		listeners = append(listeners, l)
+	}
+	s.mu.Unlock()
+
+	for _, l := range listeners {
+		l.Remove()
+	}
+}
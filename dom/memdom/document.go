@@ -0,0 +1,131 @@
+//go:build !js || !wasm
+// +build !js !wasm
+
+package memdom
+
+import (
+	"strings"
+
+	"github.com/abdorrahmani/go-wasm/dom"
+)
+
+// documentURL is the URL of every document created by memdom
+const documentURL = "about:blank"
+
+func (n *node) createElement(tagName string) *node {
+	e := newNode(n, elementNode, strings.ToLower(tagName))
+	e.namespace = xhtmlNamespace
+	return e
+}
+
+// createElementNS creates an element with a namespace and a qualified name,
+// which has already been validated
+func (n *node) createElementNS(namespace, qualifiedName string) *node {
+	prefix, localName := splitQualifiedName(qualifiedName)
+	e := newNode(n, elementNode, localName)
+	e.namespace, e.prefix = namespace, prefix
+	return e
+}
+
+func (n *node) documentElement() *node {
+	if elements := n.elementChildren(); len(elements) > 0 {
+		return elements[0]
+	}
+	return nil
+}
+
+// childElement returns the first child element of the document element with the given tag name
+func (n *node) childElement(tagName string) *node {
+	root := n.documentElement()
+	if root == nil {
+		return nil
+	}
+	for _, c := range root.elementChildren() {
+		if c.name == tagName {
+			return c
+		}
+	}
+	return nil
+}
+
+func (n *node) titleElement() *node {
+	elements := n.descendantElements(func(c *node) bool {
+		return c.name == "title"
+	})
+	if len(elements) == 0 {
+		return nil
+	}
+	return elements[0]
+}
+
+func (d *DOM) Document() dom.Object {
+	return d.document
+}
+
+func (d *DOM) CreateElement(doc dom.Object, tagName string) (dom.Object, error) {
+	if !validName(tagName) {
+		return nil, domException("InvalidCharacterError", "Failed to execute 'createElement' on 'Document': The tag name provided ('%s') is not a valid name.", tagName)
+	}
+	return nodeOf(doc).createElement(tagName), nil
+}
+
+func (d *DOM) CreateElementNS(doc dom.Object, namespace, qualifiedName string) (dom.Object, error) {
+	if err := validateQualifiedName(namespace, qualifiedName, "createElementNS", "Document"); err != nil {
+		return nil, err
+	}
+	return nodeOf(doc).createElementNS(namespace, qualifiedName), nil
+}
+
+func (d *DOM) CreateTextNode(doc dom.Object, text string) dom.Object {
+	n := newNode(nodeOf(doc), textNode, "#text")
+	n.data = text
+	return n
+}
+
+func (d *DOM) CreateDocumentFragment(doc dom.Object) dom.Object {
+	return newNode(nodeOf(doc), fragmentNode, "#document-fragment")
+}
+
+func (d *DOM) GetElementByID(doc dom.Object, id string) dom.Object {
+	return object(nodeOf(doc).elementByID(id))
+}
+
+func (d *DOM) Body(doc dom.Object) dom.Object {
+	return object(nodeOf(doc).childElement("body"))
+}
+
+func (d *DOM) Head(doc dom.Object) dom.Object {
+	return object(nodeOf(doc).childElement("head"))
+}
+
+// Title returns the text of the title element with collapsed whitespace
+func (d *DOM) Title(doc dom.Object) string {
+	title := nodeOf(doc).titleElement()
+	if title == nil {
+		return ""
+	}
+	return strings.Join(strings.Fields(title.textContent()), " ")
+}
+
+// SetTitle sets the text of the title element, adding one to the head if needed
+func (d *DOM) SetTitle(doc dom.Object, text string) {
+	n := nodeOf(doc)
+	title := n.titleElement()
+	if title == nil {
+		head := n.childElement("head")
+		if head == nil {
+			return
+		}
+		title = head.appendChild(n.createElement("title"))
+	}
+	title.setTextContent(text)
+}
+
+func (d *DOM) URL(doc dom.Object) string {
+	return documentURL
+}
+
+// ReadyState returns "complete", as there is nothing to load
+func (d *DOM) ReadyState(doc dom.Object) string {
+	return "complete"
+}
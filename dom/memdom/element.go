@@ -0,0 +1,460 @@
+//go:build !js || !wasm
+// +build !js !wasm
+
+package memdom
+
+import (
+	"strings"
+
+	"github.com/abdorrahmani/go-wasm/dom"
+)
+
+// validName reports whether name can be used as a tag or attribute name
+func validName(name string) bool {
+	return name != "" && !strings.ContainsAny(name, " \t\n\f\r\"'<>/=")
+}
+
+// splitQualifiedName splits a qualified name into its prefix and local name
+func splitQualifiedName(qualifiedName string) (prefix, localName string) {
+	if i := strings.IndexByte(qualifiedName, ':'); i >= 0 {
+		return qualifiedName[:i], qualifiedName[i+1:]
+	}
+	return "", qualifiedName
+}
+
+// validateQualifiedName returns an InvalidCharacterError if qualifiedName is
+// not a valid qualified name, and a NamespaceError if its prefix does not fit
+// the namespace
+func validateQualifiedName(namespace, qualifiedName, method, iface string) error {
+	prefix, localName := splitQualifiedName(qualifiedName)
+	if !validName(qualifiedName) || strings.Count(qualifiedName, ":") > 1 ||
+		localName == "" || (strings.Contains(qualifiedName, ":") && prefix == "") {
+		return domException("InvalidCharacterError", "Failed to execute '%s' on '%s': The qualified name provided ('%s') contains the invalid name-start character ':'.", method, iface, qualifiedName)
+	}
+	var invalid bool
+	switch {
+	case prefix != "" && namespace == "":
+		invalid = true
+	case prefix == "xml" && namespace != xmlNamespace:
+		invalid = true
+	case (qualifiedName == "xmlns" || prefix == "xmlns") != (namespace == xmlnsNamespace):
+		invalid = true
+	}
+	if invalid {
+		return domException("NamespaceError", "Failed to execute '%s' on '%s': The namespace '%s' is not valid for the qualified name '%s'.", method, iface, namespace, qualifiedName)
+	}
+	return nil
+}
+
+func (n *node) attribute(name string) (string, bool) {
+	for _, a := range n.attrs {
+		if a.name == name {
+			return a.value, true
+		}
+	}
+	return "", false
+}
+
+func (n *node) setAttribute(name, value string) {
+	for i, a := range n.attrs {
+		if a.name == name {
+			n.attrs[i].value = value
+			n.attributeChanged(name)
+			return
+		}
+	}
+	n.attrs = append(n.attrs, attr{name: name, localName: name, value: value})
+	n.attributeChanged(name)
+}
+
+// attributeNS returns the attribute with the given namespace and local name
+func (n *node) attributeNS(namespace, localName string) (string, bool) {
+	for _, a := range n.attrs {
+		if a.namespace == namespace && a.localName == localName {
+			return a.value, true
+		}
+	}
+	return "", false
+}
+
+// setAttributeNS sets the attribute with the given namespace and local name,
+// keeping the prefix of an existing attribute
+func (n *node) setAttributeNS(namespace, qualifiedName, value string) {
+	prefix, localName := splitQualifiedName(qualifiedName)
+	for i, a := range n.attrs {
+		if a.namespace == namespace && a.localName == localName {
+			n.attrs[i].value = value
+			n.attributeChanged(a.name)
+			return
+		}
+	}
+	name := localName
+	if prefix != "" {
+		name = prefix + ":" + localName
+	}
+	n.attrs = append(n.attrs, attr{name: name, namespace: namespace, localName: localName, value: value})
+	n.attributeChanged(name)
+}
+
+func (n *node) removeAttributeNS(namespace, localName string) {
+	for i, a := range n.attrs {
+		if a.namespace == namespace && a.localName == localName {
+			n.attrs = append(n.attrs[:i], n.attrs[i+1:]...)
+			n.attributeChanged(a.name)
+			return
+		}
+	}
+}
+
+func (n *node) removeAttribute(name string) {
+	for i, a := range n.attrs {
+		if a.name == name {
+			n.attrs = append(n.attrs[:i], n.attrs[i+1:]...)
+			n.attributeChanged(name)
+			return
+		}
+	}
+}
+
+// attributeChanged keeps the inline style in sync with the style attribute
+func (n *node) attributeChanged(name string) {
+	if name == "style" && n.style != nil && !n.style.updating {
+		value, _ := n.attribute("style")
+		n.style.parse(value)
+	}
+}
+
+func (n *node) hasClass(class string) bool {
+	value, _ := n.attribute("class")
+	for _, c := range strings.Fields(value) {
+		if c == class {
+			return true
+		}
+	}
+	return false
+}
+
+// attributeName lower-cases attribute names of HTML elements
+func (n *node) attributeName(name string) string {
+	if n.isHTML() {
+		return strings.ToLower(name)
+	}
+	return name
+}
+
+// checkAttributeName returns an InvalidCharacterError if name is not a valid attribute name
+func checkAttributeName(name, method string) error {
+	if !validName(name) {
+		return domException("InvalidCharacterError", "Failed to execute '%s' on 'Element': '%s' is not a valid attribute name.", method, name)
+	}
+	return nil
+}
+
+func (n *node) getStyle() *style {
+	if n.style == nil {
+		n.style = newStyle(n)
+	}
+	return n.style
+}
+
+func (n *node) getClassList() *tokenList {
+	if n.classList == nil {
+		n.classList = newTokenList(n, "class")
+	}
+	return n.classList
+}
+
+// tokenList implements DOMTokenList on top of an attribute, as used by classList
+type tokenList struct {
+	owner     *node
+	attribute string
+}
+
+func newTokenList(owner *node, attribute string) *tokenList {
+	return &tokenList{owner: owner, attribute: attribute}
+}
+
+func (l *tokenList) tokens() []string {
+	value, _ := l.owner.attribute(l.attribute)
+	var tokens []string
+	for _, token := range strings.Fields(value) {
+		if indexOf(tokens, token) < 0 {
+			tokens = append(tokens, token)
+		}
+	}
+	return tokens
+}
+
+func (l *tokenList) setTokens(tokens []string) {
+	l.owner.setAttribute(l.attribute, strings.Join(tokens, " "))
+}
+
+func indexOf(tokens []string, token string) int {
+	for i, t := range tokens {
+		if t == token {
+			return i
+		}
+	}
+	return -1
+}
+
+// checkTokens returns the error the browser throws for empty tokens or tokens with whitespace
+func checkTokens(tokens []string, method string) error {
+	for _, token := range tokens {
+		switch {
+		case token == "":
+			return domException("SyntaxError", "Failed to execute '%s' on 'DOMTokenList': The token provided must not be empty.", method)
+		case strings.ContainsAny(token, " \t\n\f\r"):
+			return domException("InvalidCharacterError", "Failed to execute '%s' on 'DOMTokenList': The token provided ('%s') contains HTML space characters, which are not valid in tokens.", method, token)
+		}
+	}
+	return nil
+}
+
+func (d *DOM) TagName(o dom.Object) string {
+	return nodeOf(o).nodeName()
+}
+
+func (d *DOM) NamespaceURI(o dom.Object) string {
+	return nodeOf(o).namespace
+}
+
+func (d *DOM) Prefix(o dom.Object) string {
+	return nodeOf(o).prefix
+}
+
+func (d *DOM) LocalName(o dom.Object) string {
+	return nodeOf(o).name
+}
+
+func (d *DOM) InnerHTML(o dom.Object) string {
+	return innerHTML(nodeOf(o))
+}
+
+func (d *DOM) SetInnerHTML(o dom.Object, html string) {
+	e := nodeOf(o)
+	e.removeChildren()
+	for _, c := range parseHTML(e.owner(), html) {
+		e.appendChild(c)
+	}
+}
+
+func (d *DOM) Attributes(o dom.Object) []dom.Attr {
+	e := nodeOf(o)
+	attrs := make([]dom.Attr, len(e.attrs))
+	for i, a := range e.attrs {
+		attrs[i] = dom.Attr{
+			Name:         a.name,
+			LocalName:    a.localName,
+			NamespaceURI: a.namespace,
+			Value:        a.value,
+		}
+		if a.name != a.localName {
+			attrs[i].Prefix, _ = splitQualifiedName(a.name)
+		}
+	}
+	return attrs
+}
+
+func (d *DOM) GetAttribute(o dom.Object, name string) (string, bool) {
+	e := nodeOf(o)
+	return e.attribute(e.attributeName(name))
+}
+
+func (d *DOM) SetAttribute(o dom.Object, name, value string) error {
+	e := nodeOf(o)
+	name = e.attributeName(name)
+	if err := checkAttributeName(name, "setAttribute"); err != nil {
+		return err
+	}
+	e.setAttribute(name, value)
+	return nil
+}
+
+func (d *DOM) RemoveAttribute(o dom.Object, name string) {
+	e := nodeOf(o)
+	e.removeAttribute(e.attributeName(name))
+}
+
+func (d *DOM) ToggleAttribute(o dom.Object, name string, force bool) (bool, error) {
+	e := nodeOf(o)
+	name = e.attributeName(name)
+	if err := checkAttributeName(name, "toggleAttribute"); err != nil {
+		return false, err
+	}
+	_, ok := e.attribute(name)
+	switch {
+	case ok && !force:
+		e.removeAttribute(name)
+	case !ok && force:
+		e.setAttribute(name, "")
+	}
+	return force, nil
+}
+
+func (d *DOM) GetAttributeNS(o dom.Object, namespace, localName string) (string, bool) {
+	return nodeOf(o).attributeNS(namespace, localName)
+}
+
+func (d *DOM) SetAttributeNS(o dom.Object, namespace, qualifiedName, value string) error {
+	if err := validateQualifiedName(namespace, qualifiedName, "setAttributeNS", "Element"); err != nil {
+		return err
+	}
+	nodeOf(o).setAttributeNS(namespace, qualifiedName, value)
+	return nil
+}
+
+func (d *DOM) RemoveAttributeNS(o dom.Object, namespace, localName string) {
+	nodeOf(o).removeAttributeNS(namespace, localName)
+}
+
+func (d *DOM) Closest(o dom.Object, selector string) (dom.Object, error) {
+	sel, err := parseSelectorArg(selector, "closest", "Element")
+	if err != nil {
+		return nil, err
+	}
+	for e := nodeOf(o); e != nil && e.nodeType == elementNode; e = e.parent {
+		if sel.match(e, nil) {
+			return e, nil
+		}
+	}
+	return nil, nil
+}
+
+func (d *DOM) ClassList(o dom.Object) dom.Object {
+	return nodeOf(o).getClassList()
+}
+
+func (d *DOM) Style(o dom.Object) dom.Object {
+	return nodeOf(o).getStyle()
+}
+
+// Focus makes the element the active element of its document and fires focus events
+func (d *DOM) Focus(o dom.Object) {
+	e := nodeOf(o)
+	doc := e.owner()
+	if !e.isConnected() || doc.focused == e {
+		return
+	}
+	if doc.focused != nil {
+		d.Blur(doc.focused)
+	}
+	doc.focused = e
+	dispatch(e, d.newEvent("focus", eventInit{}))
+	dispatch(e, d.newEvent("focusin", eventInit{bubbles: true}))
+}
+
+// Blur removes the focus from the element and fires blur events
+func (d *DOM) Blur(o dom.Object) {
+	e := nodeOf(o)
+	doc := e.owner()
+	if doc.focused != e {
+		return
+	}
+	doc.focused = nil
+	dispatch(e, d.newEvent("blur", eventInit{}))
+	dispatch(e, d.newEvent("focusout", eventInit{bubbles: true}))
+}
+
+// Click fires a click event that bubbles and can be canceled
+func (d *DOM) Click(o dom.Object) {
+	dispatch(nodeOf(o), d.newEvent("click", eventInit{bubbles: true, cancelable: true, composed: true}))
+}
+
+// tokenListOf returns the token list behind an object
+func tokenListOf(o dom.Object) *tokenList {
+	return o.(*tokenList)
+}
+
+func (d *DOM) AddTokens(o dom.Object, tokens []string) error {
+	if err := checkTokens(tokens, "add"); err != nil {
+		return err
+	}
+	l := tokenListOf(o)
+	current := l.tokens()
+	for _, token := range tokens {
+		if indexOf(current, token) < 0 {
+			current = append(current, token)
+		}
+	}
+	l.setTokens(current)
+	return nil
+}
+
+func (d *DOM) RemoveTokens(o dom.Object, tokens []string) error {
+	if err := checkTokens(tokens, "remove"); err != nil {
+		return err
+	}
+	l := tokenListOf(o)
+	current := l.tokens()
+	for _, token := range tokens {
+		if i := indexOf(current, token); i >= 0 {
+			current = append(current[:i], current[i+1:]...)
+		}
+	}
+	l.setTokens(current)
+	return nil
+}
+
+func (d *DOM) ToggleToken(o dom.Object, token string) (bool, error) {
+	return d.ToggleTokenForce(o, token, !d.ContainsToken(o, token))
+}
+
+func (d *DOM) ToggleTokenForce(o dom.Object, token string, force bool) (bool, error) {
+	if err := checkTokens([]string{token}, "toggle"); err != nil {
+		return false, err
+	}
+	l := tokenListOf(o)
+	tokens := l.tokens()
+	i := indexOf(tokens, token)
+	switch {
+	case i >= 0 && !force:
+		l.setTokens(append(tokens[:i], tokens[i+1:]...))
+	case i < 0 && force:
+		l.setTokens(append(tokens, token))
+	}
+	return force, nil
+}
+
+func (d *DOM) ReplaceToken(o dom.Object, oldToken, newToken string) (bool, error) {
+	if err := checkTokens([]string{oldToken, newToken}, "replace"); err != nil {
+		return false, err
+	}
+	l := tokenListOf(o)
+	tokens := l.tokens()
+	if indexOf(tokens, oldToken) < 0 {
+		return false, nil
+	}
+	var replaced []string
+	for _, token := range tokens {
+		if token == oldToken {
+			token = newToken
+		}
+		if indexOf(replaced, token) < 0 {
+			replaced = append(replaced, token)
+		}
+	}
+	l.setTokens(replaced)
+	return true, nil
+}
+
+func (d *DOM) ContainsToken(o dom.Object, token string) bool {
+	return indexOf(tokenListOf(o).tokens(), token) >= 0
+}
+
+func (d *DOM) TokenCount(o dom.Object) int {
+	return len(tokenListOf(o).tokens())
+}
+
+func (d *DOM) Token(o dom.Object, i int) (string, bool) {
+	if tokens := tokenListOf(o).tokens(); i >= 0 && i < len(tokens) {
+		return tokens[i], true
+	}
+	return "", false
+}
+
+func (d *DOM) TokenListValue(o dom.Object) string {
+	l := tokenListOf(o)
+	value, _ := l.owner.attribute(l.attribute)
+	return value
+}
@@ -0,0 +1,308 @@
+//go:build !js || !wasm
+// +build !js !wasm
+
+package memdom
+
+import (
+	"time"
+
+	"github.com/abdorrahmani/go-wasm/dom"
+)
+
+// Event phases, matching the constants of the dom package
+const (
+	phaseNone      = 0
+	phaseCapturing = 1
+	phaseAtTarget  = 2
+	phaseBubbling  = 3
+)
+
+// target is implemented by the objects events can be dispatched to
+type target interface {
+	listenerList() *eventTarget
+	// parentTarget returns the next target of the event path, or nil
+	parentTarget() target
+}
+
+func (n *node) parentTarget() target {
+	if n.parent == nil {
+		return nil
+	}
+	return n.parent
+}
+
+// listener is an event listener registered with AddEventListener
+type listener struct {
+	eventType string
+	handler   func(dom.Object)
+	capture   bool
+	once      bool
+	passive   bool
+	removed   bool
+}
+
+// eventTarget holds the event listeners of a target
+type eventTarget struct {
+	listeners []*listener
+}
+
+func (t *eventTarget) listenerList() *eventTarget {
+	return t
+}
+
+func (t *eventTarget) remove(l *listener) {
+	l.removed = true
+	for i, other := range t.listeners {
+		if other == l {
+			t.listeners = append(t.listeners[:i], t.listeners[i+1:]...)
+			return
+		}
+	}
+}
+
+// dispatch dispatches e to t through the capture, target and bubble phases.
+// It returns false if a listener canceled the event.
+func dispatch(t target, e *event) bool {
+	if e.dispatching {
+		panic(domException("InvalidStateError", "Failed to execute 'dispatchEvent' on 'EventTarget': The event is already being dispatched."))
+	}
+	e.dispatching = true
+	e.target = t
+
+	var path []target
+	for p := t; p != nil; p = p.parentTarget() {
+		path = append(path, p)
+	}
+
+	e.phase = phaseCapturing
+	for i := len(path) - 1; i > 0 && !e.stopped; i-- {
+		e.invoke(path[i], true)
+	}
+	e.phase = phaseAtTarget
+	if !e.stopped {
+		e.invoke(path[0], true)
+	}
+	if !e.stopped {
+		e.invoke(path[0], false)
+	}
+	if e.init.bubbles {
+		e.phase = phaseBubbling
+		for i := 1; i < len(path) && !e.stopped; i++ {
+			e.invoke(path[i], false)
+		}
+	}
+
+	e.phase = phaseNone
+	e.currentTarget = nil
+	e.dispatching = false
+	e.stopped = false
+	e.stoppedImmediately = false
+	return !e.canceled
+}
+
+// invoke calls the listeners of t registered for the given phase
+func (e *event) invoke(t target, capture bool) {
+	e.currentTarget = t
+	listeners := t.listenerList()
+	for _, l := range append([]*listener(nil), listeners.listeners...) {
+		if l.removed || l.eventType != e.eventType || l.capture != capture {
+			continue
+		}
+		if l.once {
+			listeners.remove(l)
+		}
+		e.inPassive = l.passive
+		l.handler(e)
+		e.inPassive = false
+		if e.stoppedImmediately {
+			return
+		}
+	}
+}
+
+// eventInit holds the flags an event is created with
+type eventInit struct {
+	bubbles    bool
+	cancelable bool
+	composed   bool
+}
+
+// event implements Event
+type event struct {
+	eventType string
+	init      eventInit
+
+	target        target
+	currentTarget target
+	phase         int
+
+	stopped            bool
+	stoppedImmediately bool
+	canceled           bool
+	inPassive          bool
+	dispatching        bool
+
+	timeStamp float64
+}
+
+// newEvent creates an event with a time stamp relative to the time origin
+func (d *DOM) newEvent(eventType string, init eventInit) *event {
+	return &event{
+		eventType: eventType,
+		init:      init,
+		timeStamp: float64(time.Since(d.timeOrigin)) / float64(time.Millisecond),
+	}
+}
+
+// eventOf returns the event behind an object
+func eventOf(o dom.Object) *event {
+	return o.(*event)
+}
+
+func (d *DOM) NewEvent(eventType string, init dom.EventInit) dom.Object {
+	return d.newEvent(eventType, eventInit{
+		bubbles:    init.Bubbles,
+		cancelable: init.Cancelable,
+		composed:   init.Composed,
+	})
+}
+
+func (d *DOM) EventType(o dom.Object) string {
+	return eventOf(o).eventType
+}
+
+func (d *DOM) Target(o dom.Object) dom.Object {
+	if e := eventOf(o); e.target != nil {
+		return e.target
+	}
+	return nil
+}
+
+func (d *DOM) CurrentTarget(o dom.Object) dom.Object {
+	if e := eventOf(o); e.currentTarget != nil {
+		return e.currentTarget
+	}
+	return nil
+}
+
+func (d *DOM) EventPhase(o dom.Object) int {
+	return eventOf(o).phase
+}
+
+func (d *DOM) Bubbles(o dom.Object) bool {
+	return eventOf(o).init.bubbles
+}
+
+func (d *DOM) Cancelable(o dom.Object) bool {
+	return eventOf(o).init.cancelable
+}
+
+func (d *DOM) TimeStamp(o dom.Object) float64 {
+	return eventOf(o).timeStamp
+}
+
+func (d *DOM) DefaultPrevented(o dom.Object) bool {
+	return eventOf(o).canceled
+}
+
+// IsTrusted returns false, as every event is dispatched by the program
+func (d *DOM) IsTrusted(o dom.Object) bool {
+	return false
+}
+
+func (d *DOM) CancelBubble(o dom.Object) bool {
+	return eventOf(o).stopped
+}
+
+func (d *DOM) StopPropagation(o dom.Object) {
+	eventOf(o).stopped = true
+}
+
+func (d *DOM) StopImmediatePropagation(o dom.Object) {
+	e := eventOf(o)
+	e.stopped = true
+	e.stoppedImmediately = true
+}
+
+// PreventDefault cancels the event, unless it is not cancelable or the
+// listener was registered as passive
+func (d *DOM) PreventDefault(o dom.Object) {
+	if e := eventOf(o); e.init.cancelable && !e.inPassive {
+		e.canceled = true
+	}
+}
+
+func (d *DOM) AddEventListener(o dom.Object, eventType string, handler func(dom.Object), opts dom.AddEventListenerOptions) func() {
+	listeners := o.(target).listenerList()
+	var signal *abortSignal
+	if opts.Signal != nil {
+		signal = opts.Signal.Value.(*abortSignal)
+		if signal.aborted {
+			return func() {}
+		}
+	}
+	l := &listener{
+		eventType: eventType,
+		handler:   handler,
+		capture:   opts.Capture,
+		once:      opts.Once,
+		passive:   opts.Passive,
+	}
+	listeners.listeners = append(listeners.listeners, l)
+	if signal != nil {
+		signal.algorithms = append(signal.algorithms, func() {
+			listeners.remove(l)
+		})
+	}
+	return func() {
+		listeners.remove(l)
+	}
+}
+
+// DispatchEvent dispatches the event at the target. It panics with an
+// InvalidStateError if the event is already being dispatched.
+func (d *DOM) DispatchEvent(o, event dom.Object) bool {
+	return dispatch(o.(target), eventOf(event))
+}
+
+// abortController implements AbortController
+type abortController struct {
+	signal *abortSignal
+}
+
+// abortSignal implements AbortSignal
+type abortSignal struct {
+	eventTarget
+	aborted bool
+	// algorithms run when the signal is aborted, e.g. to remove listeners
+	algorithms []func()
+}
+
+func (s *abortSignal) parentTarget() target {
+	return nil
+}
+
+func (d *DOM) NewAbortController() (dom.Object, dom.Object) {
+	c := &abortController{signal: &abortSignal{}}
+	return c, c.signal
+}
+
+// Abort aborts the signal of the controller, removes the listeners registered
+// with it and fires the abort event
+func (d *DOM) Abort(o dom.Object) {
+	s := o.(*abortController).signal
+	if s.aborted {
+		return
+	}
+	s.aborted = true
+	algorithms := s.algorithms
+	s.algorithms = nil
+	for _, algorithm := range algorithms {
+		algorithm()
+	}
+	dispatch(s, d.newEvent("abort", eventInit{}))
+}
+
+func (d *DOM) Aborted(o dom.Object) bool {
+	return o.(*abortSignal).aborted
+}
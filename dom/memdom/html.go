@@ -0,0 +1,323 @@
+//go:build !js || !wasm
+// +build !js !wasm
+
+package memdom
+
+import (
+	"html"
+	"slices"
+	"strings"
+)
+
+// voidElements are the elements that never have children or an end tag
+var voidElements = map[string]bool{
+	"area": true, "base": true, "br": true, "col": true, "embed": true, "hr": true, "img": true,
+	"input": true, "link": true, "meta": true, "source": true, "track": true, "wbr": true,
+}
+
+// rawTextElements are the elements whose contents are not parsed as markup
+var rawTextElements = map[string]bool{
+	"script": true, "style": true, "textarea": true, "title": true,
+}
+
+// closesParagraph lists the start tags that end an open p element
+var closesParagraph = map[string]bool{
+	"address": true, "article": true, "aside": true, "blockquote": true, "details": true, "dialog": true,
+	"dd": true, "div": true, "dl": true, "dt": true, "fieldset": true, "figcaption": true, "figure": true,
+	"footer": true, "form": true, "h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true,
+	"header": true, "hgroup": true, "hr": true, "li": true, "main": true, "menu": true, "nav": true,
+	"ol": true, "p": true, "pre": true, "section": true, "summary": true, "table": true, "ul": true,
+}
+
+// impliedEnds maps the start tags that end open elements implicitly to the
+// elements they end and the elements that stop the search for them
+var impliedEnds = map[string]struct{ ends, scope []string }{
+	"li":       {[]string{"li"}, []string{"ul", "ol", "menu", "table", "td", "th"}},
+	"dd":       {[]string{"dd", "dt"}, []string{"dl", "table", "td", "th"}},
+	"dt":       {[]string{"dd", "dt"}, []string{"dl", "table", "td", "th"}},
+	"tr":       {[]string{"tr"}, []string{"table", "tbody", "thead", "tfoot"}},
+	"td":       {[]string{"td", "th"}, []string{"tr", "table"}},
+	"th":       {[]string{"td", "th"}, []string{"tr", "table"}},
+	"tbody":    {[]string{"tbody", "thead", "tfoot"}, []string{"table"}},
+	"thead":    {[]string{"tbody", "thead", "tfoot"}, []string{"table"}},
+	"tfoot":    {[]string{"tbody", "thead", "tfoot"}, []string{"table"}},
+	"option":   {[]string{"option"}, nil},
+	"optgroup": {[]string{"option", "optgroup"}, nil},
+}
+
+// paragraphScope lists the elements that hide an open p from the start tags
+// that would end it
+var paragraphScope = []string{"button", "table", "td", "th", "caption", "template", "object"}
+
+// closeImplied pops the elements of stack ended by the start tag of an HTML
+// element, like the first li of <li>1<li>2 or the p of <p>a<div>b
+func closeImplied(stack []*node, name string) []*node {
+	if closesParagraph[name] {
+		stack = closeOpen(stack, []string{"p"}, paragraphScope)
+	}
+	if rule, ok := impliedEnds[name]; ok {
+		stack = closeOpen(stack, rule.ends, rule.scope)
+	}
+	return stack
+}
+
+// closeOpen pops the innermost open HTML element named in ends, and the
+// elements inside it, unless an element named in scope or a foreign element
+// is reached first. With no scope, only current elements are ended, as an
+// option is by the next one.
+func closeOpen(stack []*node, ends, scope []string) []*node {
+	if scope == nil {
+		for len(stack) > 1 && stack[len(stack)-1].isHTML() && slices.Contains(ends, stack[len(stack)-1].name) {
+			stack = stack[:len(stack)-1]
+		}
+		return stack
+	}
+	for i := len(stack) - 1; i > 0; i-- {
+		n := stack[i]
+		if !n.isHTML() || slices.Contains(scope, n.name) {
+			return stack
+		}
+		if slices.Contains(ends, n.name) {
+			return stack[:i]
+		}
+	}
+	return stack
+}
+
+func innerHTML(n *node) string {
+	var b strings.Builder
+	for _, c := range n.children {
+		writeHTML(&b, c)
+	}
+	return b.String()
+}
+
+var (
+	textEscaper      = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", "\u00a0", "&nbsp;")
+	attributeEscaper = strings.NewReplacer("&", "&amp;", "\"", "&quot;", "\u00a0", "&nbsp;")
+)
+
+// writeHTML serializes n like the HTML fragment serialization algorithm
+func writeHTML(b *strings.Builder, n *node) {
+	switch n.nodeType {
+	case textNode:
+		if n.parent != nil && n.parent.nodeType == elementNode && rawTextElements[n.parent.name] && n.parent.name != "textarea" && n.parent.name != "title" {
+			b.WriteString(n.data)
+			return
+		}
+		b.WriteString(textEscaper.Replace(n.data))
+	case commentNode:
+		b.WriteString("<!--" + n.data + "-->")
+	case elementNode:
+		b.WriteString("<" + n.qualifiedName())
+		for _, a := range n.attrs {
+			b.WriteString(" " + a.name + "=\"" + attributeEscaper.Replace(a.value) + "\"")
+		}
+		b.WriteString(">")
+		if n.isHTML() && voidElements[n.name] {
+			return
+		}
+		for _, c := range n.children {
+			writeHTML(b, c)
+		}
+		b.WriteString("</" + n.qualifiedName() + ">")
+	default:
+		for _, c := range n.children {
+			writeHTML(b, c)
+		}
+	}
+}
+
+// parseHTML parses an HTML fragment into nodes owned by doc.
+// It handles elements, attributes, text, comments and character references,
+// the end tags implied by start tags, such as those of li, p, option, tr and
+// td, and closes unclosed elements at the end of the input. Other error
+// recovery rules of the HTML parser, such as inserting tbody, are not
+// implemented.
+func parseHTML(doc *node, s string) []*node {
+	root := newNode(doc, fragmentNode, "#document-fragment")
+	stack := []*node{root}
+	current := func() *node {
+		return stack[len(stack)-1]
+	}
+	appendText := func(text string) {
+		if text == "" {
+			return
+		}
+		parent := current()
+		if last := parent.lastChild(); last != nil && last.nodeType == textNode {
+			last.data += text
+			return
+		}
+		t := newNode(doc, textNode, "#text")
+		t.data = text
+		parent.appendChild(t)
+	}
+
+	for len(s) > 0 {
+		lt := strings.IndexByte(s, '<')
+		if lt < 0 {
+			appendText(html.UnescapeString(s))
+			break
+		}
+		appendText(html.UnescapeString(s[:lt]))
+		s = s[lt:]
+
+		switch {
+		case strings.HasPrefix(s, "<!--"):
+			end := strings.Index(s[4:], "-->")
+			comment := newNode(doc, commentNode, "#comment")
+			if end < 0 {
+				comment.data, s = s[4:], ""
+			} else {
+				comment.data, s = s[4:4+end], s[4+end+3:]
+			}
+			current().appendChild(comment)
+		case strings.HasPrefix(s, "</"):
+			end := strings.IndexByte(s, '>')
+			if end < 0 {
+				s = ""
+				break
+			}
+			name := strings.ToLower(strings.TrimSpace(s[2:end]))
+			s = s[end+1:]
+			for i := len(stack) - 1; i > 0; i-- {
+				if stack[i].name == name {
+					stack = stack[:i]
+					break
+				}
+			}
+		case strings.HasPrefix(s, "<!") || strings.HasPrefix(s, "<?"):
+			// Doctypes and processing instructions are dropped
+			end := strings.IndexByte(s, '>')
+			if end < 0 {
+				s = ""
+				break
+			}
+			s = s[end+1:]
+		case len(s) > 1 && isTagStart(s[1]):
+			var e *node
+			var selfClosing bool
+			e, selfClosing, s = parseStartTag(doc, current(), s)
+			if e.isHTML() {
+				stack = closeImplied(stack, e.name)
+			}
+			current().appendChild(e)
+			switch {
+			case e.isHTML() && rawTextElements[e.name]:
+				end := strings.Index(strings.ToLower(s), "</"+e.name)
+				if end < 0 {
+					end = len(s)
+				}
+				text := s[:end]
+				if e.name == "textarea" || e.name == "title" {
+					text = html.UnescapeString(text)
+				}
+				if text != "" {
+					t := newNode(doc, textNode, "#text")
+					t.data = text
+					e.appendChild(t)
+				}
+				s = s[end:]
+				if close := strings.IndexByte(s, '>'); close >= 0 {
+					s = s[close+1:]
+				}
+			case !selfClosing && !(e.isHTML() && voidElements[e.name]):
+				stack = append(stack, e)
+			}
+		default:
+			appendText("<")
+			s = s[1:]
+		}
+	}
+
+	children := append([]*node(nil), root.children...)
+	root.removeChildren()
+	return children
+}
+
+// foreignNamespace returns the namespace of an svg or math element and of the
+// descendants of such elements, which are parsed as foreign content
+func foreignNamespace(parent *node, name string) string {
+	switch {
+	case name == "svg":
+		return svgNamespace
+	case name == "math":
+		return mathMLNamespace
+	case parent.nodeType == elementNode && !parent.isHTML():
+		return parent.namespace
+	}
+	return ""
+}
+
+func isTagStart(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f'
+}
+
+// parseStartTag parses the start tag at the beginning of s and returns the
+// element, whether the tag was self-closing and the rest of the input
+func parseStartTag(doc, parent *node, s string) (*node, bool, string) {
+	i := 1
+	for i < len(s) && !isSpace(s[i]) && s[i] != '>' && s[i] != '/' {
+		i++
+	}
+	e := doc.createElement(s[1:i])
+	if namespace := foreignNamespace(parent, e.name); namespace != "" {
+		e.namespace = namespace
+	}
+
+	for {
+		for i < len(s) && isSpace(s[i]) {
+			i++
+		}
+		switch {
+		case i >= len(s):
+			return e, false, ""
+		case s[i] == '>':
+			return e, false, s[i+1:]
+		case strings.HasPrefix(s[i:], "/>"):
+			return e, true, s[i+2:]
+		case s[i] == '/':
+			i++
+			continue
+		}
+
+		start := i
+		for i < len(s) && !isSpace(s[i]) && s[i] != '>' && s[i] != '=' && !strings.HasPrefix(s[i:], "/>") {
+			i++
+		}
+		name := e.attributeName(s[start:i])
+		for i < len(s) && isSpace(s[i]) {
+			i++
+		}
+		value := ""
+		if i < len(s) && s[i] == '=' {
+			i++
+			for i < len(s) && isSpace(s[i]) {
+				i++
+			}
+			if i < len(s) && (s[i] == '"' || s[i] == '\'') {
+				quote := s[i]
+				end := strings.IndexByte(s[i+1:], quote)
+				if end < 0 {
+					end = len(s) - i - 1
+				}
+				value = s[i+1 : i+1+end]
+				i += end + 2
+			} else {
+				start := i
+				for i < len(s) && !isSpace(s[i]) && s[i] != '>' {
+					i++
+				}
+				value = s[start:i]
+			}
+		}
+		if _, ok := e.attribute(name); !ok && name != "" {
+			e.setAttribute(name, html.UnescapeString(value))
+		}
+	}
+}
@@ -0,0 +1,76 @@
+//go:build !js || !wasm
+// +build !js !wasm
+
+// Package memdom implements dom.Backend with an in-memory DOM written in Go,
+// so code written against the dom package can run and be tested with a plain
+// go test, outside a browser:
+//
+//	func TestMain(m *testing.M) {
+//		memdom.Install()
+//		os.Exit(m.Run())
+//	}
+//
+// It models the document tree, attributes, classes, inline styles, selectors
+// and event dispatch with capture and bubble phases. Nothing is rendered or
+// loaded, so the document is always complete and its URL is about:blank.
+package memdom
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/abdorrahmani/go-wasm/dom"
+)
+
+// Namespaces with special meaning to the DOM
+const (
+	xhtmlNamespace  = "http://www.w3.org/1999/xhtml"
+	svgNamespace    = "http://www.w3.org/2000/svg"
+	mathMLNamespace = "http://www.w3.org/1998/Math/MathML"
+	xmlNamespace    = "http://www.w3.org/XML/1998/namespace"
+	xmlnsNamespace  = "http://www.w3.org/2000/xmlns/"
+)
+
+// DOM is an in-memory DOM with a single document. Its objects are plain Go
+// values and must not be shared between DOMs.
+type DOM struct {
+	document *node
+	// timeOrigin is the time event time stamps are relative to
+	timeOrigin time.Time
+}
+
+var _ dom.Backend = (*DOM)(nil)
+
+// New creates a DOM whose document contains empty html, head and body elements
+func New() *DOM {
+	doc := newNode(nil, documentNode, "#document")
+	html := doc.createElement("html")
+	html.appendChild(doc.createElement("head"))
+	html.appendChild(doc.createElement("body"))
+	doc.appendChild(html)
+	return &DOM{document: doc, timeOrigin: time.Now()}
+}
+
+// Install creates a new DOM and makes it the backend of the dom package.
+// Calling Install again replaces the document.
+func Install() *DOM {
+	d := New()
+	dom.SetBackend(d)
+	return d
+}
+
+// DOMException is the error returned where a browser throws a DOMException
+type DOMException struct {
+	// Name is the exception name, e.g. "HierarchyRequestError" or "SyntaxError"
+	Name    string
+	Message string
+}
+
+func (e *DOMException) Error() string {
+	return e.Name + ": " + e.Message
+}
+
+// domException returns a DOMException with a formatted message
+func domException(name, format string, args ...interface{}) error {
+	return &DOMException{Name: name, Message: fmt.Sprintf(format, args...)}
+}
@@ -0,0 +1,369 @@
+//go:build !js || !wasm
+// +build !js !wasm
+
+package memdom
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/abdorrahmani/go-wasm/dom"
+)
+
+func TestMemDOM(t *testing.T) {
+	fmt.Println("Starting in-memory DOM tests...")
+
+	Install()
+	document := dom.Global()
+	body := document.GetBody()
+	asNode := func(e *dom.Element) *dom.Node {
+		return &dom.Node{Value: e.Value}
+	}
+
+	tests := []struct {
+		name     string
+		validate func() error
+	}{
+		{
+			name: "Events capture and bubble in order",
+			validate: func() error {
+				outer := document.CreateElement("div")
+				inner := document.CreateElement("span")
+				if err := outer.AppendChild(asNode(inner)); err != nil {
+					return err
+				}
+				if err := body.AppendChild(asNode(outer)); err != nil {
+					return err
+				}
+				defer body.RemoveChild(asNode(outer))
+
+				var order []string
+				record := func(label string) func(*dom.Event) {
+					return func(e *dom.Event) {
+						order = append(order, fmt.Sprintf("%s:%d", label, e.GetEventPhase()))
+					}
+				}
+				l1 := body.AddEventListenerWithOptions("click", record("body-capture"), dom.AddEventListenerOptions{Capture: true})
+				defer l1.Remove()
+				l2 := outer.AddEventListener("click", record("outer-bubble"))
+				defer l2.Remove()
+				l3 := inner.AddEventListener("click", record("inner"))
+				defer l3.Remove()
+
+				inner.Click()
+				if got := strings.Join(order, " "); got != "body-capture:1 inner:2 outer-bubble:3" {
+					return fmt.Errorf("unexpected dispatch order: %s", got)
+				}
+				return nil
+			},
+		},
+		{
+			name: "stopPropagation and preventDefault",
+			validate: func() error {
+				outer := document.CreateElement("div")
+				inner := document.CreateElement("button")
+				if err := outer.AppendChild(asNode(inner)); err != nil {
+					return err
+				}
+
+				reached, prevented := false, false
+				inner.AddEventListener("click", func(e *dom.Event) {
+					e.StopPropagation()
+					e.PreventDefault()
+				})
+				inner.AddEventListener("click", func(e *dom.Event) { prevented = e.GetDefaultPrevented() })
+				outer.AddEventListener("click", func(e *dom.Event) { reached = true })
+
+				inner.Click()
+				if reached {
+					return fmt.Errorf("StopPropagation should keep the event from bubbling")
+				}
+				if !prevented {
+					return fmt.Errorf("GetDefaultPrevented should be true")
+				}
+
+				passive := document.CreateElement("div")
+				passive.AddEventListenerWithOptions("click", func(e *dom.Event) { e.PreventDefault() }, dom.AddEventListenerOptions{Passive: true})
+				passive.AddEventListener("click", func(e *dom.Event) { prevented = e.GetDefaultPrevented() })
+				passive.Click()
+				if prevented {
+					return fmt.Errorf("a passive listener should not cancel the event")
+				}
+				return nil
+			},
+		},
+		{
+			name: "Events created with NewEvent follow their init",
+			validate: func() error {
+				outer := document.CreateElement("div")
+				inner := document.CreateElement("span")
+				if err := outer.AppendChild(asNode(inner)); err != nil {
+					return err
+				}
+
+				reached := false
+				outer.AddEventListener("ping", func(*dom.Event) { reached = true })
+				inner.AddEventListener("ping", func(e *dom.Event) { e.PreventDefault() })
+				if !inner.DispatchEvent(dom.NewEvent("ping", dom.EventInit{})) {
+					return fmt.Errorf("an event that is not cancelable should not be canceled")
+				}
+				if reached {
+					return fmt.Errorf("an event that does not bubble should not reach the parent")
+				}
+
+				event := dom.NewEvent("ping", dom.EventInit{Bubbles: true, Cancelable: true})
+				if inner.DispatchEvent(event) {
+					return fmt.Errorf("DispatchEvent should return false for a canceled event")
+				}
+				if !reached || !event.GetDefaultPrevented() {
+					return fmt.Errorf("the event should bubble and be canceled")
+				}
+				return nil
+			},
+		},
+		{
+			name: "Delegated listeners match the closest element",
+			validate: func() error {
+				list := document.CreateElement("ul")
+				list.SetInnerHTML(`<li class="item"><span>a</span></li><li>b</li>`)
+				var matched []string
+				l, err := list.On("click", "li.item", func(e *dom.DelegatedEvent) {
+					matched = append(matched, e.DelegateTarget.GetTagName())
+				})
+				if err != nil {
+					return err
+				}
+				defer l.Remove()
+
+				item := list.GetFirstChild()
+				(&dom.Element{Value: item.GetFirstChild().Value}).Click()
+				(&dom.Element{Value: item.GetNextSibling().Value}).Click()
+				if got := strings.Join(matched, " "); got != "LI" {
+					return fmt.Errorf("unexpected matches: %q", got)
+				}
+				if _, err := list.On("click", "li[", func(*dom.DelegatedEvent) {}); err == nil {
+					return fmt.Errorf("expected an error for an invalid selector")
+				}
+				return nil
+			},
+		},
+		{
+			name: "Style, class list and dataset stay in sync with attributes",
+			validate: func() error {
+				el := document.CreateElement("div")
+				el.GetStyle().SetBackgroundColor("red")
+				el.GetStyle().SetProperty("margin-top", "4px")
+				if got := el.GetAttribute("style"); got != "background-color: red; margin-top: 4px;" {
+					return fmt.Errorf("unexpected style attribute: %q", got)
+				}
+				el.SetAttribute("style", "color: blue")
+				if el.GetStyle().GetColor() != "blue" || el.GetStyle().GetPropertyValue("margin-top") != "" {
+					return fmt.Errorf("style should follow the style attribute")
+				}
+
+				if err := el.ClassList().Add("a", "b"); err != nil {
+					return err
+				}
+				if _, err := el.ClassList().Toggle("a"); err != nil {
+					return err
+				}
+				if el.GetClassName() != "b" {
+					return fmt.Errorf("unexpected className: %q", el.GetClassName())
+				}
+				if err := el.ClassList().Add("c d"); err == nil {
+					return fmt.Errorf("expected an error for a token with whitespace")
+				}
+
+				el.Dataset().SetInt("userId", 7)
+				if got := el.GetAttribute("data-user-id"); got != "7" {
+					return fmt.Errorf("unexpected data attribute: %q", got)
+				}
+				if keys := el.Dataset().Keys(); len(keys) != 1 || keys[0] != "userId" {
+					return fmt.Errorf("unexpected dataset keys: %v", keys)
+				}
+				return nil
+			},
+		},
+		{
+			name: "innerHTML parses and serializes markup",
+			validate: func() error {
+				el := document.CreateElement("div")
+				el.SetInnerHTML(`<p class="x">a &amp; b<br></p><!--c--><ul><li>1<li>2</ul>`)
+				if got := el.GetInnerHTML(); got != `<p class="x">a &amp; b<br></p><!--c--><ul><li>1</li><li>2</li></ul>` {
+					return fmt.Errorf("unexpected innerHTML: %s", got)
+				}
+				if err := body.AppendChild(asNode(el)); err != nil {
+					return err
+				}
+				defer body.RemoveChild(asNode(el))
+				br, err := document.QuerySelector("p.x > br")
+				if err != nil {
+					return err
+				}
+				if br == nil {
+					return fmt.Errorf("QuerySelector should find the br element")
+				}
+				items, err := document.QuerySelectorAll("li")
+				if err != nil {
+					return err
+				}
+				if len(items) != 2 {
+					return fmt.Errorf("expected 2 list items, got %d", len(items))
+				}
+				return nil
+			},
+		},
+		{
+			name: "Start tags imply the end tags of open elements",
+			validate: func() error {
+				tests := []struct{ markup, want string }{
+					{`<p>a<p>b<div>c</div>`, `<p>a</p><p>b</p><div>c</div>`},
+					{`<ul><li>a<ul><li>b</ul><li>c</ul>`, `<ul><li>a<ul><li>b</li></ul></li><li>c</li></ul>`},
+					{`<select><option>a<option>b<optgroup><option>c<optgroup></select>`, `<select><option>a</option><option>b</option><optgroup><option>c</option></optgroup><optgroup></optgroup></select>`},
+					{`<table><tbody><tr><td>a<td>b<tr><th>c</table>`, `<table><tbody><tr><td>a</td><td>b</td></tr><tr><th>c</th></tr></tbody></table>`},
+					{`<dl><dt>a<dd>b<dt>c</dl>`, `<dl><dt>a</dt><dd>b</dd><dt>c</dt></dl>`},
+					{`<p>a<button><div>b</div></button>c<div>d</div>`, `<p>a<button><div>b</div></button>c</p><div>d</div>`},
+				}
+				for _, tt := range tests {
+					el := document.CreateElement("div")
+					el.SetInnerHTML(tt.markup)
+					if got := el.GetInnerHTML(); got != tt.want {
+						return fmt.Errorf("unexpected innerHTML of %s: %s", tt.markup, got)
+					}
+				}
+				return nil
+			},
+		},
+		{
+			name: "svg markup is parsed as foreign content",
+			validate: func() error {
+				div := document.CreateElement("div")
+				div.SetInnerHTML(`<svg viewBox="0 0 1 1"><circle r="1"/></svg><br>`)
+				circle := &dom.Element{Value: div.GetFirstChild().GetFirstChild().Value}
+				if got := circle.GetNamespaceURI(); got != svgNamespace {
+					return fmt.Errorf("unexpected namespace: %s", got)
+				}
+				if got := circle.GetTagName(); got != "circle" {
+					return fmt.Errorf("unexpected tag name: %s", got)
+				}
+				if got := div.GetInnerHTML(); got != `<svg viewBox="0 0 1 1"><circle r="1"></circle></svg><br>` {
+					return fmt.Errorf("unexpected markup: %s", got)
+				}
+				return nil
+			},
+		},
+		{
+			name: "Namespaced attributes are listed with their prefix",
+			validate: func() error {
+				use, err := document.CreateElementNS(dom.SVGNamespace, "use")
+				if err != nil {
+					return err
+				}
+				if err := use.SetAttributeNS(dom.XLinkNamespace, "xlink:href", "#a"); err != nil {
+					return err
+				}
+				use.SetAttribute("x", "1")
+				if got := use.GetAttributeNS(dom.XLinkNamespace, "href"); got != "#a" {
+					return fmt.Errorf("unexpected href: %q", got)
+				}
+				attrs := use.Attributes()
+				if len(attrs) != 2 || attrs[0].Prefix != "xlink" || attrs[0].LocalName != "href" || attrs[1].Name != "x" {
+					return fmt.Errorf("unexpected attributes: %+v", attrs)
+				}
+				if err := use.SetAttributeNS(dom.SVGNamespace, "xmlns:x", "1"); err == nil {
+					return fmt.Errorf("expected an error for the xmlns prefix outside the XMLNS namespace")
+				}
+				return nil
+			},
+		},
+		{
+			name: "Invalid operations return DOMExceptions",
+			validate: func() error {
+				parent := document.CreateElement("div")
+				child := document.CreateElement("div")
+				if err := parent.AppendChild(asNode(child)); err != nil {
+					return err
+				}
+
+				var exception *DOMException
+				err := child.AppendChild(asNode(parent))
+				if !errors.As(err, &exception) || exception.Name != "HierarchyRequestError" {
+					return fmt.Errorf("expected a HierarchyRequestError, got %v", err)
+				}
+				err = parent.RemoveChild(asNode(document.CreateElement("span")))
+				if !errors.As(err, &exception) || exception.Name != "NotFoundError" {
+					return fmt.Errorf("expected a NotFoundError, got %v", err)
+				}
+				_, err = document.QuerySelector("div[")
+				if !errors.As(err, &exception) || exception.Name != "SyntaxError" {
+					return fmt.Errorf("expected a SyntaxError, got %v", err)
+				}
+				_, err = document.CreateElementNS(svgNamespace, ":circle")
+				if !errors.As(err, &exception) || exception.Name != "InvalidCharacterError" {
+					return fmt.Errorf("expected an InvalidCharacterError, got %v", err)
+				}
+				return nil
+			},
+		},
+		{
+			name: "Listeners are removed by once and an abort signal",
+			validate: func() error {
+				el := document.CreateElement("div")
+				controller := dom.NewAbortController()
+				var once, signaled int
+				el.AddEventListenerWithOptions("click", func(*dom.Event) { once++ }, dom.AddEventListenerOptions{Once: true})
+				l := el.AddEventListenerWithOptions("click", func(*dom.Event) { signaled++ }, dom.AddEventListenerOptions{Signal: controller.Signal()})
+
+				el.Click()
+				controller.Abort()
+				el.Click()
+				if once != 1 || signaled != 1 {
+					return fmt.Errorf("expected each listener to run once, got %d and %d", once, signaled)
+				}
+				if !controller.Signal().Aborted() || !l.Removed() {
+					return fmt.Errorf("the signal should be aborted and its listener removed")
+				}
+				return nil
+			},
+		},
+		{
+			name: "Focus and click fire events",
+			validate: func() error {
+				input := document.CreateElement("input")
+				if err := body.AppendChild(asNode(input)); err != nil {
+					return err
+				}
+				defer body.RemoveChild(asNode(input))
+
+				var events []string
+				for _, eventType := range []string{"focus", "focusin", "blur", "focusout", "click"} {
+					l := input.AddEventListener(eventType, func(e *dom.Event) {
+						events = append(events, e.GetType())
+					})
+					defer l.Remove()
+				}
+				input.Focus()
+				input.Blur()
+				input.Click()
+				if got := strings.Join(events, " "); got != "focus focusin blur focusout click" {
+					return fmt.Errorf("unexpected events: %s", got)
+				}
+				return nil
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fmt.Printf("Running test: %s\n", tt.name)
+			if err := tt.validate(); err != nil {
+				t.Errorf("validation failed: %v", err)
+				fmt.Printf("❌ Test failed: %s - %v\n", tt.name, err)
+			} else {
+				fmt.Printf("✅ Test passed: %s\n", tt.name)
+			}
+		})
+	}
+}
@@ -0,0 +1,688 @@
+//go:build !js || !wasm
+// +build !js !wasm
+
+package memdom
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/abdorrahmani/go-wasm/dom"
+)
+
+// Node types, matching the constants of the dom package
+const (
+	elementNode  = 1
+	textNode     = 3
+	commentNode  = 8
+	documentNode = 9
+	fragmentNode = 11
+)
+
+// Bits returned by compareDocumentPosition
+const (
+	positionDisconnected           = 1
+	positionPreceding              = 2
+	positionFollowing              = 4
+	positionContains               = 8
+	positionContainedBy            = 16
+	positionImplementationSpecific = 32
+)
+
+// attr is an attribute of an element
+type attr struct {
+	// name is the qualified name, including the prefix if there is one
+	name      string
+	namespace string
+	localName string
+	value     string
+}
+
+// node is a node of the tree. A single type covers every node type so the
+// tree operations do not need to care which kind of node they handle.
+type node struct {
+	eventTarget
+
+	nodeType int
+	// name is the local name of elements, which is lower-case for HTML
+	// elements, and the node name of other nodes
+	name string
+	// namespace and prefix are the namespace URI and prefix of elements
+	namespace string
+	prefix    string
+	// data holds the contents of text and comment nodes
+	data  string
+	attrs []attr
+	// style and classList are created on first use
+	style     *style
+	classList *tokenList
+	// focused is the focused element of documents
+	focused *node
+
+	// doc is the owner document, or nil for documents
+	doc      *node
+	parent   *node
+	children []*node
+}
+
+func newNode(doc *node, nodeType int, name string) *node {
+	return &node{doc: doc, nodeType: nodeType, name: name}
+}
+
+// nodeOf returns the node behind an object, or nil if it is not a node
+func nodeOf(o dom.Object) *node {
+	n, _ := o.(*node)
+	return n
+}
+
+// object returns n as an object, which is nil for a missing node
+func object(n *node) dom.Object {
+	if n == nil {
+		return nil
+	}
+	return n
+}
+
+// objects converts nodes to objects
+func objects(nodes []*node) []dom.Object {
+	values := make([]dom.Object, len(nodes))
+	for i, n := range nodes {
+		values[i] = n
+	}
+	return values
+}
+
+// owner returns the document that owns n, which is n itself for documents
+func (n *node) owner() *node {
+	if n.nodeType == documentNode {
+		return n
+	}
+	return n.doc
+}
+
+func (n *node) nodeName() string {
+	if n.nodeType != elementNode {
+		return n.name
+	}
+	if n.isHTML() {
+		return strings.ToUpper(n.qualifiedName())
+	}
+	return n.qualifiedName()
+}
+
+// qualifiedName returns the prefixed name of an element
+func (n *node) qualifiedName() string {
+	if n.prefix != "" {
+		return n.prefix + ":" + n.name
+	}
+	return n.name
+}
+
+// isHTML reports whether n is an element in the HTML namespace
+func (n *node) isHTML() bool {
+	return n.nodeType == elementNode && n.namespace == xhtmlNamespace
+}
+
+func (n *node) isParentNode() bool {
+	return n.nodeType == elementNode || n.nodeType == documentNode || n.nodeType == fragmentNode
+}
+
+func (n *node) root() *node {
+	for n.parent != nil {
+		n = n.parent
+	}
+	return n
+}
+
+func (n *node) isConnected() bool {
+	return n.root().nodeType == documentNode
+}
+
+// contains reports whether other is n or one of its descendants
+func (n *node) contains(other *node) bool {
+	for ; other != nil; other = other.parent {
+		if other == n {
+			return true
+		}
+	}
+	return false
+}
+
+func (n *node) indexOf(child *node) int {
+	for i, c := range n.children {
+		if c == child {
+			return i
+		}
+	}
+	return -1
+}
+
+func (n *node) previousSibling() *node {
+	if n.parent == nil {
+		return nil
+	}
+	if i := n.parent.indexOf(n); i > 0 {
+		return n.parent.children[i-1]
+	}
+	return nil
+}
+
+func (n *node) nextSibling() *node {
+	if n.parent == nil {
+		return nil
+	}
+	if i := n.parent.indexOf(n); i < len(n.parent.children)-1 {
+		return n.parent.children[i+1]
+	}
+	return nil
+}
+
+func (n *node) firstChild() *node {
+	if len(n.children) == 0 {
+		return nil
+	}
+	return n.children[0]
+}
+
+func (n *node) lastChild() *node {
+	if len(n.children) == 0 {
+		return nil
+	}
+	return n.children[len(n.children)-1]
+}
+
+// elementChildren returns the children of n that are elements
+func (n *node) elementChildren() []*node {
+	var elements []*node
+	for _, c := range n.children {
+		if c.nodeType == elementNode {
+			elements = append(elements, c)
+		}
+	}
+	return elements
+}
+
+// walk calls f for every descendant of n in tree order, stopping when f returns false
+func (n *node) walk(f func(*node) bool) bool {
+	for _, c := range n.children {
+		if !f(c) || !c.walk(f) {
+			return false
+		}
+	}
+	return true
+}
+
+// descendantElements returns the descendant elements of n matching f, in tree order
+func (n *node) descendantElements(f func(*node) bool) []*node {
+	var elements []*node
+	n.walk(func(c *node) bool {
+		if c.nodeType == elementNode && f(c) {
+			elements = append(elements, c)
+		}
+		return true
+	})
+	return elements
+}
+
+func (n *node) textContent() string {
+	switch n.nodeType {
+	case textNode, commentNode:
+		return n.data
+	}
+	var b strings.Builder
+	n.walk(func(c *node) bool {
+		if c.nodeType == textNode {
+			b.WriteString(c.data)
+		}
+		return true
+	})
+	return b.String()
+}
+
+func (n *node) setTextContent(text string) {
+	switch n.nodeType {
+	case textNode, commentNode:
+		n.data = text
+	case elementNode, fragmentNode:
+		n.removeChildren()
+		if text != "" {
+			n.insertBefore(newNode(n.owner(), textNode, "#text"), nil).data = text
+		}
+	}
+}
+
+func (n *node) removeChildren() {
+	for len(n.children) > 0 {
+		n.removeChild(n.children[0])
+	}
+}
+
+// checkInsert returns the DOMException the browser throws when child cannot be inserted into n
+func (n *node) checkInsert(child *node, method string) error {
+	prefix := fmt.Sprintf("Failed to execute '%s' on 'Node': ", method)
+	switch {
+	case !n.isParentNode():
+		return domException("HierarchyRequestError", prefix+"This node type does not support this method.")
+	case child.contains(n):
+		return domException("HierarchyRequestError", prefix+"The new child element contains the parent.")
+	case child.nodeType == documentNode:
+		return domException("HierarchyRequestError", prefix+"Nodes of type '%s' may not be inserted inside nodes of type '%s'.", child.name, n.nodeName())
+	case n.nodeType == documentNode && child.nodeType == textNode:
+		return domException("HierarchyRequestError", prefix+"Nodes of type '#text' may not be inserted inside nodes of type '#document'.")
+	}
+	return nil
+}
+
+// insertBefore inserts child into n before ref, or at the end when ref is nil.
+// Document fragments are replaced by their children.
+func (n *node) insertBefore(child, ref *node) *node {
+	if ref == child {
+		ref = child.nextSibling()
+	}
+	nodes := []*node{child}
+	if child.nodeType == fragmentNode {
+		nodes = append([]*node(nil), child.children...)
+		child.removeChildren()
+	} else if child.parent != nil {
+		child.parent.removeChild(child)
+	}
+
+	i := len(n.children)
+	if ref != nil {
+		i = n.indexOf(ref)
+	}
+	n.children = append(n.children[:i], append(nodes, n.children[i:]...)...)
+	for _, c := range nodes {
+		c.parent = n
+		c.adopt(n.owner())
+	}
+	return child
+}
+
+// adopt moves n and its descendants to doc
+func (n *node) adopt(doc *node) {
+	if n.nodeType == documentNode || n.doc == doc {
+		return
+	}
+	n.doc = doc
+	for _, c := range n.children {
+		c.adopt(doc)
+	}
+}
+
+func (n *node) appendChild(child *node) *node {
+	return n.insertBefore(child, nil)
+}
+
+func (n *node) removeChild(child *node) *node {
+	i := n.indexOf(child)
+	n.children = append(n.children[:i], n.children[i+1:]...)
+	child.parent = nil
+	return child
+}
+
+func (n *node) replaceChild(child, old *node) *node {
+	if child == old {
+		return old
+	}
+	ref := old.nextSibling()
+	if ref == child {
+		ref = child.nextSibling()
+	}
+	n.removeChild(old)
+	n.insertBefore(child, ref)
+	return old
+}
+
+func (n *node) cloneNode(deep bool) *node {
+	clone := newNode(n.doc, n.nodeType, n.name)
+	clone.namespace, clone.prefix = n.namespace, n.prefix
+	clone.data = n.data
+	clone.attrs = append([]attr(nil), n.attrs...)
+	if deep {
+		for _, c := range n.children {
+			clone.appendChild(c.cloneNode(true))
+		}
+	}
+	return clone
+}
+
+func (n *node) isEqualNode(other *node) bool {
+	if n.nodeType != other.nodeType || n.name != other.name || n.namespace != other.namespace ||
+		n.prefix != other.prefix || n.data != other.data ||
+		len(n.attrs) != len(other.attrs) || len(n.children) != len(other.children) {
+		return false
+	}
+	for _, a := range n.attrs {
+		if value, ok := other.attributeNS(a.namespace, a.localName); !ok || value != a.value {
+			return false
+		}
+	}
+	for i, c := range n.children {
+		if !c.isEqualNode(other.children[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// normalize merges adjacent text nodes and removes empty ones
+func (n *node) normalize() {
+	for i := 0; i < len(n.children); i++ {
+		c := n.children[i]
+		if c.nodeType != textNode {
+			c.normalize()
+			continue
+		}
+		for next := c.nextSibling(); next != nil && next.nodeType == textNode; next = c.nextSibling() {
+			c.data += next.data
+			n.removeChild(next)
+		}
+		if c.data == "" {
+			n.removeChild(c)
+			i--
+		}
+	}
+}
+
+func (n *node) compareDocumentPosition(other *node) int {
+	switch {
+	case n == other:
+		return 0
+	case n.root() != other.root():
+		return positionDisconnected | positionImplementationSpecific | positionFollowing
+	case other.contains(n):
+		return positionContains | positionPreceding
+	case n.contains(other):
+		return positionContainedBy | positionFollowing
+	}
+	position := positionPreceding
+	n.root().walk(func(c *node) bool {
+		if c == n {
+			position = positionFollowing
+			return false
+		}
+		return c != other
+	})
+	return position
+}
+
+// namespaceElement returns the element used to resolve namespace lookups on n
+func (n *node) namespaceElement() *node {
+	switch n.nodeType {
+	case elementNode:
+		return n
+	case documentNode:
+		if elements := n.elementChildren(); len(elements) > 0 {
+			return elements[0]
+		}
+		return nil
+	case fragmentNode:
+		return nil
+	}
+	if n.parent != nil && n.parent.nodeType == elementNode {
+		return n.parent
+	}
+	return nil
+}
+
+// lookupNamespaceURI returns the namespace of prefix, or an empty string if there is none
+func (n *node) lookupNamespaceURI(prefix string) string {
+	switch prefix {
+	case "xml":
+		return "http://www.w3.org/XML/1998/namespace"
+	case "xmlns":
+		return "http://www.w3.org/2000/xmlns/"
+	}
+	for e := n.namespaceElement(); e != nil; e = e.parent {
+		if e.nodeType != elementNode {
+			break
+		}
+		if e.namespace != "" && e.prefix == prefix {
+			return e.namespace
+		}
+		if prefix == "" {
+			if value, ok := e.attribute("xmlns"); ok {
+				return value
+			}
+			continue
+		}
+		if value, ok := e.attribute("xmlns:" + prefix); ok {
+			return value
+		}
+	}
+	return ""
+}
+
+// lookupPrefix returns the prefix of namespace, or an empty string if there is none
+func (n *node) lookupPrefix(namespace string) string {
+	for e := n.namespaceElement(); e != nil && e.nodeType == elementNode; e = e.parent {
+		for _, a := range e.attrs {
+			if strings.HasPrefix(a.name, "xmlns:") && a.value == namespace {
+				return strings.TrimPrefix(a.name, "xmlns:")
+			}
+		}
+	}
+	return ""
+}
+
+// interfaceName returns the DOM interface name used in error messages
+func (n *node) interfaceName() string {
+	switch n.nodeType {
+	case elementNode:
+		return "Element"
+	case documentNode:
+		return "Document"
+	case fragmentNode:
+		return "DocumentFragment"
+	}
+	return "Node"
+}
+
+func (n *node) elementByID(id string) *node {
+	if id == "" {
+		return nil
+	}
+	var found *node
+	n.walk(func(c *node) bool {
+		if c.nodeType == elementNode {
+			if value, ok := c.attribute("id"); ok && value == id {
+				found = c
+			}
+		}
+		return found == nil
+	})
+	return found
+}
+
+// querySelectorAll returns the descendant elements of n matching selector
+func (n *node) querySelectorAll(selector, method string) ([]*node, error) {
+	sel, err := parseSelectorArg(selector, method, n.interfaceName())
+	if err != nil {
+		return nil, err
+	}
+	return n.descendantElements(func(c *node) bool {
+		return sel.match(c, n)
+	}), nil
+}
+
+func (d *DOM) QuerySelector(root dom.Object, selector string) (dom.Object, error) {
+	n := nodeOf(root)
+	sel, err := parseSelectorArg(selector, "querySelector", n.interfaceName())
+	if err != nil {
+		return nil, err
+	}
+	var found *node
+	n.walk(func(c *node) bool {
+		if c.nodeType == elementNode && sel.match(c, n) {
+			found = c
+		}
+		return found == nil
+	})
+	return object(found), nil
+}
+
+func (d *DOM) QuerySelectorAll(root dom.Object, selector string) ([]dom.Object, error) {
+	nodes, err := nodeOf(root).querySelectorAll(selector, "querySelectorAll")
+	if err != nil {
+		return nil, err
+	}
+	return objects(nodes), nil
+}
+
+func (d *DOM) NodeType(o dom.Object) int {
+	if n := nodeOf(o); n != nil {
+		return n.nodeType
+	}
+	return 0
+}
+
+func (d *DOM) NodeName(o dom.Object) string {
+	return nodeOf(o).nodeName()
+}
+
+func (d *DOM) NodeValue(o dom.Object) string {
+	if n := nodeOf(o); n.nodeType == textNode || n.nodeType == commentNode {
+		return n.data
+	}
+	return ""
+}
+
+func (d *DOM) SetNodeValue(o dom.Object, value string) {
+	if n := nodeOf(o); n.nodeType == textNode || n.nodeType == commentNode {
+		n.data = value
+	}
+}
+
+func (d *DOM) ParentNode(o dom.Object) dom.Object {
+	return object(nodeOf(o).parent)
+}
+
+func (d *DOM) ParentElement(o dom.Object) dom.Object {
+	if parent := nodeOf(o).parent; parent != nil && parent.nodeType == elementNode {
+		return parent
+	}
+	return nil
+}
+
+func (d *DOM) ChildNodes(o dom.Object) []dom.Object {
+	return objects(nodeOf(o).children)
+}
+
+func (d *DOM) FirstChild(o dom.Object) dom.Object {
+	return object(nodeOf(o).firstChild())
+}
+
+func (d *DOM) LastChild(o dom.Object) dom.Object {
+	return object(nodeOf(o).lastChild())
+}
+
+func (d *DOM) PreviousSibling(o dom.Object) dom.Object {
+	return object(nodeOf(o).previousSibling())
+}
+
+func (d *DOM) NextSibling(o dom.Object) dom.Object {
+	return object(nodeOf(o).nextSibling())
+}
+
+func (d *DOM) OwnerDocument(o dom.Object) dom.Object {
+	return object(nodeOf(o).doc)
+}
+
+func (d *DOM) CloneNode(o dom.Object, deep bool) dom.Object {
+	return nodeOf(o).cloneNode(deep)
+}
+
+func (d *DOM) CompareDocumentPosition(o, other dom.Object) int {
+	return nodeOf(o).compareDocumentPosition(nodeOf(other))
+}
+
+func (d *DOM) Contains(o, other dom.Object) bool {
+	if other := nodeOf(other); other != nil {
+		return nodeOf(o).contains(other)
+	}
+	return false
+}
+
+func (d *DOM) IsEqualNode(o, other dom.Object) bool {
+	if other := nodeOf(other); other != nil {
+		return nodeOf(o).isEqualNode(other)
+	}
+	return false
+}
+
+func (d *DOM) IsSameNode(o, other dom.Object) bool {
+	return o == other
+}
+
+func (d *DOM) InsertBefore(parent, child, ref dom.Object) error {
+	p, c, r := nodeOf(parent), nodeOf(child), nodeOf(ref)
+	if err := p.checkInsert(c, "insertBefore"); err != nil {
+		return err
+	}
+	if r != nil && r.parent != p {
+		return domException("NotFoundError", "Failed to execute 'insertBefore' on 'Node': The node before which the new node is to be inserted is not a child of this node.")
+	}
+	p.insertBefore(c, r)
+	return nil
+}
+
+func (d *DOM) ReplaceChild(parent, child, old dom.Object) error {
+	p, c, o := nodeOf(parent), nodeOf(child), nodeOf(old)
+	if err := p.checkInsert(c, "replaceChild"); err != nil {
+		return err
+	}
+	if o.parent != p {
+		return domException("NotFoundError", "Failed to execute 'replaceChild' on 'Node': The node to be replaced is not a child of this node.")
+	}
+	p.replaceChild(c, o)
+	return nil
+}
+
+func (d *DOM) RemoveChild(parent, child dom.Object) error {
+	p, c := nodeOf(parent), nodeOf(child)
+	if c.parent != p {
+		return domException("NotFoundError", "Failed to execute 'removeChild' on 'Node': The node to be removed is not a child of this node.")
+	}
+	p.removeChild(c)
+	return nil
+}
+
+func (d *DOM) AppendChild(parent, child dom.Object) error {
+	p, c := nodeOf(parent), nodeOf(child)
+	if err := p.checkInsert(c, "appendChild"); err != nil {
+		return err
+	}
+	p.appendChild(c)
+	return nil
+}
+
+func (d *DOM) Normalize(o dom.Object) {
+	nodeOf(o).normalize()
+}
+
+func (d *DOM) LookupNamespaceURI(o dom.Object, prefix string) string {
+	return nodeOf(o).lookupNamespaceURI(prefix)
+}
+
+func (d *DOM) LookupPrefix(o dom.Object, namespace string) string {
+	if namespace == "" {
+		return ""
+	}
+	return nodeOf(o).lookupPrefix(namespace)
+}
+
+func (d *DOM) BaseURI(o dom.Object) string {
+	return documentURL
+}
+
+func (d *DOM) TextContent(o dom.Object) string {
+	if n := nodeOf(o); n.nodeType != documentNode {
+		return n.textContent()
+	}
+	return ""
+}
+
+func (d *DOM) SetTextContent(o dom.Object, text string) {
+	nodeOf(o).setTextContent(text)
+}
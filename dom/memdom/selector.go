@@ -0,0 +1,481 @@
+//go:build !js || !wasm
+// +build !js !wasm
+
+package memdom
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// This file implements the subset of CSS selectors supported by
+// querySelector, querySelectorAll, matches and closest: type, universal,
+// id, class and attribute selectors, the four combinators and the
+// structural pseudo-classes. Unsupported syntax is reported as invalid,
+// the same way browsers report unknown pseudo-classes.
+
+// matcher tests an element, with scope being the element :scope refers to
+type matcher func(n, scope *node) bool
+
+// complexSelector is a sequence of compound selectors joined by combinators
+type complexSelector struct {
+	compounds []matcher
+	// combinators[i] joins compounds[i] and compounds[i+1]
+	combinators []byte
+}
+
+// selectorList is a comma-separated list of complex selectors
+type selectorList []complexSelector
+
+func (l selectorList) match(n, scope *node) bool {
+	for _, c := range l {
+		if c.matchAt(n, len(c.compounds)-1, scope) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c complexSelector) matchAt(n *node, i int, scope *node) bool {
+	if !c.compounds[i](n, scope) {
+		return false
+	}
+	if i == 0 {
+		return true
+	}
+	switch c.combinators[i-1] {
+	case ' ':
+		for p := n.parent; p != nil && p.nodeType == elementNode; p = p.parent {
+			if c.matchAt(p, i-1, scope) {
+				return true
+			}
+		}
+	case '>':
+		if p := n.parent; p != nil && p.nodeType == elementNode {
+			return c.matchAt(p, i-1, scope)
+		}
+	case '+':
+		if s := previousElement(n); s != nil {
+			return c.matchAt(s, i-1, scope)
+		}
+	case '~':
+		for s := previousElement(n); s != nil; s = previousElement(s) {
+			if c.matchAt(s, i-1, scope) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func previousElement(n *node) *node {
+	for s := n.previousSibling(); s != nil; s = s.previousSibling() {
+		if s.nodeType == elementNode {
+			return s
+		}
+	}
+	return nil
+}
+
+// siblingElements returns the element children of n's parent
+func siblingElements(n *node) []*node {
+	if n.parent == nil {
+		return []*node{n}
+	}
+	return n.parent.elementChildren()
+}
+
+// parseSelectorArg parses a selector argument, returning a SyntaxError if it is invalid
+func parseSelectorArg(selector, method, iface string) (selectorList, error) {
+	l, err := parseSelector(selector)
+	if err != nil {
+		return nil, domException("SyntaxError", "Failed to execute '%s' on '%s': '%s' is not a valid selector.", method, iface, selector)
+	}
+	return l, nil
+}
+
+// parseSelector parses a selector list
+func parseSelector(selector string) (l selectorList, err error) {
+	p := &selectorParser{s: selector}
+	defer func() {
+		if r := recover(); r != nil {
+			e, ok := r.(selectorError)
+			if !ok {
+				panic(r)
+			}
+			l, err = nil, e
+		}
+	}()
+	l = p.parseList()
+	if p.pos < len(p.s) {
+		p.fail("unexpected %q", p.s[p.pos])
+	}
+	return l, nil
+}
+
+// selectorError is raised by the parser and recovered by parseSelector
+type selectorError struct {
+	msg string
+}
+
+func (e selectorError) Error() string {
+	return e.msg
+}
+
+type selectorParser struct {
+	s   string
+	pos int
+}
+
+func (p *selectorParser) fail(format string, args ...interface{}) {
+	panic(selectorError{msg: fmt.Sprintf(format, args...)})
+}
+
+func (p *selectorParser) peek() byte {
+	if p.pos < len(p.s) {
+		return p.s[p.pos]
+	}
+	return 0
+}
+
+func (p *selectorParser) skipSpace() bool {
+	start := p.pos
+	for p.pos < len(p.s) && strings.IndexByte(" \t\n\r\f", p.s[p.pos]) >= 0 {
+		p.pos++
+	}
+	return p.pos > start
+}
+
+func (p *selectorParser) expect(c byte) {
+	if p.peek() != c {
+		p.fail("expected %q", c)
+	}
+	p.pos++
+}
+
+func isNameChar(c byte) bool {
+	return c == '-' || c == '_' || c >= 0x80 ||
+		(c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
+
+// parseIdent parses a CSS identifier, with backslash escapes of single characters
+func (p *selectorParser) parseIdent() string {
+	var b strings.Builder
+	for p.pos < len(p.s) {
+		c := p.s[p.pos]
+		switch {
+		case c == '\\' && p.pos+1 < len(p.s):
+			b.WriteByte(p.s[p.pos+1])
+			p.pos += 2
+			continue
+		case isNameChar(c):
+			b.WriteByte(c)
+			p.pos++
+			continue
+		}
+		break
+	}
+	ident := b.String()
+	if ident == "" || (ident[0] >= '0' && ident[0] <= '9') || ident == "-" ||
+		(ident[0] == '-' && len(ident) > 1 && ident[1] >= '0' && ident[1] <= '9') {
+		p.fail("expected identifier")
+	}
+	return ident
+}
+
+func (p *selectorParser) parseString() string {
+	quote := p.s[p.pos]
+	p.pos++
+	var b strings.Builder
+	for p.pos < len(p.s) {
+		c := p.s[p.pos]
+		switch {
+		case c == quote:
+			p.pos++
+			return b.String()
+		case c == '\\' && p.pos+1 < len(p.s):
+			b.WriteByte(p.s[p.pos+1])
+			p.pos += 2
+		default:
+			b.WriteByte(c)
+			p.pos++
+		}
+	}
+	p.fail("unterminated string")
+	return ""
+}
+
+func (p *selectorParser) parseList() selectorList {
+	var l selectorList
+	for {
+		p.skipSpace()
+		l = append(l, p.parseComplex())
+		p.skipSpace()
+		if p.peek() != ',' {
+			return l
+		}
+		p.pos++
+	}
+}
+
+func (p *selectorParser) parseComplex() complexSelector {
+	c := complexSelector{compounds: []matcher{p.parseCompound()}}
+	for {
+		space := p.skipSpace()
+		combinator := p.peek()
+		switch {
+		case combinator == '>' || combinator == '+' || combinator == '~':
+			p.pos++
+			p.skipSpace()
+		case space && combinator != 0 && combinator != ',' && combinator != ')':
+			combinator = ' '
+		default:
+			return c
+		}
+		c.combinators = append(c.combinators, combinator)
+		c.compounds = append(c.compounds, p.parseCompound())
+	}
+}
+
+func (p *selectorParser) parseCompound() matcher {
+	var matchers []matcher
+	switch c := p.peek(); {
+	case c == '*':
+		p.pos++
+		matchers = append(matchers, func(n, scope *node) bool { return true })
+	case isNameChar(c) || c == '\\':
+		tag := strings.ToLower(p.parseIdent())
+		matchers = append(matchers, func(n, scope *node) bool { return n.name == tag })
+	}
+	for {
+		switch p.peek() {
+		case '#':
+			p.pos++
+			id := p.parseIdent()
+			matchers = append(matchers, func(n, scope *node) bool {
+				value, ok := n.attribute("id")
+				return ok && value == id
+			})
+			continue
+		case '.':
+			p.pos++
+			class := p.parseIdent()
+			matchers = append(matchers, func(n, scope *node) bool { return n.hasClass(class) })
+			continue
+		case '[':
+			p.pos++
+			matchers = append(matchers, p.parseAttribute())
+			continue
+		case ':':
+			p.pos++
+			matchers = append(matchers, p.parsePseudo())
+			continue
+		}
+		break
+	}
+	if len(matchers) == 0 {
+		p.fail("expected selector")
+	}
+	return func(n, scope *node) bool {
+		for _, m := range matchers {
+			if !m(n, scope) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+func (p *selectorParser) parseAttribute() matcher {
+	p.skipSpace()
+	name := strings.ToLower(p.parseIdent())
+	p.skipSpace()
+	if p.peek() == ']' {
+		p.pos++
+		return func(n, scope *node) bool {
+			_, ok := n.attribute(name)
+			return ok
+		}
+	}
+
+	op := ""
+	if c := p.peek(); strings.IndexByte("~|^$*", c) >= 0 && c != 0 {
+		op = string(c)
+		p.pos++
+	}
+	p.expect('=')
+	p.skipSpace()
+	var value string
+	if c := p.peek(); c == '"' || c == '\'' {
+		value = p.parseString()
+	} else {
+		value = p.parseIdent()
+	}
+	p.skipSpace()
+	fold := false
+	if c := p.peek(); c == 'i' || c == 'I' || c == 's' || c == 'S' {
+		fold = c == 'i' || c == 'I'
+		p.pos++
+		p.skipSpace()
+	}
+	p.expect(']')
+
+	if fold {
+		value = strings.ToLower(value)
+	}
+	return func(n, scope *node) bool {
+		actual, ok := n.attribute(name)
+		if !ok {
+			return false
+		}
+		if fold {
+			actual = strings.ToLower(actual)
+		}
+		switch op {
+		case "~":
+			for _, word := range strings.Fields(actual) {
+				if word == value {
+					return true
+				}
+			}
+			return false
+		case "|":
+			return actual == value || strings.HasPrefix(actual, value+"-")
+		case "^":
+			return value != "" && strings.HasPrefix(actual, value)
+		case "$":
+			return value != "" && strings.HasSuffix(actual, value)
+		case "*":
+			return value != "" && strings.Contains(actual, value)
+		}
+		return actual == value
+	}
+}
+
+func (p *selectorParser) parsePseudo() matcher {
+	name := strings.ToLower(p.parseIdent())
+	switch name {
+	case "root":
+		return func(n, scope *node) bool { return n.parent != nil && n.parent.nodeType == documentNode }
+	case "scope":
+		return func(n, scope *node) bool {
+			if scope == nil || scope.nodeType != elementNode {
+				return n.parent != nil && n.parent.nodeType == documentNode
+			}
+			return n == scope
+		}
+	case "empty":
+		return func(n, scope *node) bool {
+			for _, c := range n.children {
+				if c.nodeType == elementNode || (c.nodeType == textNode && c.data != "") {
+					return false
+				}
+			}
+			return true
+		}
+	case "first-child":
+		return func(n, scope *node) bool { return siblingElements(n)[0] == n }
+	case "last-child":
+		return func(n, scope *node) bool {
+			siblings := siblingElements(n)
+			return siblings[len(siblings)-1] == n
+		}
+	case "only-child":
+		return func(n, scope *node) bool { return len(siblingElements(n)) == 1 }
+	case "first-of-type", "last-of-type", "only-of-type":
+		return func(n, scope *node) bool {
+			var same []*node
+			for _, s := range siblingElements(n) {
+				if s.name == n.name {
+					same = append(same, s)
+				}
+			}
+			switch name {
+			case "first-of-type":
+				return same[0] == n
+			case "last-of-type":
+				return same[len(same)-1] == n
+			}
+			return len(same) == 1
+		}
+	case "not", "is", "where":
+		p.expect('(')
+		l := p.parseList()
+		p.skipSpace()
+		p.expect(')')
+		if name == "not" {
+			return func(n, scope *node) bool { return !l.match(n, scope) }
+		}
+		return l.match
+	case "nth-child", "nth-last-child":
+		p.expect('(')
+		a, b := p.parseNth()
+		p.expect(')')
+		return func(n, scope *node) bool {
+			siblings := siblingElements(n)
+			i := 0
+			for i < len(siblings) && siblings[i] != n {
+				i++
+			}
+			if name == "nth-last-child" {
+				i = len(siblings) - 1 - i
+			}
+			return nthMatches(a, b, i+1)
+		}
+	}
+	p.fail("unsupported pseudo-class :%s", name)
+	return nil
+}
+
+// parseNth parses the An+B argument of :nth-child
+func (p *selectorParser) parseNth() (a, b int) {
+	p.skipSpace()
+	end := strings.IndexByte(p.s[p.pos:], ')')
+	if end < 0 {
+		p.fail("expected )")
+	}
+	expr := strings.ToLower(strings.Join(strings.Fields(p.s[p.pos:p.pos+end]), ""))
+	p.pos += end
+	switch expr {
+	case "odd":
+		return 2, 1
+	case "even":
+		return 2, 0
+	}
+	i := strings.IndexByte(expr, 'n')
+	if i < 0 {
+		b, err := strconv.Atoi(expr)
+		if err != nil {
+			p.fail("invalid nth expression %q", expr)
+		}
+		return 0, b
+	}
+	switch coefficient := expr[:i]; coefficient {
+	case "", "+":
+		a = 1
+	case "-":
+		a = -1
+	default:
+		var err error
+		if a, err = strconv.Atoi(coefficient); err != nil {
+			p.fail("invalid nth expression %q", expr)
+		}
+	}
+	if rest := expr[i+1:]; rest != "" {
+		var err error
+		if b, err = strconv.Atoi(rest); err != nil || (rest[0] != '+' && rest[0] != '-') {
+			p.fail("invalid nth expression %q", expr)
+		}
+	}
+	return a, b
+}
+
+// nthMatches reports whether position i is of the form a*k+b for some k >= 0
+func nthMatches(a, b, i int) bool {
+	if a == 0 {
+		return i == b
+	}
+	k := (i - b) / a
+	return (i-b)%a == 0 && k >= 0
+}
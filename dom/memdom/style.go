@@ -0,0 +1,199 @@
+//go:build !js || !wasm
+// +build !js !wasm
+
+package memdom
+
+import (
+	"strings"
+
+	"github.com/abdorrahmani/go-wasm/dom"
+)
+
+// declaration is a single CSS declaration
+type declaration struct {
+	name     string
+	value    string
+	priority string
+}
+
+// style implements CSSStyleDeclaration for the inline style of an element.
+// It is kept in sync with the element's style attribute.
+type style struct {
+	owner *node
+	decls []declaration
+	// updating is set while the style writes the style attribute
+	updating bool
+}
+
+func newStyle(owner *node) *style {
+	s := &style{owner: owner}
+	text, _ := owner.attribute("style")
+	s.parse(text)
+	return s
+}
+
+// splitCSS splits s at sep, ignoring separators inside quotes and parentheses
+func splitCSS(s string, sep rune) []string {
+	var parts []string
+	var quote rune
+	depth, start := 0, 0
+	for i, c := range s {
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '(':
+			depth++
+		case c == ')' && depth > 0:
+			depth--
+		case c == sep && depth == 0:
+			parts = append(parts, s[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, s[start:])
+}
+
+// parse replaces the declarations with the ones in text, skipping invalid ones
+func (s *style) parse(text string) {
+	s.decls = nil
+	for _, part := range splitCSS(text, ';') {
+		colon := strings.IndexByte(part, ':')
+		if colon < 0 {
+			continue
+		}
+		name := strings.TrimSpace(part[:colon])
+		value := strings.TrimSpace(part[colon+1:])
+		priority := ""
+		if i := strings.LastIndexByte(value, '!'); i >= 0 && strings.EqualFold(strings.TrimSpace(value[i+1:]), "important") {
+			value = strings.TrimSpace(value[:i])
+			priority = "important"
+		}
+		s.set(propertyName(name), value, priority)
+	}
+}
+
+// propertyName normalizes a property name. Custom properties are case-sensitive.
+func propertyName(name string) string {
+	if strings.HasPrefix(name, "--") {
+		return name
+	}
+	return strings.ToLower(name)
+}
+
+func (s *style) cssText() string {
+	parts := make([]string, len(s.decls))
+	for i, d := range s.decls {
+		if d.priority != "" {
+			parts[i] = d.name + ": " + d.value + " !" + d.priority + ";"
+		} else {
+			parts[i] = d.name + ": " + d.value + ";"
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+func (s *style) setCSSText(text string) {
+	s.parse(text)
+	s.sync()
+}
+
+// sync writes the declarations back to the style attribute
+func (s *style) sync() {
+	s.updating = true
+	s.owner.setAttribute("style", s.cssText())
+	s.updating = false
+}
+
+func (s *style) get(name string) *declaration {
+	for i := range s.decls {
+		if s.decls[i].name == name {
+			return &s.decls[i]
+		}
+	}
+	return nil
+}
+
+// set sets a declaration, removing it when value is empty
+func (s *style) set(name, value, priority string) {
+	if name == "" {
+		return
+	}
+	if value == "" {
+		s.remove(name)
+		return
+	}
+	if d := s.get(name); d != nil {
+		d.value, d.priority = value, priority
+		return
+	}
+	s.decls = append(s.decls, declaration{name: name, value: value, priority: priority})
+}
+
+func (s *style) remove(name string) {
+	for i, d := range s.decls {
+		if d.name == name {
+			s.decls = append(s.decls[:i], s.decls[i+1:]...)
+			return
+		}
+	}
+}
+
+// styleOf returns the style declaration behind an object
+func styleOf(o dom.Object) *style {
+	return o.(*style)
+}
+
+// SetStyleProperty sets a property, removing it when value is empty. Priorities
+// other than "important" are ignored, like in browsers.
+func (d *DOM) SetStyleProperty(o dom.Object, name, value, priority string) {
+	priority = strings.ToLower(priority)
+	if priority != "" && priority != "important" {
+		return
+	}
+	s := styleOf(o)
+	s.set(propertyName(name), strings.TrimSpace(value), priority)
+	s.sync()
+}
+
+func (d *DOM) StylePropertyValue(o dom.Object, name string) string {
+	if decl := styleOf(o).get(propertyName(name)); decl != nil {
+		return decl.value
+	}
+	return ""
+}
+
+func (d *DOM) StylePropertyPriority(o dom.Object, name string) string {
+	if decl := styleOf(o).get(propertyName(name)); decl != nil {
+		return decl.priority
+	}
+	return ""
+}
+
+func (d *DOM) RemoveStyleProperty(o dom.Object, name string) {
+	s := styleOf(o)
+	s.remove(propertyName(name))
+	s.sync()
+}
+
+func (d *DOM) CSSText(o dom.Object) string {
+	return styleOf(o).cssText()
+}
+
+func (d *DOM) SetCSSText(o dom.Object, text string) {
+	styleOf(o).setCSSText(text)
+}
+
+func (d *DOM) StyleLength(o dom.Object) int {
+	return len(styleOf(o).decls)
+}
+
+func (d *DOM) StyleItem(o dom.Object, i int) string {
+	if s := styleOf(o); i >= 0 && i < len(s.decls) {
+		return s.decls[i].name
+	}
+	return ""
+}
@@ -0,0 +1,18 @@
+//go:build !js || !wasm
+// +build !js !wasm
+
+package dom
+
+// Object is a handle to an object of the Backend, whose type is up to the
+// backend. It is comparable, so handles to the same object are equal.
+type Object interface{}
+
+// defaultBackend returns nil, as there is no DOM outside a JavaScript host
+func defaultBackend() Backend {
+	return nil
+}
+
+// isNullish reports whether v stands for null
+func isNullish(v Object) bool {
+	return v == nil
+}
@@ -1,17 +1,12 @@
-//go:build js && wasm
-// +build js,wasm
-
 package dom
 
 import (
 	"fmt"
-
-	"github.com/abdorrahmani/go-wasm/js"
 )
 
 // Node represents a DOM node
 type Node struct {
-	Value *js.Value
+	Value Object
 }
 
 // NodeType constants
@@ -30,198 +25,221 @@ const (
 	NotationNode              = 12
 )
 
+// wrapNode wraps v in a Node, returning nil when v is null or undefined
+func wrapNode(v Object) *Node {
+	if isNullish(v) {
+		return nil
+	}
+	return &Node{Value: v}
+}
+
+// wrapElement wraps v in an Element, returning nil when v is null or undefined
+func wrapElement(v Object) *Element {
+	if isNullish(v) {
+		return nil
+	}
+	return &Element{Value: v}
+}
+
+// wrapDocument wraps v in a Document, returning nil when v is null or undefined
+func wrapDocument(v Object) *Document {
+	if isNullish(v) {
+		return nil
+	}
+	return &Document{Value: v}
+}
+
+// nodeList wraps the values of a list of nodes
+func nodeList(values []Object) []*Node {
+	nodes := make([]*Node, len(values))
+	for i, value := range values {
+		nodes[i] = &Node{
+			Value: value,
+		}
+	}
+	return nodes
+}
+
 // GetNodeType returns the node type
 func (n *Node) GetNodeType() int {
-	return n.Value.Get("nodeType").MustInt()
+	return backend().NodeType(n.Value)
 }
 
 // GetNodeName returns the node name
 func (n *Node) GetNodeName() string {
-	return n.Value.Get("nodeName").MustString()
+	return backend().NodeName(n.Value)
 }
 
-// GetNodeValue returns the node value
+// GetNodeValue returns the node value, or an empty string if it is null
 func (n *Node) GetNodeValue() string {
-	return n.Value.Get("nodeValue").MustString()
+	return backend().NodeValue(n.Value)
 }
 
 // SetNodeValue sets the node value
 func (n *Node) SetNodeValue(value string) {
-	n.Value.Set("nodeValue", value)
+	backend().SetNodeValue(n.Value, value)
 }
 
-// GetParentNode returns the parent node
+// GetParentNode returns the parent node, or nil if there is none
 func (n *Node) GetParentNode() *Node {
-	return &Node{
-		Value: n.Value.Get("parentNode"),
-	}
+	return wrapNode(backend().ParentNode(n.Value))
 }
 
 // GetChildNodes returns all child nodes
 func (n *Node) GetChildNodes() []*Node {
-	value := n.Value.Get("childNodes")
-	length := value.MustLength()
-	nodes := make([]*Node, length)
-	for i := 0; i < length; i++ {
-		nodes[i] = &Node{
-			Value: value.Get(fmt.Sprintf("%d", i)),
-		}
-	}
-	return nodes
+	return nodeList(backend().ChildNodes(n.Value))
 }
 
-// GetFirstChild returns the first child node
+// GetFirstChild returns the first child node, or nil if there is none
 func (n *Node) GetFirstChild() *Node {
-	return &Node{
-		Value: n.Value.Get("firstChild"),
-	}
+	return wrapNode(backend().FirstChild(n.Value))
 }
 
-// GetLastChild returns the last child node
+// GetLastChild returns the last child node, or nil if there is none
 func (n *Node) GetLastChild() *Node {
-	return &Node{
-		Value: n.Value.Get("lastChild"),
-	}
+	return wrapNode(backend().LastChild(n.Value))
 }
 
-// GetPreviousSibling returns the previous sibling node
+// GetPreviousSibling returns the previous sibling node, or nil if there is none
 func (n *Node) GetPreviousSibling() *Node {
-	return &Node{
-		Value: n.Value.Get("previousSibling"),
-	}
+	return wrapNode(backend().PreviousSibling(n.Value))
 }
 
-// GetNextSibling returns the next sibling node
+// GetNextSibling returns the next sibling node, or nil if there is none
 func (n *Node) GetNextSibling() *Node {
-	return &Node{
-		Value: n.Value.Get("nextSibling"),
-	}
+	return wrapNode(backend().NextSibling(n.Value))
 }
 
-// GetOwnerDocument returns the owner document
+// GetOwnerDocument returns the owner document, or nil if there is none
 func (n *Node) GetOwnerDocument() *Document {
-	return &Document{
-		Value: n.Value.Get("ownerDocument"),
-	}
+	return wrapDocument(backend().OwnerDocument(n.Value))
 }
 
 // HasChildNodes checks if the node has child nodes
 func (n *Node) HasChildNodes() bool {
-	return n.Value.Call("hasChildNodes").MustBool()
+	return backend().FirstChild(n.Value) != nil
 }
 
 // CloneNode creates a copy of the node
 func (n *Node) CloneNode(deep bool) *Node {
 	return &Node{
-		Value: n.Value.Call("cloneNode", deep),
+		Value: backend().CloneNode(n.Value, deep),
 	}
 }
 
 // CompareDocumentPosition compares the position of two nodes
 func (n *Node) CompareDocumentPosition(other *Node) int {
-	return n.Value.Call("compareDocumentPosition", other.Value).MustInt()
+	return backend().CompareDocumentPosition(n.Value, other.Value)
 }
 
 // Contains checks if the node contains another node
 func (n *Node) Contains(other *Node) bool {
-	return n.Value.Call("contains", other.Value).MustBool()
+	return backend().Contains(n.Value, other.Value)
 }
 
 // InsertBefore inserts a node before a reference node
 func (n *Node) InsertBefore(newNode, referenceNode *Node) error {
-	if n.Value == nil || n.Value.Raw().IsNull() || n.Value.Raw().IsUndefined() {
+	if isNullish(n.Value) {
 		return fmt.Errorf("node is nil or undefined/null")
 	}
-	if newNode == nil || newNode.Value == nil || newNode.Value.Raw().IsNull() || newNode.Value.Raw().IsUndefined() {
+	if newNode == nil || isNullish(newNode.Value) {
 		return fmt.Errorf("new node is nil or undefined/null")
 	}
-	if referenceNode == nil || referenceNode.Value == nil || referenceNode.Value.Raw().IsNull() || referenceNode.Value.Raw().IsUndefined() {
+	if referenceNode == nil || isNullish(referenceNode.Value) {
 		return fmt.Errorf("reference node is nil or undefined/null")
 	}
-	n.Value.Call("insertBefore", newNode.Value, referenceNode.Value)
+	if err := backend().InsertBefore(n.Value, newNode.Value, referenceNode.Value); err != nil {
+		return fmt.Errorf("insertBefore: %w", err)
+	}
 	return nil
 }
 
 // ReplaceChild replaces a child node
 func (n *Node) ReplaceChild(newNode, oldNode *Node) error {
-	if n.Value == nil || n.Value.Raw().IsNull() || n.Value.Raw().IsUndefined() {
+	if isNullish(n.Value) {
 		return fmt.Errorf("node is nil or undefined/null")
 	}
-	if newNode == nil || newNode.Value == nil || newNode.Value.Raw().IsNull() || newNode.Value.Raw().IsUndefined() {
+	if newNode == nil || isNullish(newNode.Value) {
 		return fmt.Errorf("new node is nil or undefined/null")
 	}
-	if oldNode == nil || oldNode.Value == nil || oldNode.Value.Raw().IsNull() || oldNode.Value.Raw().IsUndefined() {
+	if oldNode == nil || isNullish(oldNode.Value) {
 		return fmt.Errorf("old node is nil or undefined/null")
 	}
-	n.Value.Call("replaceChild", newNode.Value, oldNode.Value)
+	if err := backend().ReplaceChild(n.Value, newNode.Value, oldNode.Value); err != nil {
+		return fmt.Errorf("replaceChild: %w", err)
+	}
 	return nil
 }
 
 // RemoveChild removes a child node
 func (n *Node) RemoveChild(child *Node) error {
-	if n.Value == nil || n.Value.Raw().IsNull() || n.Value.Raw().IsUndefined() {
+	if isNullish(n.Value) {
 		return fmt.Errorf("node is nil or undefined/null")
 	}
-	if child == nil || child.Value == nil || child.Value.Raw().IsNull() || child.Value.Raw().IsUndefined() {
+	if child == nil || isNullish(child.Value) {
 		return fmt.Errorf("child node is nil or undefined/null")
 	}
-	n.Value.Call("removeChild", child.Value)
+	if err := backend().RemoveChild(n.Value, child.Value); err != nil {
+		return fmt.Errorf("removeChild: %w", err)
+	}
 	return nil
 }
 
 // AppendChild appends a child node
 func (n *Node) AppendChild(child *Node) error {
-	if n.Value == nil || n.Value.Raw().IsNull() || n.Value.Raw().IsUndefined() {
+	if isNullish(n.Value) {
 		return fmt.Errorf("node is nil or undefined/null")
 	}
-	if child == nil || child.Value == nil || child.Value.Raw().IsNull() || child.Value.Raw().IsUndefined() {
+	if child == nil || isNullish(child.Value) {
 		return fmt.Errorf("child node is nil or undefined/null")
 	}
-	n.Value.Call("appendChild", child.Value)
+	if err := backend().AppendChild(n.Value, child.Value); err != nil {
+		return fmt.Errorf("appendChild: %w", err)
+	}
 	return nil
 }
 
 // Normalize normalizes the node's text nodes
 func (n *Node) Normalize() {
-	n.Value.Call("normalize")
+	backend().Normalize(n.Value)
 }
 
 // IsDefaultNamespace checks if the node is in the default namespace
 func (n *Node) IsDefaultNamespace(namespaceURI string) bool {
-	return n.Value.Call("isDefaultNamespace", namespaceURI).MustBool()
+	return backend().LookupNamespaceURI(n.Value, "") == namespaceURI
 }
 
-// LookupNamespaceURI looks up the namespace URI for a prefix
+// LookupNamespaceURI looks up the namespace URI for a prefix, or an empty string if there is none
 func (n *Node) LookupNamespaceURI(prefix string) string {
-	return n.Value.Call("lookupNamespaceURI", prefix).MustString()
+	return backend().LookupNamespaceURI(n.Value, prefix)
 }
 
-// LookupPrefix looks up the prefix for a namespace URI
+// LookupPrefix looks up the prefix for a namespace URI, or an empty string if there is none
 func (n *Node) LookupPrefix(namespaceURI string) string {
-	return n.Value.Call("lookupPrefix", namespaceURI).MustString()
+	return backend().LookupPrefix(n.Value, namespaceURI)
 }
 
 // IsEqualNode checks if two nodes are equal
 func (n *Node) IsEqualNode(other *Node) bool {
-	return n.Value.Call("isEqualNode", other.Value).MustBool()
+	return backend().IsEqualNode(n.Value, other.Value)
 }
 
 // IsSameNode checks if two nodes are the same
 func (n *Node) IsSameNode(other *Node) bool {
-	return n.Value.Call("isSameNode", other.Value).MustBool()
+	return backend().IsSameNode(n.Value, other.Value)
 }
 
 // GetBaseURI returns the node's base URI
 func (n *Node) GetBaseURI() string {
-	return n.Value.Get("baseURI").MustString()
+	return backend().BaseURI(n.Value)
 }
 
-// GetTextContent returns the node's text content
+// GetTextContent returns the node's text content, or an empty string if it is null
 func (n *Node) GetTextContent() string {
-	return n.Value.Get("textContent").MustString()
+	return backend().TextContent(n.Value)
 }
 
 // SetTextContent sets the node's text content
 func (n *Node) SetTextContent(text string) {
-	n.Value.Set("textContent", text)
+	backend().SetTextContent(n.Value, text)
 }
@@ -0,0 +1,65 @@
+//go:build js && wasm
+// +build js,wasm
+
+package dom
+
+import (
+	"github.com/abdorrahmani/go-wasm/js"
+)
+
+// DOMRect represents a rectangle with position and dimensions
+type DOMRect struct {
+	Value *js.Value
+}
+
+// GetBoundingClientRect returns the element's bounding rectangle
+func (e *Element) GetBoundingClientRect() *DOMRect {
+	return &DOMRect{
+		Value: e.Value.Call("getBoundingClientRect"),
+	}
+}
+
+// ScrollIntoView scrolls the element into view
+func (e *Element) ScrollIntoView() {
+	e.Value.Call("scrollIntoView")
+}
+
+// GetTop returns the top position
+func (r *DOMRect) GetTop() float64 {
+	return r.Value.Get("top").MustFloat()
+}
+
+// GetRight returns the right position
+func (r *DOMRect) GetRight() float64 {
+	return r.Value.Get("right").MustFloat()
+}
+
+// GetBottom returns the bottom position
+func (r *DOMRect) GetBottom() float64 {
+	return r.Value.Get("bottom").MustFloat()
+}
+
+// GetLeft returns the left position
+func (r *DOMRect) GetLeft() float64 {
+	return r.Value.Get("left").MustFloat()
+}
+
+// GetWidth returns the width
+func (r *DOMRect) GetWidth() float64 {
+	return r.Value.Get("width").MustFloat()
+}
+
+// GetHeight returns the height
+func (r *DOMRect) GetHeight() float64 {
+	return r.Value.Get("height").MustFloat()
+}
+
+// GetX returns the x position
+func (r *DOMRect) GetX() float64 {
+	return r.Value.Get("x").MustFloat()
+}
+
+// GetY returns the y position
+func (r *DOMRect) GetY() float64 {
+	return r.Value.Get("y").MustFloat()
+}
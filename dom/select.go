@@ -0,0 +1,119 @@
+//go:build js && wasm
+// +build js,wasm
+
+package dom
+
+import (
+	"strconv"
+
+	"github.com/abdorrahmani/go-wasm/js"
+)
+
+// SelectElement wraps a select element
+type SelectElement struct {
+	FormControl
+}
+
+// OptionElement wraps an option element
+type OptionElement struct {
+	Element
+}
+
+// AsSelect returns the element as a select, or nil if it is not a select element
+func (e *Element) AsSelect() *SelectElement {
+	if !e.isHTMLElement("select") {
+		return nil
+	}
+	return &SelectElement{FormControl{Element: *e}}
+}
+
+// AsOption returns the element as an option, or nil if it is not an option element
+func (e *Element) AsOption() *OptionElement {
+	if !e.isHTMLElement("option") {
+		return nil
+	}
+	return &OptionElement{Element: *e}
+}
+
+// options wraps a collection of option elements
+func options(value *js.Value) []*OptionElement {
+	length := value.MustLength()
+	options := make([]*OptionElement, length)
+	for i := 0; i < length; i++ {
+		options[i] = &OptionElement{
+			Element: Element{Value: value.Get(strconv.Itoa(i))},
+		}
+	}
+	return options
+}
+
+// GetValue returns the value of the first selected option, or an empty string if none is selected
+func (s *SelectElement) GetValue() string {
+	return s.Value.Get("value").MustString()
+}
+
+// SetValue selects the first option with the given value, deselecting all options if there is none
+func (s *SelectElement) SetValue(value string) {
+	s.Value.Set("value", value)
+}
+
+// GetMultiple reports whether more than one option can be selected
+func (s *SelectElement) GetMultiple() bool {
+	return s.Value.Get("multiple").MustBool()
+}
+
+// SetMultiple sets whether more than one option can be selected
+func (s *SelectElement) SetMultiple(multiple bool) {
+	s.Value.Set("multiple", multiple)
+}
+
+// GetOptions returns the select's options, including those in option groups
+func (s *SelectElement) GetOptions() []*OptionElement {
+	return options(s.Value.Get("options"))
+}
+
+// GetSelectedOptions returns the selected options
+func (s *SelectElement) GetSelectedOptions() []*OptionElement {
+	return options(s.Value.Get("selectedOptions"))
+}
+
+// GetSelectedIndex returns the index of the first selected option, or -1 if none is selected
+func (s *SelectElement) GetSelectedIndex() int {
+	return s.Value.Get("selectedIndex").MustInt()
+}
+
+// SetSelectedIndex selects the option at index, deselecting all others.
+// An index out of range deselects all options.
+func (s *SelectElement) SetSelectedIndex(index int) {
+	s.Value.Set("selectedIndex", index)
+}
+
+// GetValue returns the option's value, which defaults to its text
+func (o *OptionElement) GetValue() string {
+	return o.Value.Get("value").MustString()
+}
+
+// SetValue sets the option's value
+func (o *OptionElement) SetValue(value string) {
+	o.Value.Set("value", value)
+}
+
+// GetText returns the option's text with whitespace collapsed
+func (o *OptionElement) GetText() string {
+	return o.Value.Get("text").MustString()
+}
+
+// GetSelected reports whether the option is selected
+func (o *OptionElement) GetSelected() bool {
+	return o.Value.Get("selected").MustBool()
+}
+
+// SetSelected selects or deselects the option
+func (o *OptionElement) SetSelected(selected bool) {
+	o.Value.Set("selected", selected)
+}
+
+// GetIndex returns the option's index in its select
+func (o *OptionElement) GetIndex() int {
+	return o.Value.Get("index").MustInt()
+}
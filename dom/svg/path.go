@@ -0,0 +1,123 @@
+//go:build js && wasm
+// +build js,wasm
+
+package svg
+
+import (
+	"strings"
+
+	"github.com/abdorrahmani/go-wasm/dom"
+)
+
+// PathData builds the d attribute of a path with absolute coordinates.
+// Its methods return the builder so commands can be chained:
+//
+//	d := new(svg.PathData).MoveTo(0, 0).LineTo(10, 0).LineTo(10, 10).Close()
+type PathData struct {
+	commands []string
+}
+
+// command appends a command letter and its parameters
+func (d *PathData) command(letter string, params ...float64) *PathData {
+	parts := make([]string, len(params)+1)
+	parts[0] = letter
+	for i, p := range params {
+		parts[i+1] = formatNumber(p)
+	}
+	d.commands = append(d.commands, strings.Join(parts, " "))
+	return d
+}
+
+// MoveTo starts a new subpath at (x, y)
+func (d *PathData) MoveTo(x, y float64) *PathData {
+	return d.command("M", x, y)
+}
+
+// LineTo draws a line to (x, y)
+func (d *PathData) LineTo(x, y float64) *PathData {
+	return d.command("L", x, y)
+}
+
+// HorizontalTo draws a horizontal line to x
+func (d *PathData) HorizontalTo(x float64) *PathData {
+	return d.command("H", x)
+}
+
+// VerticalTo draws a vertical line to y
+func (d *PathData) VerticalTo(y float64) *PathData {
+	return d.command("V", y)
+}
+
+// CubicTo draws a cubic Bézier curve to (x, y) with control points (x1, y1) and (x2, y2)
+func (d *PathData) CubicTo(x1, y1, x2, y2, x, y float64) *PathData {
+	return d.command("C", x1, y1, x2, y2, x, y)
+}
+
+// SmoothCubicTo draws a cubic Bézier curve to (x, y) whose first control
+// point reflects the last control point of the previous curve
+func (d *PathData) SmoothCubicTo(x2, y2, x, y float64) *PathData {
+	return d.command("S", x2, y2, x, y)
+}
+
+// QuadTo draws a quadratic Bézier curve to (x, y) with control point (x1, y1)
+func (d *PathData) QuadTo(x1, y1, x, y float64) *PathData {
+	return d.command("Q", x1, y1, x, y)
+}
+
+// SmoothQuadTo draws a quadratic Bézier curve to (x, y) whose control point
+// reflects the control point of the previous curve
+func (d *PathData) SmoothQuadTo(x, y float64) *PathData {
+	return d.command("T", x, y)
+}
+
+// ArcTo draws an elliptical arc to (x, y). The flags choose between the four
+// arcs with the given radii and rotation, in degrees, that join the points.
+func (d *PathData) ArcTo(rx, ry, rotation float64, largeArc, sweep bool, x, y float64) *PathData {
+	return d.command("A", rx, ry, rotation, flag(largeArc), flag(sweep), x, y)
+}
+
+// Close draws a line back to the start of the subpath
+func (d *PathData) Close() *PathData {
+	d.commands = append(d.commands, "Z")
+	return d
+}
+
+// String returns the path data
+func (d *PathData) String() string {
+	return strings.Join(d.commands, " ")
+}
+
+// flag converts an arc flag to its numeric form
+func flag(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// Path is a <path> element
+type Path struct {
+	Geometry
+}
+
+// NewPath creates a <path> element drawing d
+func NewPath(doc *dom.Document, d *PathData) *Path {
+	p := &Path{Geometry: Geometry{Element: newElement(doc, "path")}}
+	p.SetPathData(d)
+	return p
+}
+
+// SetPathData sets the path data
+func (p *Path) SetPathData(d *PathData) {
+	p.SetD(d.String())
+}
+
+// SetD sets the d attribute
+func (p *Path) SetD(d string) {
+	p.SetAttribute("d", d)
+}
+
+// GetD returns the d attribute
+func (p *Path) GetD() string {
+	return p.GetAttribute("d")
+}
@@ -0,0 +1,191 @@
+//go:build js && wasm
+// +build js,wasm
+
+package svg
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/abdorrahmani/go-wasm/dom"
+)
+
+// Circle is a <circle> element
+type Circle struct {
+	Geometry
+}
+
+// NewCircle creates a <circle> element centered at (cx, cy) with radius r
+func NewCircle(doc *dom.Document, cx, cy, r float64) *Circle {
+	c := &Circle{Geometry: Geometry{Element: newElement(doc, "circle")}}
+	c.SetCenter(cx, cy)
+	c.SetRadius(r)
+	return c
+}
+
+// SetCenter sets the center
+func (c *Circle) SetCenter(cx, cy float64) {
+	c.setNumber("cx", cx)
+	c.setNumber("cy", cy)
+}
+
+// GetCenter returns the center
+func (c *Circle) GetCenter() Point {
+	return Point{X: c.number("cx"), Y: c.number("cy")}
+}
+
+// SetRadius sets the radius
+func (c *Circle) SetRadius(r float64) {
+	c.setNumber("r", r)
+}
+
+// GetRadius returns the radius
+func (c *Circle) GetRadius() float64 {
+	return c.number("r")
+}
+
+// Rect is a <rect> element
+type Rect struct {
+	Geometry
+}
+
+// NewRect creates a <rect> element with its top-left corner at (x, y)
+func NewRect(doc *dom.Document, x, y, width, height float64) *Rect {
+	r := &Rect{Geometry: Geometry{Element: newElement(doc, "rect")}}
+	r.SetPosition(x, y)
+	r.SetSize(width, height)
+	return r
+}
+
+// SetPosition sets the top-left corner
+func (r *Rect) SetPosition(x, y float64) {
+	r.setNumber("x", x)
+	r.setNumber("y", y)
+}
+
+// GetPosition returns the top-left corner
+func (r *Rect) GetPosition() Point {
+	return Point{X: r.number("x"), Y: r.number("y")}
+}
+
+// SetSize sets the width and height
+func (r *Rect) SetSize(width, height float64) {
+	r.setNumber("width", width)
+	r.setNumber("height", height)
+}
+
+// GetWidth returns the width
+func (r *Rect) GetWidth() float64 {
+	return r.number("width")
+}
+
+// GetHeight returns the height
+func (r *Rect) GetHeight() float64 {
+	return r.number("height")
+}
+
+// SetCornerRadius rounds the corners with the given radii
+func (r *Rect) SetCornerRadius(rx, ry float64) {
+	r.setNumber("rx", rx)
+	r.setNumber("ry", ry)
+}
+
+// Line is a <line> element
+type Line struct {
+	Geometry
+}
+
+// NewLine creates a <line> element from (x1, y1) to (x2, y2)
+func NewLine(doc *dom.Document, x1, y1, x2, y2 float64) *Line {
+	l := &Line{Geometry: Geometry{Element: newElement(doc, "line")}}
+	l.SetStart(x1, y1)
+	l.SetEnd(x2, y2)
+	return l
+}
+
+// SetStart sets the start point
+func (l *Line) SetStart(x, y float64) {
+	l.setNumber("x1", x)
+	l.setNumber("y1", y)
+}
+
+// GetStart returns the start point
+func (l *Line) GetStart() Point {
+	return Point{X: l.number("x1"), Y: l.number("y1")}
+}
+
+// SetEnd sets the end point
+func (l *Line) SetEnd(x, y float64) {
+	l.setNumber("x2", x)
+	l.setNumber("y2", y)
+}
+
+// GetEnd returns the end point
+func (l *Line) GetEnd() Point {
+	return Point{X: l.number("x2"), Y: l.number("y2")}
+}
+
+// Polyline is a <polyline> element, a series of connected lines
+type Polyline struct {
+	Geometry
+}
+
+// NewPolyline creates a <polyline> element through points
+func NewPolyline(doc *dom.Document, points ...Point) *Polyline {
+	p := &Polyline{Geometry: Geometry{Element: newElement(doc, "polyline")}}
+	p.SetPoints(points...)
+	return p
+}
+
+// SetPoints sets the points
+func (p *Polyline) SetPoints(points ...Point) {
+	pairs := make([]string, len(points))
+	for i, point := range points {
+		pairs[i] = formatNumber(point.X) + "," + formatNumber(point.Y)
+	}
+	p.SetAttribute("points", strings.Join(pairs, " "))
+}
+
+// GetPoints parses the points attribute, ignoring a trailing odd coordinate
+func (p *Polyline) GetPoints() []Point {
+	fields := splitList(p.GetAttribute("points"))
+	points := make([]Point, 0, len(fields)/2)
+	for i := 0; i+1 < len(fields); i += 2 {
+		x, errX := strconv.ParseFloat(fields[i], 64)
+		y, errY := strconv.ParseFloat(fields[i+1], 64)
+		if errX != nil || errY != nil {
+			break
+		}
+		points = append(points, Point{X: x, Y: y})
+	}
+	return points
+}
+
+// Text is a <text> element
+type Text struct {
+	Element
+}
+
+// NewText creates a <text> element whose baseline starts at (x, y)
+func NewText(doc *dom.Document, x, y float64, text string) *Text {
+	t := &Text{Element: newElement(doc, "text")}
+	t.SetPosition(x, y)
+	t.SetTextContent(text)
+	return t
+}
+
+// SetPosition sets the start of the baseline
+func (t *Text) SetPosition(x, y float64) {
+	t.setNumber("x", x)
+	t.setNumber("y", y)
+}
+
+// GetPosition returns the start of the baseline
+func (t *Text) GetPosition() Point {
+	return Point{X: t.number("x"), Y: t.number("y")}
+}
+
+// SetTextAnchor aligns the text to its position: "start", "middle" or "end"
+func (t *Text) SetTextAnchor(anchor string) {
+	t.SetAttribute("text-anchor", anchor)
+}
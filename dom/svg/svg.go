@@ -0,0 +1,200 @@
+//go:build js && wasm
+// +build js,wasm
+
+// Package svg provides typed wrappers for SVG elements. The elements are
+// created in the SVG namespace, so they render as graphics rather than as
+// unknown HTML elements.
+//
+// The package is only available when compiled for js/wasm.
+package svg
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/abdorrahmani/go-wasm/dom"
+)
+
+// Element is an SVG element
+type Element struct {
+	dom.Element
+}
+
+// Child is an SVG element that can be appended to a container such as SVG or G
+type Child interface {
+	svgElement() *Element
+}
+
+func (e *Element) svgElement() *Element {
+	return e
+}
+
+// newElement creates an SVG element with the given local name
+func newElement(doc *dom.Document, name string) Element {
+	e, err := doc.CreateElementNS(dom.SVGNamespace, name)
+	if err != nil {
+		// The names used by this package are always valid
+		panic(err)
+	}
+	return Element{Element: *e}
+}
+
+// formatNumber formats a number for an attribute value
+func formatNumber(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+// splitList splits a list of numbers separated by whitespace or commas
+func splitList(s string) []string {
+	return strings.FieldsFunc(s, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\t' || r == '\n' || r == '\r' || r == '\f'
+	})
+}
+
+// setNumber sets an attribute to a number
+func (e *Element) setNumber(name string, value float64) {
+	e.SetAttribute(name, formatNumber(value))
+}
+
+// number parses a numeric attribute, returning 0 if it is missing or invalid
+func (e *Element) number(name string) float64 {
+	f, _ := strconv.ParseFloat(strings.TrimSpace(e.GetAttribute(name)), 64)
+	return f
+}
+
+// appendChildren appends children to e
+func (e *Element) appendChildren(children []Child) error {
+	for _, child := range children {
+		if err := e.AppendChild(&dom.Node{Value: child.svgElement().Value}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SetFill sets the fill paint, such as a color or "none"
+func (e *Element) SetFill(paint string) {
+	e.SetAttribute("fill", paint)
+}
+
+// SetStroke sets the stroke paint
+func (e *Element) SetStroke(paint string) {
+	e.SetAttribute("stroke", paint)
+}
+
+// SetStrokeWidth sets the stroke width
+func (e *Element) SetStrokeWidth(width float64) {
+	e.setNumber("stroke-width", width)
+}
+
+// SetTransform sets the transform list, such as "translate(10 20) rotate(45)"
+func (e *Element) SetTransform(transform string) {
+	e.SetAttribute("transform", transform)
+}
+
+// GetBBox returns the bounding box of the element's geometry in its user
+// space, not including strokes or its own transform
+func (e *Element) GetBBox() *dom.DOMRect {
+	return &dom.DOMRect{
+		Value: e.Value.Call("getBBox"),
+	}
+}
+
+// Point is a point in user space
+type Point struct {
+	X, Y float64
+}
+
+// Geometry is an SVG shape whose outline can be measured
+type Geometry struct {
+	Element
+}
+
+// GetTotalLength returns the length of the outline
+func (g *Geometry) GetTotalLength() float64 {
+	return g.Value.Call("getTotalLength").MustFloat()
+}
+
+// GetPointAtLength returns the point at distance along the outline.
+// It returns an error if the outline is empty.
+func (g *Geometry) GetPointAtLength(distance float64) (Point, error) {
+	p, err := g.Value.CallE("getPointAtLength", distance)
+	if err != nil {
+		return Point{}, err
+	}
+	return Point{X: p.Get("x").MustFloat(), Y: p.Get("y").MustFloat()}, nil
+}
+
+// ViewBox is the area of user space an SVG element maps to its viewport
+type ViewBox struct {
+	MinX, MinY, Width, Height float64
+}
+
+// String formats the view box as a viewBox attribute value
+func (v ViewBox) String() string {
+	return fmt.Sprintf("%s %s %s %s", formatNumber(v.MinX), formatNumber(v.MinY), formatNumber(v.Width), formatNumber(v.Height))
+}
+
+// SVG is an <svg> element, the root of a drawing
+type SVG struct {
+	Element
+}
+
+// NewSVG creates an <svg> element with the given size
+func NewSVG(doc *dom.Document, width, height float64) *SVG {
+	s := &SVG{Element: newElement(doc, "svg")}
+	s.SetSize(width, height)
+	return s
+}
+
+// SetSize sets the width and height of the viewport
+func (s *SVG) SetSize(width, height float64) {
+	s.setNumber("width", width)
+	s.setNumber("height", height)
+}
+
+// SetViewBox sets the viewBox attribute
+func (s *SVG) SetViewBox(viewBox ViewBox) {
+	s.SetAttribute("viewBox", viewBox.String())
+}
+
+// GetViewBox parses the viewBox attribute, reporting false if it is missing or invalid
+func (s *SVG) GetViewBox() (ViewBox, bool) {
+	fields := splitList(s.GetAttribute("viewBox"))
+	if len(fields) != 4 {
+		return ViewBox{}, false
+	}
+	var values [4]float64
+	for i, field := range fields {
+		f, err := strconv.ParseFloat(field, 64)
+		if err != nil {
+			return ViewBox{}, false
+		}
+		values[i] = f
+	}
+	if values[2] < 0 || values[3] < 0 {
+		return ViewBox{}, false
+	}
+	return ViewBox{MinX: values[0], MinY: values[1], Width: values[2], Height: values[3]}, true
+}
+
+// Append appends the children in order
+func (s *SVG) Append(children ...Child) error {
+	return s.appendChildren(children)
+}
+
+// G is a <g> element, which groups its children
+type G struct {
+	Element
+}
+
+// NewG creates an empty <g> element
+func NewG(doc *dom.Document) *G {
+	return &G{Element: newElement(doc, "g")}
+}
+
+// Append appends the children in order
+func (g *G) Append(children ...Child) error {
+	return g.appendChildren(children)
+}
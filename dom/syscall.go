@@ -0,0 +1,528 @@
+//go:build js && wasm
+// +build js,wasm
+
+package dom
+
+import (
+	"strconv"
+
+	"github.com/abdorrahmani/go-wasm/js"
+)
+
+// Object is a handle to an object of the Backend. When compiled for js/wasm
+// it is a JavaScript value, so the Value fields of the package's types can be
+// used with the js package.
+type Object = *js.Value
+
+// defaultBackend returns the browser's DOM
+func defaultBackend() Backend {
+	return jsBackend{}
+}
+
+// isNullish reports whether v is missing or wraps JavaScript null or undefined
+func isNullish(v *js.Value) bool {
+	return v == nil || v.IsNull() || v.IsUndefined()
+}
+
+// orNil returns nil when v is null or undefined, and v otherwise
+func orNil(v *js.Value) *js.Value {
+	if isNullish(v) {
+		return nil
+	}
+	return v
+}
+
+// list converts an array-like JavaScript value, such as a NodeList, to a slice
+func list(v *js.Value) []*js.Value {
+	length := v.MustLength()
+	values := make([]*js.Value, length)
+	for i := range values {
+		values[i] = v.Get(strconv.Itoa(i))
+	}
+	return values
+}
+
+// object converts the options to a JavaScript EventInit dictionary
+func (i EventInit) object() *js.Value {
+	init := js.Global().Call("Object")
+	init.Set("bubbles", i.Bubbles)
+	init.Set("cancelable", i.Cancelable)
+	init.Set("composed", i.Composed)
+	return init
+}
+
+// jsBackend implements Backend with the DOM of the JavaScript host
+type jsBackend struct{}
+
+func (jsBackend) Document() *js.Value {
+	return js.Global().Get("document")
+}
+
+func (jsBackend) CreateElement(doc *js.Value, tagName string) (*js.Value, error) {
+	return doc.CallE("createElement", tagName)
+}
+
+func (jsBackend) CreateElementNS(doc *js.Value, namespace, qualifiedName string) (*js.Value, error) {
+	return doc.CallE("createElementNS", namespace, qualifiedName)
+}
+
+func (jsBackend) CreateTextNode(doc *js.Value, text string) *js.Value {
+	return doc.Call("createTextNode", text)
+}
+
+func (jsBackend) CreateDocumentFragment(doc *js.Value) *js.Value {
+	return doc.Call("createDocumentFragment")
+}
+
+func (jsBackend) GetElementByID(doc *js.Value, id string) *js.Value {
+	return orNil(doc.Call("getElementById", id))
+}
+
+func (jsBackend) Body(doc *js.Value) *js.Value {
+	return orNil(doc.Get("body"))
+}
+
+func (jsBackend) Head(doc *js.Value) *js.Value {
+	return orNil(doc.Get("head"))
+}
+
+func (jsBackend) Title(doc *js.Value) string {
+	return doc.Get("title").MustString()
+}
+
+func (jsBackend) SetTitle(doc *js.Value, title string) {
+	doc.Set("title", title)
+}
+
+func (jsBackend) URL(doc *js.Value) string {
+	return doc.Get("URL").MustString()
+}
+
+func (jsBackend) ReadyState(doc *js.Value) string {
+	return doc.Get("readyState").MustString()
+}
+
+func (jsBackend) QuerySelector(root *js.Value, selector string) (*js.Value, error) {
+	value, err := root.CallE("querySelector", selector)
+	if err != nil {
+		return nil, err
+	}
+	return orNil(value), nil
+}
+
+func (jsBackend) QuerySelectorAll(root *js.Value, selector string) ([]*js.Value, error) {
+	value, err := root.CallE("querySelectorAll", selector)
+	if err != nil {
+		return nil, err
+	}
+	return list(value), nil
+}
+
+func (jsBackend) NodeType(node *js.Value) int {
+	return node.Get("nodeType").MustInt()
+}
+
+func (jsBackend) NodeName(node *js.Value) string {
+	return node.Get("nodeName").MustString()
+}
+
+func (jsBackend) NodeValue(node *js.Value) string {
+	return node.Get("nodeValue").TryString("")
+}
+
+func (jsBackend) SetNodeValue(node *js.Value, value string) {
+	node.Set("nodeValue", value)
+}
+
+func (jsBackend) ParentNode(node *js.Value) *js.Value {
+	return orNil(node.Get("parentNode"))
+}
+
+func (jsBackend) ParentElement(node *js.Value) *js.Value {
+	return orNil(node.Get("parentElement"))
+}
+
+func (jsBackend) ChildNodes(node *js.Value) []*js.Value {
+	return list(node.Get("childNodes"))
+}
+
+func (jsBackend) FirstChild(node *js.Value) *js.Value {
+	return orNil(node.Get("firstChild"))
+}
+
+func (jsBackend) LastChild(node *js.Value) *js.Value {
+	return orNil(node.Get("lastChild"))
+}
+
+func (jsBackend) PreviousSibling(node *js.Value) *js.Value {
+	return orNil(node.Get("previousSibling"))
+}
+
+func (jsBackend) NextSibling(node *js.Value) *js.Value {
+	return orNil(node.Get("nextSibling"))
+}
+
+func (jsBackend) OwnerDocument(node *js.Value) *js.Value {
+	return orNil(node.Get("ownerDocument"))
+}
+
+func (jsBackend) CloneNode(node *js.Value, deep bool) *js.Value {
+	return node.Call("cloneNode", deep)
+}
+
+func (jsBackend) CompareDocumentPosition(node, other *js.Value) int {
+	return node.Call("compareDocumentPosition", other).MustInt()
+}
+
+func (jsBackend) Contains(node, other *js.Value) bool {
+	return node.Call("contains", other).MustBool()
+}
+
+func (jsBackend) IsEqualNode(node, other *js.Value) bool {
+	return node.Call("isEqualNode", other).MustBool()
+}
+
+func (jsBackend) IsSameNode(node, other *js.Value) bool {
+	return node.Call("isSameNode", other).MustBool()
+}
+
+func (jsBackend) InsertBefore(parent, node, child *js.Value) error {
+	_, err := parent.CallE("insertBefore", node, child)
+	return err
+}
+
+func (jsBackend) ReplaceChild(parent, node, child *js.Value) error {
+	_, err := parent.CallE("replaceChild", node, child)
+	return err
+}
+
+func (jsBackend) RemoveChild(parent, child *js.Value) error {
+	_, err := parent.CallE("removeChild", child)
+	return err
+}
+
+func (jsBackend) AppendChild(parent, child *js.Value) error {
+	_, err := parent.CallE("appendChild", child)
+	return err
+}
+
+func (jsBackend) Normalize(node *js.Value) {
+	node.Call("normalize")
+}
+
+func (jsBackend) LookupNamespaceURI(node *js.Value, prefix string) string {
+	return node.Call("lookupNamespaceURI", prefix).TryString("")
+}
+
+func (jsBackend) LookupPrefix(node *js.Value, namespace string) string {
+	return node.Call("lookupPrefix", namespace).TryString("")
+}
+
+func (jsBackend) BaseURI(node *js.Value) string {
+	return node.Get("baseURI").MustString()
+}
+
+func (jsBackend) TextContent(node *js.Value) string {
+	return node.Get("textContent").TryString("")
+}
+
+func (jsBackend) SetTextContent(node *js.Value, text string) {
+	node.Set("textContent", text)
+}
+
+func (jsBackend) TagName(element *js.Value) string {
+	return element.Get("tagName").MustString()
+}
+
+func (jsBackend) NamespaceURI(element *js.Value) string {
+	return element.Get("namespaceURI").TryString("")
+}
+
+func (jsBackend) Prefix(element *js.Value) string {
+	return element.Get("prefix").TryString("")
+}
+
+func (jsBackend) LocalName(element *js.Value) string {
+	return element.Get("localName").MustString()
+}
+
+func (jsBackend) InnerHTML(element *js.Value) string {
+	return element.Get("innerHTML").MustString()
+}
+
+func (jsBackend) SetInnerHTML(element *js.Value, html string) {
+	element.Set("innerHTML", html)
+}
+
+func (jsBackend) Attributes(element *js.Value) []Attr {
+	// attributes is a NamedNodeMap, not an array
+	values := element.Get("attributes")
+	attrs := make([]Attr, values.MustLength())
+	for i := range attrs {
+		value := values.Call("item", i)
+		attrs[i] = Attr{
+			Name:         value.Get("name").MustString(),
+			LocalName:    value.Get("localName").MustString(),
+			NamespaceURI: value.Get("namespaceURI").TryString(""),
+			Prefix:       value.Get("prefix").TryString(""),
+			Value:        value.Get("value").MustString(),
+		}
+	}
+	return attrs
+}
+
+func (jsBackend) GetAttribute(element *js.Value, name string) (string, bool) {
+	value := element.Call("getAttribute", name)
+	if isNullish(value) {
+		return "", false
+	}
+	return value.MustString(), true
+}
+
+func (jsBackend) SetAttribute(element *js.Value, name, value string) error {
+	_, err := element.CallE("setAttribute", name, value)
+	return err
+}
+
+func (jsBackend) RemoveAttribute(element *js.Value, name string) {
+	element.Call("removeAttribute", name)
+}
+
+func (jsBackend) ToggleAttribute(element *js.Value, name string, force bool) (bool, error) {
+	result, err := element.CallE("toggleAttribute", name, force)
+	if err != nil {
+		return false, err
+	}
+	return result.MustBool(), nil
+}
+
+func (jsBackend) GetAttributeNS(element *js.Value, namespace, localName string) (string, bool) {
+	value := element.Call("getAttributeNS", namespace, localName)
+	if isNullish(value) {
+		return "", false
+	}
+	return value.MustString(), true
+}
+
+func (jsBackend) SetAttributeNS(element *js.Value, namespace, qualifiedName, value string) error {
+	_, err := element.CallE("setAttributeNS", namespace, qualifiedName, value)
+	return err
+}
+
+func (jsBackend) RemoveAttributeNS(element *js.Value, namespace, localName string) {
+	element.Call("removeAttributeNS", namespace, localName)
+}
+
+func (jsBackend) Closest(element *js.Value, selector string) (*js.Value, error) {
+	value, err := element.CallE("closest", selector)
+	if err != nil {
+		return nil, err
+	}
+	return orNil(value), nil
+}
+
+func (jsBackend) ClassList(element *js.Value) *js.Value {
+	return element.Get("classList")
+}
+
+func (jsBackend) Style(element *js.Value) *js.Value {
+	return element.Get("style")
+}
+
+func (jsBackend) Focus(element *js.Value) {
+	element.Call("focus")
+}
+
+func (jsBackend) Blur(element *js.Value) {
+	element.Call("blur")
+}
+
+func (jsBackend) Click(element *js.Value) {
+	element.Call("click")
+}
+
+// tokenArgs converts tokens to call arguments
+func tokenArgs(tokens []string) []interface{} {
+	args := make([]interface{}, len(tokens))
+	for i, token := range tokens {
+		args[i] = token
+	}
+	return args
+}
+
+func (jsBackend) AddTokens(list *js.Value, tokens []string) error {
+	_, err := list.CallE("add", tokenArgs(tokens)...)
+	return err
+}
+
+func (jsBackend) RemoveTokens(list *js.Value, tokens []string) error {
+	_, err := list.CallE("remove", tokenArgs(tokens)...)
+	return err
+}
+
+func (jsBackend) ToggleToken(list *js.Value, token string) (bool, error) {
+	result, err := list.CallE("toggle", token)
+	if err != nil {
+		return false, err
+	}
+	return result.MustBool(), nil
+}
+
+func (jsBackend) ToggleTokenForce(list *js.Value, token string, force bool) (bool, error) {
+	result, err := list.CallE("toggle", token, force)
+	if err != nil {
+		return false, err
+	}
+	return result.MustBool(), nil
+}
+
+func (jsBackend) ReplaceToken(list *js.Value, oldToken, newToken string) (bool, error) {
+	result, err := list.CallE("replace", oldToken, newToken)
+	if err != nil {
+		return false, err
+	}
+	return result.MustBool(), nil
+}
+
+func (jsBackend) ContainsToken(list *js.Value, token string) bool {
+	return list.Call("contains", token).MustBool()
+}
+
+func (jsBackend) TokenCount(list *js.Value) int {
+	return list.Get("length").MustInt()
+}
+
+func (jsBackend) Token(list *js.Value, i int) (string, bool) {
+	value := list.Call("item", i)
+	if isNullish(value) {
+		return "", false
+	}
+	return value.MustString(), true
+}
+
+func (jsBackend) TokenListValue(list *js.Value) string {
+	return list.Get("value").MustString()
+}
+
+func (jsBackend) SetStyleProperty(style *js.Value, name, value, priority string) {
+	style.Call("setProperty", name, value, priority)
+}
+
+func (jsBackend) StylePropertyValue(style *js.Value, name string) string {
+	return style.Call("getPropertyValue", name).MustString()
+}
+
+func (jsBackend) StylePropertyPriority(style *js.Value, name string) string {
+	return style.Call("getPropertyPriority", name).MustString()
+}
+
+func (jsBackend) RemoveStyleProperty(style *js.Value, name string) {
+	style.Call("removeProperty", name)
+}
+
+func (jsBackend) CSSText(style *js.Value) string {
+	return style.Get("cssText").MustString()
+}
+
+func (jsBackend) SetCSSText(style *js.Value, text string) {
+	style.Set("cssText", text)
+}
+
+func (jsBackend) StyleLength(style *js.Value) int {
+	return style.Get("length").MustInt()
+}
+
+func (jsBackend) StyleItem(style *js.Value, i int) string {
+	return style.Call("item", i).MustString()
+}
+
+func (jsBackend) NewEvent(eventType string, init EventInit) *js.Value {
+	return js.Global().Get("Event").New(eventType, init.object())
+}
+
+func (jsBackend) EventType(event *js.Value) string {
+	return event.Get("type").MustString()
+}
+
+func (jsBackend) Target(event *js.Value) *js.Value {
+	return orNil(event.Get("target"))
+}
+
+func (jsBackend) CurrentTarget(event *js.Value) *js.Value {
+	return orNil(event.Get("currentTarget"))
+}
+
+func (jsBackend) EventPhase(event *js.Value) int {
+	return event.Get("eventPhase").MustInt()
+}
+
+func (jsBackend) Bubbles(event *js.Value) bool {
+	return event.Get("bubbles").MustBool()
+}
+
+func (jsBackend) Cancelable(event *js.Value) bool {
+	return event.Get("cancelable").MustBool()
+}
+
+func (jsBackend) TimeStamp(event *js.Value) float64 {
+	return event.Get("timeStamp").MustFloat()
+}
+
+func (jsBackend) DefaultPrevented(event *js.Value) bool {
+	return event.Get("defaultPrevented").MustBool()
+}
+
+func (jsBackend) IsTrusted(event *js.Value) bool {
+	return event.Get("isTrusted").MustBool()
+}
+
+func (jsBackend) CancelBubble(event *js.Value) bool {
+	return event.Get("cancelBubble").TryBool(false)
+}
+
+func (jsBackend) StopPropagation(event *js.Value) {
+	event.Call("stopPropagation")
+}
+
+func (jsBackend) StopImmediatePropagation(event *js.Value) {
+	event.Call("stopImmediatePropagation")
+}
+
+func (jsBackend) PreventDefault(event *js.Value) {
+	event.Call("preventDefault")
+}
+
+func (jsBackend) AddEventListener(target *js.Value, eventType string, handler func(*js.Value), opts AddEventListenerOptions) func() {
+	callback := js.NewCallback(func(args []*js.Value) {
+		handler(args[0])
+	})
+	options := js.Global().Call("Object")
+	options.Set("capture", opts.Capture)
+	options.Set("once", opts.Once)
+	options.Set("passive", opts.Passive)
+	if opts.Signal != nil {
+		options.Set("signal", opts.Signal.Value)
+	}
+	target.Call("addEventListener", eventType, callback, options)
+	return func() {
+		target.Call("removeEventListener", eventType, callback, opts.Capture)
+		callback.Release()
+	}
+}
+
+func (jsBackend) DispatchEvent(target, event *js.Value) bool {
+	return target.Call("dispatchEvent", event).MustBool()
+}
+
+func (jsBackend) NewAbortController() (*js.Value, *js.Value) {
+	controller := js.Global().Get("AbortController").New()
+	return controller, controller.Get("signal")
+}
+
+func (jsBackend) Abort(controller *js.Value) {
+	controller.Call("abort")
+}
+
+func (jsBackend) Aborted(signal *js.Value) bool {
+	return signal.Get("aborted").MustBool()
+}
@@ -0,0 +1,59 @@
+//go:build js && wasm
+// +build js,wasm
+
+package test
+
+import (
+	"time"
+
+	"github.com/abdorrahmani/go-wasm/js"
+)
+
+// wait lets timers, animation frames and idle callbacks due within d run
+func wait(d time.Duration) {
+	time.Sleep(d)
+}
+
+// fire dispatches a plain event of the given type at target
+func fire(target *js.Value, eventType string, bubbles bool) {
+	init := js.Global().Call("Object")
+	init.Set("bubbles", bubbles)
+	target.Call("dispatchEvent", js.Global().Get("Event").New(eventType, init))
+}
+
+// override shadows a read-only property of the document with value, as
+// assigning to it has no effect in a browser
+func override(doc *js.Value, name string, value interface{}) {
+	descriptor := js.Global().Call("Object")
+	descriptor.Set("value", value)
+	descriptor.Set("configurable", true)
+	js.Global().Get("Object").Call("defineProperty", doc, name, descriptor)
+}
+
+// setReadyState simulates the document loading up to state, firing the same
+// events as a browser
+func setReadyState(state string) {
+	doc := js.Global().Get("document")
+	if doc.Get("readyState").MustString() == state {
+		return
+	}
+	override(doc, "readyState", state)
+	fire(doc, "readystatechange", false)
+	switch state {
+	case "interactive":
+		fire(doc, "DOMContentLoaded", true)
+	case "complete":
+		fire(js.Global(), "load", false)
+	}
+}
+
+// setVisibilityState simulates the user hiding or showing the page
+func setVisibilityState(state string) {
+	doc := js.Global().Get("document")
+	if doc.Get("visibilityState").MustString() == state {
+		return
+	}
+	override(doc, "visibilityState", state)
+	override(doc, "hidden", state == "hidden")
+	fire(doc, "visibilitychange", true)
+}
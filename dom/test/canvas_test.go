@@ -0,0 +1,679 @@
+//go:build js && wasm
+// +build js,wasm
+
+package test
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/abdorrahmani/go-wasm/dom"
+	"github.com/abdorrahmani/go-wasm/dom/canvas"
+	"github.com/abdorrahmani/go-wasm/js"
+)
+
+// recorder is the body of a function returning a stand-in for a 2D context.
+// Its methods record their name and arguments in calls, and the methods that
+// return something return fixed values. Properties are stored as set.
+const recorder = `
+const calls = [];
+const record = (name, result) => (...args) => {
+	calls.push([name, ...args]);
+	return result?.(...args);
+};
+const methods = {
+	getTransform: () => ({ a: 2, b: 0, c: 0, d: 2, e: 5, f: 5 }),
+	getLineDash: () => [3, 1],
+	isPointInPath: () => true,
+	measureText: () => ({
+		width: 42, actualBoundingBoxLeft: 1, actualBoundingBoxRight: 41,
+		actualBoundingBoxAscent: 15, actualBoundingBoxDescent: 4,
+		fontBoundingBoxAscent: 18, fontBoundingBoxDescent: 5,
+	}),
+	getImageData: (x, y, w, h) => ({
+		width: w, height: h,
+		data: Uint8ClampedArray.from([255, 0, 0, 255, 200, 100, 50, 128, 10, 20, 30, 0]),
+	}),
+	createLinearGradient: () => ({ addColorStop: record("addColorStop") }),
+	createRadialGradient: () => ({ addColorStop: record("addColorStop") }),
+	createPattern: () => ({ setTransform: record("setTransform") }),
+};
+// describe converts the arguments of calls to JSON, with elements as their
+// tag names and image data as its size and pixels
+const describe = (key, value) => {
+	if (value && typeof value.nodeName === "string") {
+		return "<" + value.nodeName.toLowerCase() + ">";
+	}
+	if (value && value.data instanceof Uint8ClampedArray) {
+		return { width: value.width, height: value.height, data: Array.from(value.data) };
+	}
+	return value;
+};
+return new Proxy({}, {
+	get(target, name) {
+		if (name === "calls") {
+			return JSON.stringify(calls, describe);
+		}
+		if (name in target || typeof name !== "string") {
+			return target[name];
+		}
+		return record(name, methods[name]);
+	},
+});
+`
+
+// newRecorder returns a 2D context whose calls are recorded instead of drawn.
+// Browsers without ImageData get a plain one for PutImageData.
+func newRecorder() *canvas.Context2D {
+	global := js.Global()
+	if global.Get("ImageData").IsUndefined() {
+		global.Set("ImageData", global.Get("Function").New("width", "height", `
+			this.width = width;
+			this.height = height;
+			this.data = new Uint8ClampedArray(width * height * 4);
+		`))
+	}
+	return &canvas.Context2D{Value: global.Get("Function").New(recorder).Invoke()}
+}
+
+// TestContextCalls checks that the context methods call the methods of the
+// canvas API with their arguments in order, and convert what they return
+func TestContextCalls(t *testing.T) {
+	fmt.Println("Starting context call tests...")
+
+	doc := dom.Global()
+	source := canvas.New(doc, 2, 2)
+
+	tests := []struct {
+		name string
+		run  func(ctx *canvas.Context2D) error
+		// calls is the JSON of the recorded calls
+		calls string
+	}{
+		{
+			name: "Transforms And State",
+			run: func(ctx *canvas.Context2D) error {
+				ctx.Save()
+				ctx.Translate(1, 2)
+				ctx.Scale(2, 3)
+				ctx.Rotate(0.5)
+				ctx.Transform(canvas.Matrix{A: 1, B: 2, C: 3, D: 4, E: 5, F: 6})
+				ctx.SetTransform(canvas.Identity)
+				ctx.ResetTransform()
+				ctx.Restore()
+				if got := ctx.GetTransform(); got != (canvas.Matrix{A: 2, D: 2, E: 5, F: 5}) {
+					return fmt.Errorf("unexpected transform: %+v", got)
+				}
+				return nil
+			},
+			calls: `[["save"],["translate",1,2],["scale",2,3],["rotate",0.5],["transform",1,2,3,4,5,6],` +
+				`["setTransform",1,0,0,1,0,0],["resetTransform"],["restore"],["getTransform"]]`,
+		},
+		{
+			name: "Paths",
+			run: func(ctx *canvas.Context2D) error {
+				ctx.BeginPath()
+				ctx.MoveTo(1, 2)
+				ctx.LineTo(3, 4)
+				ctx.QuadraticCurveTo(1, 2, 3, 4)
+				ctx.BezierCurveTo(1, 2, 3, 4, 5, 6)
+				if err := ctx.Arc(1, 2, 3, 0, 1.5, true); err != nil {
+					return err
+				}
+				if err := ctx.ArcTo(1, 2, 3, 4, 5); err != nil {
+					return err
+				}
+				if err := ctx.Ellipse(1, 2, 3, 4, 0.5, 0, 1, false); err != nil {
+					return err
+				}
+				ctx.Rect(1, 2, 3, 4)
+				ctx.ClosePath()
+				return nil
+			},
+			calls: `[["beginPath"],["moveTo",1,2],["lineTo",3,4],["quadraticCurveTo",1,2,3,4],` +
+				`["bezierCurveTo",1,2,3,4,5,6],["arc",1,2,3,0,1.5,true],["arcTo",1,2,3,4,5],` +
+				`["ellipse",1,2,3,4,0.5,0,1,false],["rect",1,2,3,4],["closePath"]]`,
+		},
+		{
+			name: "Fill Rules",
+			run: func(ctx *canvas.Context2D) error {
+				ctx.Fill("")
+				ctx.Fill(canvas.EvenOdd)
+				ctx.Clip(canvas.NonZero)
+				ctx.Stroke()
+				if !ctx.IsPointInPath(1, 2, "") || !ctx.IsPointInPath(1, 2, canvas.EvenOdd) {
+					return fmt.Errorf("IsPointInPath should return the result of isPointInPath")
+				}
+				return nil
+			},
+			calls: `[["fill"],["fill","evenodd"],["clip","nonzero"],["stroke"],` +
+				`["isPointInPath",1,2],["isPointInPath",1,2,"evenodd"]]`,
+		},
+		{
+			name: "Rectangles And Text",
+			run: func(ctx *canvas.Context2D) error {
+				ctx.FillRect(1, 2, 3, 4)
+				ctx.StrokeRect(5, 6, 7, 8)
+				ctx.ClearRect(0, 0, 10, 10)
+				ctx.FillText("Go", 1, 2)
+				ctx.StrokeText("Gopher", 3, 4)
+				want := canvas.TextMetrics{
+					Width: 42, ActualBoundingBoxLeft: 1, ActualBoundingBoxRight: 41,
+					ActualBoundingBoxAscent: 15, ActualBoundingBoxDescent: 4,
+					FontBoundingBoxAscent: 18, FontBoundingBoxDescent: 5,
+				}
+				if got := ctx.MeasureText("Go"); got != want {
+					return fmt.Errorf("unexpected metrics: %+v", got)
+				}
+				return nil
+			},
+			calls: `[["fillRect",1,2,3,4],["strokeRect",5,6,7,8],["clearRect",0,0,10,10],` +
+				`["fillText","Go",1,2],["strokeText","Gopher",3,4],["measureText","Go"]]`,
+		},
+		{
+			name: "Line Styles",
+			run: func(ctx *canvas.Context2D) error {
+				ctx.SetLineWidth(3)
+				ctx.SetLineCap(canvas.RoundCap)
+				ctx.SetLineJoin(canvas.BevelJoin)
+				ctx.SetLineDash([]float64{3, 1})
+				if ctx.GetLineWidth() != 3 || ctx.GetLineCap() != canvas.RoundCap || ctx.GetLineJoin() != canvas.BevelJoin {
+					return fmt.Errorf("unexpected line style: %v, %v, %v", ctx.GetLineWidth(), ctx.GetLineCap(), ctx.GetLineJoin())
+				}
+				if got := ctx.GetLineDash(); len(got) != 2 || got[0] != 3 || got[1] != 1 {
+					return fmt.Errorf("unexpected line dash: %v", got)
+				}
+				return nil
+			},
+			calls: `[["setLineDash",[3,1]],["getLineDash"]]`,
+		},
+		{
+			name: "Fill And Stroke Styles",
+			run: func(ctx *canvas.Context2D) error {
+				ctx.SetFillStyle(canvas.Color("red"))
+				if got := ctx.GetFillStyle(); got != canvas.Color("red") {
+					return fmt.Errorf("unexpected fill style: %v", got)
+				}
+				gradient, err := ctx.CreateLinearGradient(0, 1, 2, 3)
+				if err != nil {
+					return err
+				}
+				if err := gradient.AddColorStop(0.5, "blue"); err != nil {
+					return err
+				}
+				if _, err := ctx.CreateRadialGradient(0, 1, 2, 3, 4, 5); err != nil {
+					return err
+				}
+				ctx.SetStrokeStyle(gradient)
+				if _, ok := ctx.GetStrokeStyle().(*canvas.Gradient); !ok {
+					return fmt.Errorf("the stroke style should be the gradient")
+				}
+				pattern, err := ctx.CreatePattern(source, canvas.RepeatX)
+				if err != nil {
+					return err
+				}
+				ctx.SetFillStyle(pattern)
+				if _, ok := ctx.GetFillStyle().(*canvas.Pattern); !ok {
+					return fmt.Errorf("the fill style should be the pattern")
+				}
+				return nil
+			},
+			calls: `[["createLinearGradient",0,1,2,3],["addColorStop",0.5,"blue"],` +
+				`["createRadialGradient",0,1,2,3,4,5],["createPattern","<canvas>","repeat-x"]]`,
+		},
+		{
+			name: "Draw Images",
+			run: func(ctx *canvas.Context2D) error {
+				if err := ctx.DrawImage(source, 1, 2); err != nil {
+					return err
+				}
+				if err := ctx.DrawImageScaled(source, 1, 2, 3, 4); err != nil {
+					return err
+				}
+				return ctx.DrawImageRegion(source, 1, 2, 3, 4, 5, 6, 7, 8)
+			},
+			calls: `[["drawImage","<canvas>",1,2],["drawImage","<canvas>",1,2,3,4],` +
+				`["drawImage","<canvas>",1,2,3,4,5,6,7,8]]`,
+		},
+		{
+			name: "Get Image Data Premultiplies",
+			run: func(ctx *canvas.Context2D) error {
+				img, err := ctx.GetImageData(image.Rect(1, 2, 4, 3))
+				if err != nil {
+					return err
+				}
+				if img.Rect != image.Rect(1, 2, 4, 3) {
+					return fmt.Errorf("unexpected bounds: %v", img.Rect)
+				}
+				want := []color.RGBA{{255, 0, 0, 255}, {100, 50, 25, 128}, {0, 0, 0, 0}}
+				for i, w := range want {
+					if got := img.RGBAAt(1+i, 2); got != w {
+						return fmt.Errorf("expected %v at %d, got %v", w, 1+i, got)
+					}
+				}
+				if _, err := ctx.GetImageData(image.Rect(0, 0, 0, 5)); err != nil {
+					return fmt.Errorf("an empty rectangle should not fail: %v", err)
+				}
+				return nil
+			},
+			calls: `[["getImageData",1,2,3,1]]`,
+		},
+		{
+			name: "Put Image Data Unpremultiplies",
+			run: func(ctx *canvas.Context2D) error {
+				// A sub-image has a stride wider than its rows
+				img := image.NewRGBA(image.Rect(0, 0, 4, 2))
+				img.SetRGBA(1, 0, color.RGBA{255, 0, 0, 255})
+				img.SetRGBA(2, 0, color.RGBA{100, 50, 25, 128})
+				img.SetRGBA(2, 1, color.RGBA{9, 9, 9, 9})
+				// Colors above alpha are not valid premultiplied colors
+				img.SetRGBA(1, 1, color.RGBA{200, 10, 0, 100})
+				sub := img.SubImage(image.Rect(1, 0, 3, 2)).(*image.RGBA)
+				if err := ctx.PutImageData(sub, 5, 6); err != nil {
+					return err
+				}
+				return ctx.PutImageData(image.NewRGBA(image.Rect(0, 0, 0, 0)), 0, 0)
+			},
+			calls: `[["putImageData",{"width":2,"height":2,"data":[255,0,0,255,199,100,50,128,255,26,0,100,255,255,255,9]},5,6]]`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fmt.Printf("Running test: %s\n", tt.name)
+			ctx := newRecorder()
+			err := tt.run(ctx)
+			if calls := ctx.Value.Get("calls").MustString(); err == nil && calls != tt.calls {
+				err = fmt.Errorf("unexpected calls:\n got %s\nwant %s", calls, tt.calls)
+			}
+			if err != nil {
+				t.Errorf("validation failed: %v", err)
+				fmt.Printf("❌ Test failed: %s - %v\n", tt.name, err)
+			} else {
+				fmt.Printf("✅ Test passed: %s\n", tt.name)
+			}
+		})
+	}
+}
+
+// TestCanvas draws onto a real canvas. It only runs in browsers, as the DOM
+// used under Node.js has no canvas.
+func TestCanvas(t *testing.T) {
+	if name := missingAPI("HTMLCanvasElement"); name != "" {
+		t.Skipf("%s is not available", name)
+	}
+	fmt.Println("Starting canvas tests...")
+
+	doc := dom.Global()
+	c := canvas.New(doc, 40, 20)
+	if err := doc.GetBody().AppendChild(&dom.Node{Value: c.Value}); err != nil {
+		t.Fatalf("Failed to append canvas: %v", err)
+	}
+	defer doc.GetBody().RemoveChild(&dom.Node{Value: c.Value})
+	ctx, err := c.GetContext2D()
+	if err != nil {
+		t.Fatalf("Failed to get 2D context: %v", err)
+	}
+	// pixel returns the color of a canvas pixel
+	pixel := func(x, y int) (color.RGBA, error) {
+		img, err := ctx.GetImageData(image.Rect(x, y, x+1, y+1))
+		if err != nil {
+			return color.RGBA{}, err
+		}
+		return img.RGBAAt(x, y), nil
+	}
+	// clear resets the canvas, its drawing state and its path
+	clear := func() {
+		c.SetWidth(40)
+	}
+
+	tests := []struct {
+		name     string
+		validate func() error
+	}{
+		{
+			name: "Canvas Size And Context",
+			validate: func() error {
+				if c.GetWidth() != 40 || c.GetHeight() != 20 {
+					return fmt.Errorf("unexpected size: %dx%d", c.GetWidth(), c.GetHeight())
+				}
+				if canvas.AsCanvas(&c.Element) == nil || canvas.AsCanvas(doc.GetBody()) != nil {
+					return fmt.Errorf("AsCanvas should only accept canvas elements")
+				}
+				again, err := c.GetContext2D()
+				if err != nil {
+					return fmt.Errorf("failed to get the context again: %v", err)
+				}
+				again.SetLineWidth(7)
+				if ctx.GetLineWidth() != 7 {
+					return fmt.Errorf("the context should be reused")
+				}
+				if !ctx.GetCanvas().IsSameNode(&c.Element) {
+					return fmt.Errorf("the context should point back to its canvas")
+				}
+				return nil
+			},
+		},
+		{
+			name: "Fill Rectangles And Read Pixels",
+			validate: func() error {
+				clear()
+				ctx.SetFillStyle(canvas.Color("red"))
+				ctx.FillRect(10, 5, 10, 10)
+				ctx.SetFillStyle(canvas.Color("rgba(0, 0, 255, 0.5)"))
+				ctx.FillRect(30, 0, 10, 10)
+				img, err := ctx.GetImageData(image.Rect(0, 0, 40, 20))
+				if err != nil {
+					return fmt.Errorf("failed to get image data: %v", err)
+				}
+				if got := img.RGBAAt(15, 10); got != (color.RGBA{255, 0, 0, 255}) {
+					return fmt.Errorf("expected red inside the rectangle, got %v", got)
+				}
+				if got := img.RGBAAt(5, 10); got != (color.RGBA{}) {
+					return fmt.Errorf("expected transparent outside the rectangle, got %v", got)
+				}
+				if got := img.RGBAAt(35, 5); got != (color.RGBA{0, 0, 128, 128}) {
+					return fmt.Errorf("expected premultiplied half-transparent blue, got %v", got)
+				}
+				ctx.ClearRect(10, 5, 5, 10)
+				if got, _ := pixel(12, 10); got != (color.RGBA{}) {
+					return fmt.Errorf("cleared pixels should be transparent, got %v", got)
+				}
+				if got := ctx.GetFillStyle(); got != canvas.Color("rgba(0, 0, 255, 0.5)") {
+					return fmt.Errorf("unexpected fill style: %v", got)
+				}
+				return nil
+			},
+		},
+		{
+			name: "Put Image Data",
+			validate: func() error {
+				clear()
+				img := image.NewRGBA(image.Rect(0, 0, 2, 2))
+				img.SetRGBA(0, 0, color.RGBA{0, 255, 0, 255})
+				img.SetRGBA(1, 1, color.RGBA{0, 0, 100, 200})
+				if err := ctx.PutImageData(img, 3, 4); err != nil {
+					return fmt.Errorf("failed to put image data: %v", err)
+				}
+				got, err := ctx.GetImageData(image.Rect(3, 4, 5, 6))
+				if err != nil {
+					return fmt.Errorf("failed to get image data: %v", err)
+				}
+				if got.RGBAAt(3, 4) != (color.RGBA{0, 255, 0, 255}) || got.RGBAAt(4, 5) != (color.RGBA{0, 0, 100, 200}) {
+					return fmt.Errorf("pixels should round-trip: %v, %v", got.RGBAAt(3, 4), got.RGBAAt(4, 5))
+				}
+				if _, err := ctx.GetImageData(image.Rect(0, 0, 0, 5)); err != nil {
+					return fmt.Errorf("an empty rectangle should not fail: %v", err)
+				}
+				return nil
+			},
+		},
+		{
+			name: "Transforms And Saved State",
+			validate: func() error {
+				clear()
+				ctx.Translate(5, 5)
+				ctx.Save()
+				ctx.Scale(2, 2)
+				ctx.SetLineWidth(3)
+				if got := ctx.GetTransform(); got != (canvas.Matrix{A: 2, D: 2, E: 5, F: 5}) {
+					return fmt.Errorf("unexpected transform: %+v", got)
+				}
+				ctx.FillRect(0, 0, 2, 2)
+				if got, _ := pixel(8, 8); got.A != 255 {
+					return fmt.Errorf("the rectangle should be scaled, got %v", got)
+				}
+				ctx.Restore()
+				if got := ctx.GetTransform(); got != (canvas.Matrix{A: 1, D: 1, E: 5, F: 5}) || ctx.GetLineWidth() != 1 {
+					return fmt.Errorf("restore should bring back the saved state: %+v, %v", got, ctx.GetLineWidth())
+				}
+				ctx.ResetTransform()
+				if ctx.GetTransform() != canvas.Identity {
+					return fmt.Errorf("the transform should be reset")
+				}
+				return nil
+			},
+		},
+		{
+			name: "Paths And Fill Rules",
+			validate: func() error {
+				clear()
+				ctx.BeginPath()
+				ctx.Rect(0, 0, 20, 20)
+				ctx.Rect(5, 5, 10, 10)
+				if !ctx.IsPointInPath(10, 10, canvas.NonZero) || ctx.IsPointInPath(10, 10, canvas.EvenOdd) {
+					return fmt.Errorf("the fill rule should decide whether the inner square is inside")
+				}
+				ctx.Fill(canvas.EvenOdd)
+				if hole, _ := pixel(10, 10); hole.A != 0 {
+					return fmt.Errorf("the even-odd rule should leave a hole, got %v", hole)
+				}
+				if ring, _ := pixel(2, 10); ring.A != 255 {
+					return fmt.Errorf("the ring should be filled, got %v", ring)
+				}
+
+				ctx.BeginPath()
+				ctx.MoveTo(30, 2)
+				ctx.LineTo(38, 2)
+				ctx.QuadraticCurveTo(38, 10, 30, 10)
+				ctx.ClosePath()
+				if !ctx.IsPointInPath(34, 4, canvas.NonZero) || ctx.IsPointInPath(39, 10, canvas.NonZero) {
+					return fmt.Errorf("unexpected curve hit testing")
+				}
+				if err := ctx.Arc(0, 0, -1, 0, 1, false); err == nil {
+					return fmt.Errorf("a negative radius should fail")
+				}
+				return nil
+			},
+		},
+		{
+			name: "Strokes And Line Styles",
+			validate: func() error {
+				clear()
+				ctx.SetStrokeStyle(canvas.Color("#00ff00"))
+				ctx.SetLineWidth(4)
+				ctx.SetLineCap(canvas.SquareCap)
+				ctx.BeginPath()
+				ctx.MoveTo(10, 10)
+				ctx.LineTo(30, 10)
+				ctx.Stroke()
+				if got, _ := pixel(20, 11); got != (color.RGBA{0, 255, 0, 255}) {
+					return fmt.Errorf("expected the stroke, got %v", got)
+				}
+				if got, _ := pixel(31, 10); got.A != 255 {
+					return fmt.Errorf("square caps should extend the line, got %v", got)
+				}
+				if got, _ := pixel(20, 13); got.A != 0 {
+					return fmt.Errorf("the stroke should be 4 pixels wide, got %v", got)
+				}
+				ctx.SetLineDash([]float64{3})
+				if got := ctx.GetLineDash(); len(got) != 2 || got[0] != 3 || got[1] != 3 {
+					return fmt.Errorf("an odd dash list should be repeated: %v", got)
+				}
+				if ctx.GetLineCap() != canvas.SquareCap || ctx.GetStrokeStyle() != canvas.Color("#00ff00") {
+					return fmt.Errorf("unexpected line style: %v, %v", ctx.GetLineCap(), ctx.GetStrokeStyle())
+				}
+				return nil
+			},
+		},
+		{
+			name: "Gradients And Patterns",
+			validate: func() error {
+				clear()
+				gradient, err := ctx.CreateLinearGradient(0, 0, 40, 0)
+				if err != nil {
+					return fmt.Errorf("failed to create gradient: %v", err)
+				}
+				if err := gradient.AddColorStop(0, "black"); err != nil {
+					return fmt.Errorf("failed to add color stop: %v", err)
+				}
+				if err := gradient.AddColorStop(1, "white"); err != nil {
+					return fmt.Errorf("failed to add color stop: %v", err)
+				}
+				if err := gradient.AddColorStop(2, "white"); err == nil {
+					return fmt.Errorf("an offset out of range should fail")
+				}
+				if err := gradient.AddColorStop(0.5, "not a color"); err == nil {
+					return fmt.Errorf("an invalid color should fail")
+				}
+				ctx.SetFillStyle(gradient)
+				ctx.FillRect(0, 0, 40, 10)
+				left, _ := pixel(0, 5)
+				right, _ := pixel(39, 5)
+				if left.R > 10 || right.R < 245 {
+					return fmt.Errorf("unexpected gradient colors: %v, %v", left, right)
+				}
+				if _, ok := ctx.GetFillStyle().(*canvas.Gradient); !ok {
+					return fmt.Errorf("the fill style should be the gradient")
+				}
+				if _, err := ctx.CreateRadialGradient(0, 0, -1, 0, 0, 1); err == nil {
+					return fmt.Errorf("a negative radius should fail")
+				}
+
+				tile := canvas.New(doc, 2, 2)
+				tileCtx, _ := tile.GetContext2D()
+				tileCtx.SetFillStyle(canvas.Color("blue"))
+				tileCtx.FillRect(0, 0, 1, 1)
+				pattern, err := ctx.CreatePattern(tile, canvas.Repeat)
+				if err != nil || pattern == nil {
+					return fmt.Errorf("failed to create pattern: %v", err)
+				}
+				ctx.SetFillStyle(pattern)
+				ctx.FillRect(0, 10, 40, 10)
+				if on, _ := pixel(4, 12); on != (color.RGBA{0, 0, 255, 255}) {
+					return fmt.Errorf("expected the pattern's colored pixel, got %v", on)
+				}
+				if off, _ := pixel(5, 12); off.A != 0 {
+					return fmt.Errorf("expected the pattern's transparent pixel, got %v", off)
+				}
+				if _, err := ctx.CreatePattern(tile, "sideways"); err == nil {
+					return fmt.Errorf("an invalid repetition should fail")
+				}
+				return nil
+			},
+		},
+		{
+			name: "Draw Images",
+			validate: func() error {
+				clear()
+				source := canvas.New(doc, 4, 4)
+				sourceCtx, _ := source.GetContext2D()
+				sourceCtx.SetFillStyle(canvas.Color("red"))
+				sourceCtx.FillRect(0, 0, 2, 4)
+				sourceCtx.SetFillStyle(canvas.Color("blue"))
+				sourceCtx.FillRect(2, 0, 2, 4)
+
+				// Scaled images are smoothed, which blends the colors
+				// where they meet
+				ctx.SetImageSmoothingEnabled(false)
+				if err := ctx.DrawImage(source, 0, 0); err != nil {
+					return fmt.Errorf("failed to draw image: %v", err)
+				}
+				if err := ctx.DrawImageScaled(source, 10, 0, 8, 8); err != nil {
+					return fmt.Errorf("failed to draw scaled image: %v", err)
+				}
+				if err := ctx.DrawImageRegion(source, 2, 0, 2, 2, 20, 0, 4, 4); err != nil {
+					return fmt.Errorf("failed to draw image region: %v", err)
+				}
+				checks := map[image.Point]color.RGBA{
+					{1, 1}: {255, 0, 0, 255}, {3, 1}: {0, 0, 255, 255},
+					{13, 6}: {255, 0, 0, 255}, {14, 6}: {0, 0, 255, 255}, {18, 1}: {},
+					{21, 1}: {0, 0, 255, 255}, {24, 1}: {},
+				}
+				for p, want := range checks {
+					if got, _ := pixel(p.X, p.Y); got != want {
+						return fmt.Errorf("expected %v at %v, got %v", want, p, got)
+					}
+				}
+
+				img := canvas.AsImage(doc.CreateElement("img"))
+				if err := ctx.DrawImage(img, 0, 0); err != nil {
+					return fmt.Errorf("an image without data should draw nothing: %v", err)
+				}
+				empty := canvas.New(doc, 0, 0)
+				if err := ctx.DrawImage(empty, 0, 0); err == nil {
+					return fmt.Errorf("drawing an empty canvas should fail")
+				}
+				return nil
+			},
+		},
+		{
+			name: "Measure Text",
+			validate: func() error {
+				clear()
+				ctx.SetFont("20px sans-serif")
+				metrics := ctx.MeasureText("Gopher")
+				if metrics.Width <= 0 || metrics.ActualBoundingBoxAscent <= 0 {
+					return fmt.Errorf("unexpected metrics: %+v", metrics)
+				}
+				if longer := ctx.MeasureText("Gopher Gopher"); longer.Width <= metrics.Width {
+					return fmt.Errorf("longer text should be wider: %v <= %v", longer.Width, metrics.Width)
+				}
+				ctx.SetFont("not a font")
+				if ctx.GetFont() != "20px sans-serif" {
+					return fmt.Errorf("an invalid font should be ignored, got %q", ctx.GetFont())
+				}
+				return nil
+			},
+		},
+		{
+			name: "Export As PNG",
+			validate: func() error {
+				clear()
+				ctx.SetFillStyle(canvas.Color("#336699"))
+				ctx.FillRect(0, 0, 40, 20)
+				url := c.ToDataURL("")
+				if !strings.HasPrefix(url, "data:image/png;base64,") {
+					return fmt.Errorf("unexpected data URL: %.40s", url)
+				}
+				data, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(url, "data:image/png;base64,"))
+				if err != nil {
+					return fmt.Errorf("invalid base64: %v", err)
+				}
+				decoded, err := png.Decode(bytes.NewReader(data))
+				if err != nil {
+					return fmt.Errorf("invalid PNG: %v", err)
+				}
+				if decoded.Bounds() != image.Rect(0, 0, 40, 20) {
+					return fmt.Errorf("unexpected PNG size: %v", decoded.Bounds())
+				}
+				if r, g, b, a := decoded.At(5, 5).RGBA(); r>>8 != 0x33 || g>>8 != 0x66 || b>>8 != 0x99 || a>>8 != 0xff {
+					return fmt.Errorf("unexpected PNG color: %v", decoded.At(5, 5))
+				}
+
+				blobs := make(chan *canvas.Blob, 1)
+				if err := c.ToBlob("image/png", func(b *canvas.Blob) { blobs <- b }); err != nil {
+					return fmt.Errorf("failed to encode blob: %v", err)
+				}
+				select {
+				case b := <-blobs:
+					if b == nil || b.GetType() != "image/png" || b.GetSize() == 0 {
+						return fmt.Errorf("unexpected blob: %v", b)
+					}
+				case <-time.After(time.Second):
+					return fmt.Errorf("the blob callback was not called")
+				}
+				return nil
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fmt.Printf("Running test: %s\n", tt.name)
+			if err := tt.validate(); err != nil {
+				t.Errorf("validation failed: %v", err)
+				fmt.Printf("❌ Test failed: %s - %v\n", tt.name, err)
+			} else {
+				fmt.Printf("✅ Test passed: %s\n", tt.name)
+			}
+		})
+	}
+}
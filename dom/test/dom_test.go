@@ -4,7 +4,9 @@
 package test
 
 import (
+	"errors"
 	"fmt"
+	"strings"
 	"testing"
 	"time"
 
@@ -28,6 +30,19 @@ func waitForDOM() error {
 	return nil
 }
 
+// missingAPI returns the first of the named globals that the DOM lacks, or ""
+// if it has them all. The DOM installed under Node.js only covers the node
+// tree and events, so tests of other APIs skip themselves there and run in
+// a browser.
+func missingAPI(names ...string) string {
+	for _, name := range names {
+		if js.Global().Get(name).IsUndefined() {
+			return name
+		}
+	}
+	return ""
+}
+
 func TestDOM(t *testing.T) {
 	fmt.Println("Starting DOM WebAssembly tests...")
 
@@ -498,6 +513,452 @@ func TestDOM(t *testing.T) {
 				return nil
 			},
 		},
+		{
+			name: "Event Listener Removal",
+			setup: func() error {
+				div := doc.CreateElement("div")
+				div.SetID("listener-test")
+				return testContainer.AppendChild(&dom.Node{Value: div.Value})
+			},
+			validate: func() error {
+				div := doc.GetElementByID("listener-test")
+				before := js.LiveCallbacks()
+
+				clicks := 0
+				listener := div.AddEventListener("click", func(e *dom.Event) {
+					clicks++
+				})
+				div.Click()
+				listener.Remove()
+				div.Click()
+				if clicks != 1 {
+					return fmt.Errorf("expected 1 click before removal, got %d", clicks)
+				}
+
+				onceClicks := 0
+				once := div.AddEventListenerWithOptions("click", func(e *dom.Event) {
+					onceClicks++
+				}, dom.AddEventListenerOptions{Once: true})
+				div.Click()
+				div.Click()
+				if onceClicks != 1 || !once.Removed() {
+					return fmt.Errorf("once listener should fire exactly once, got %d", onceClicks)
+				}
+
+				controller := dom.NewAbortController()
+				groupClicks := 0
+				for i := 0; i < 3; i++ {
+					div.AddEventListenerWithOptions("click", func(e *dom.Event) {
+						groupClicks++
+					}, dom.AddEventListenerOptions{Signal: controller.Signal()})
+				}
+				div.Click()
+				controller.Abort()
+				div.Click()
+				if groupClicks != 3 {
+					return fmt.Errorf("expected 3 group clicks before abort, got %d", groupClicks)
+				}
+
+				if leaked := js.LiveCallbacks() - before; leaked != 0 {
+					return fmt.Errorf("%d callbacks leaked", leaked)
+				}
+				return nil
+			},
+		},
+		{
+			name: "Typed Events",
+			setup: func() error {
+				div := doc.CreateElement("div")
+				div.SetID("typed-events")
+				return testContainer.AppendChild(&dom.Node{Value: div.Value})
+			},
+			validate: func() error {
+				div := doc.GetElementByID("typed-events")
+				dispatch := func(iface, eventType string, init map[string]interface{}) {
+					div.DispatchEvent(&dom.Event{Value: js.Global().Get(iface).New(eventType, js.MustMarshal(init))})
+				}
+
+				var key string
+				var ctrl, repeat bool
+				keyDown := div.OnKeyDown(func(e *dom.KeyboardEvent) {
+					key, ctrl, repeat = e.GetKey(), e.GetModifierState("Control"), e.GetRepeat()
+				})
+				defer keyDown.Remove()
+				dispatch("KeyboardEvent", "keydown", map[string]interface{}{"key": "Enter", "code": "Enter", "ctrlKey": true, "repeat": true})
+				if key != "Enter" || !ctrl || !repeat {
+					return fmt.Errorf("unexpected keyboard event: key=%q ctrl=%v repeat=%v", key, ctrl, repeat)
+				}
+
+				var data, inputType string
+				input := div.OnInput(func(e *dom.InputEvent) {
+					data, inputType = e.GetData(), e.GetInputType()
+				})
+				defer input.Remove()
+				dispatch("InputEvent", "input", map[string]interface{}{"data": "a", "inputType": "insertText"})
+				if data != "a" || inputType != "insertText" {
+					return fmt.Errorf("unexpected input event: data=%q inputType=%q", data, inputType)
+				}
+
+				var pointerID int
+				var pointerType string
+				var pressure float64
+				pointer := div.OnPointerDown(func(e *dom.PointerEvent) {
+					pointerID, pointerType, pressure = e.GetPointerID(), e.GetPointerType(), e.GetPressure()
+				})
+				defer pointer.Remove()
+				dispatch("PointerEvent", "pointerdown", map[string]interface{}{"pointerId": 7, "pointerType": "pen", "pressure": 0.5})
+				if pointerID != 7 || pointerType != "pen" || pressure != 0.5 {
+					return fmt.Errorf("unexpected pointer event: id=%d type=%q pressure=%v", pointerID, pointerType, pressure)
+				}
+
+				var deltaY float64
+				var deltaMode int
+				wheel := div.OnWheel(func(e *dom.WheelEvent) {
+					deltaY, deltaMode = e.GetDeltaY(), e.GetDeltaMode()
+				})
+				defer wheel.Remove()
+				dispatch("WheelEvent", "wheel", map[string]interface{}{"deltaY": 120, "deltaMode": dom.DeltaModeLine})
+				if deltaY != 120 || deltaMode != dom.DeltaModeLine {
+					return fmt.Errorf("unexpected wheel event: deltaY=%v deltaMode=%d", deltaY, deltaMode)
+				}
+
+				var related *dom.Element
+				focus := div.OnFocus(func(e *dom.FocusEvent) {
+					related = e.GetRelatedTarget()
+				})
+				defer focus.Remove()
+				dispatch("FocusEvent", "focus", map[string]interface{}{"relatedTarget": testContainer.Value})
+				if related == nil || related.GetID() != "test-container" {
+					return fmt.Errorf("focus event should report the related target")
+				}
+
+				var touches []*dom.Touch
+				touch := div.OnTouchStart(func(e *dom.TouchEvent) {
+					touches = e.GetChangedTouches()
+				})
+				defer touch.Remove()
+				point := js.Global().Get("Touch").New(js.MustMarshal(map[string]interface{}{"identifier": 3, "target": div.Value, "clientX": 10}))
+				dispatch("TouchEvent", "touchstart", map[string]interface{}{"changedTouches": []interface{}{point}})
+				if len(touches) != 1 || touches[0].GetIdentifier() != 3 || touches[0].GetClientX() != 10 {
+					return fmt.Errorf("unexpected changed touches: %d", len(touches))
+				}
+				if touches[0].GetTarget().GetID() != "typed-events" {
+					return fmt.Errorf("touch should report its target")
+				}
+				return nil
+			},
+		},
+		{
+			name: "Synthetic Events",
+			setup: func() error {
+				parent := doc.CreateElement("div")
+				parent.SetID("synthetic-parent")
+				child := doc.CreateElement("button")
+				child.SetID("synthetic-child")
+				if err := parent.AppendChild(&dom.Node{Value: child.Value}); err != nil {
+					return err
+				}
+				return testContainer.AppendChild(&dom.Node{Value: parent.Value})
+			},
+			validate: func() error {
+				parent := doc.GetElementByID("synthetic-parent")
+				child := doc.GetElementByID("synthetic-child")
+
+				type selection struct {
+					ID    int      `js:"id"`
+					Items []string `js:"items"`
+				}
+				var got selection
+				var decodeErr error
+				selected := parent.OnCustomEvent("item-selected", func(e *dom.CustomEvent) {
+					decodeErr = e.Detail(&got)
+				})
+				defer selected.Remove()
+				event, err := dom.NewCustomEventWithOptions("item-selected", selection{ID: 42, Items: []string{"a", "b"}}, dom.EventInit{Bubbles: true})
+				if err != nil {
+					return fmt.Errorf("failed to create custom event: %v", err)
+				}
+				child.DispatchEvent(&event.Event)
+				if decodeErr != nil {
+					return fmt.Errorf("failed to decode detail: %v", decodeErr)
+				}
+				if got.ID != 42 || len(got.Items) != 2 || got.Items[1] != "b" {
+					return fmt.Errorf("unexpected detail: %+v", got)
+				}
+
+				var clientX float64
+				var shift bool
+				click := child.OnClick(func(e *dom.MouseEvent) {
+					clientX, shift = e.GetClientX(), e.GetShiftKey()
+					e.PreventDefault()
+				})
+				defer click.Remove()
+				mouse := dom.NewMouseEvent("click", dom.MouseEventInit{
+					EventInit: dom.EventInit{Bubbles: true, Cancelable: true},
+					ClientX:   12,
+					ShiftKey:  true,
+				})
+				if child.DispatchEvent(&mouse.Event) {
+					return fmt.Errorf("DispatchEvent should return false when the default is prevented")
+				}
+				if clientX != 12 || !shift {
+					return fmt.Errorf("unexpected mouse event: clientX=%v shift=%v", clientX, shift)
+				}
+
+				var code string
+				keyUp := child.OnKeyUp(func(e *dom.KeyboardEvent) {
+					code = e.GetCode()
+				})
+				defer keyUp.Remove()
+				child.DispatchEvent(&dom.NewKeyboardEvent("keyup", dom.KeyboardEventInit{Key: "a", Code: "KeyA"}).Event)
+				if code != "KeyA" {
+					return fmt.Errorf("unexpected key code: %q", code)
+				}
+
+				plain := dom.NewEvent("ping", dom.EventInit{})
+				if plain.GetBubbles() || plain.GetCancelable() || plain.GetType() != "ping" {
+					return fmt.Errorf("unexpected event init")
+				}
+				if _, err := dom.NewCustomEvent("bad", func() {}); err == nil {
+					return fmt.Errorf("a detail that cannot be marshalled should fail")
+				}
+				return nil
+			},
+		},
+		{
+			name: "Event Delegation",
+			setup: func() error {
+				list := doc.CreateElement("ul")
+				list.SetID("delegation-list")
+				list.SetInnerHTML(`<li class="item" id="outer-item"><ul><li class="item" id="inner-item"><span id="item-label">x</span></li></ul></li>`)
+				return testContainer.AppendChild(&dom.Node{Value: list.Value})
+			},
+			validate: func() error {
+				list := doc.GetElementByID("delegation-list")
+				label := doc.GetElementByID("item-label")
+				before := js.LiveCallbacks()
+
+				var matched []string
+				listener, err := list.On("click", ".item", func(e *dom.DelegatedEvent) {
+					matched = append(matched, e.DelegateTarget.GetID())
+				})
+				if err != nil {
+					return fmt.Errorf("failed to delegate: %v", err)
+				}
+				label.Click()
+				if len(matched) != 2 || matched[0] != "inner-item" || matched[1] != "outer-item" {
+					return fmt.Errorf("unexpected delegate targets: %v", matched)
+				}
+
+				matched = nil
+				stopper, err := doc.On("click", "#inner-item", func(e *dom.DelegatedEvent) {
+					matched = append(matched, "document")
+				})
+				if err != nil {
+					return fmt.Errorf("failed to delegate on the document: %v", err)
+				}
+				listener.Remove()
+				listener, _ = list.On("click", ".item", func(e *dom.DelegatedEvent) {
+					matched = append(matched, e.DelegateTarget.GetID())
+					e.StopPropagation()
+				})
+				label.Click()
+				if len(matched) != 1 || matched[0] != "inner-item" {
+					return fmt.Errorf("StopPropagation should end delegation, got %v", matched)
+				}
+
+				matched = nil
+				listener.Remove()
+				label.Click()
+				if len(matched) != 1 || matched[0] != "document" {
+					return fmt.Errorf("document delegation should match, got %v", matched)
+				}
+				stopper.Remove()
+
+				matched = nil
+				list.Click()
+				if len(matched) != 0 {
+					return fmt.Errorf("events outside the selector should be ignored")
+				}
+				if _, err := list.On("click", "[[invalid", func(e *dom.DelegatedEvent) {}); err == nil {
+					return fmt.Errorf("an invalid selector should return an error")
+				}
+				if leaked := js.LiveCallbacks() - before; leaked != 0 {
+					return fmt.Errorf("%d callbacks leaked", leaked)
+				}
+				return nil
+			},
+		},
+		{
+			name: "Class List and Dataset",
+			setup: func() error {
+				div := doc.CreateElement("div")
+				div.SetID("class-list")
+				div.SetClassName("box other")
+				return testContainer.AppendChild(&dom.Node{Value: div.Value})
+			},
+			validate: func() error {
+				div := doc.GetElementByID("class-list")
+				classes := div.ClassList()
+				if err := classes.Add("highlight", "box"); err != nil {
+					return fmt.Errorf("failed to add classes: %v", err)
+				}
+				if on, _ := classes.Toggle("highlight"); on || classes.Contains("highlight") {
+					return fmt.Errorf("toggle should remove a present class")
+				}
+				if on, _ := classes.ToggleForce("active", true); !on {
+					return fmt.Errorf("forced toggle should add the class")
+				}
+				if replaced, _ := classes.Replace("other", "second"); !replaced {
+					return fmt.Errorf("replace should report the replaced class")
+				}
+				if got := strings.Join(classes.Values(), ","); got != "box,second,active" || classes.Length() != 3 || classes.Item(1) != "second" {
+					return fmt.Errorf("unexpected classes: %s", got)
+				}
+				if err := classes.Add("two words"); err == nil {
+					return fmt.Errorf("adding a token with whitespace should fail")
+				}
+
+				data := div.Dataset()
+				data.Set("userName", "ada")
+				data.SetInt("count", 3)
+				data.SetBool("open", true)
+				if err := data.SetJSON("config", map[string]int{"size": 2}); err != nil {
+					return fmt.Errorf("failed to set JSON: %v", err)
+				}
+				if div.GetAttribute("data-user-name") != "ada" {
+					return fmt.Errorf("dataset names should map to data-* attributes")
+				}
+				if count, err := data.GetInt("count"); err != nil || count != 3 {
+					return fmt.Errorf("unexpected count: %d, %v", count, err)
+				}
+				if open, err := data.GetBool("open"); err != nil || !open {
+					return fmt.Errorf("unexpected open: %v, %v", open, err)
+				}
+				var config struct{ Size int }
+				if err := data.GetJSON("config", &config); err != nil || config.Size != 2 {
+					return fmt.Errorf("unexpected config: %+v, %v", config, err)
+				}
+				if got := strings.Join(data.Keys(), ","); got != "userName,count,open,config" {
+					return fmt.Errorf("unexpected keys: %s", got)
+				}
+				data.Delete("count")
+				if _, ok := data.Get("count"); ok || data.Has("count") {
+					return fmt.Errorf("deleted key should be missing")
+				}
+				if _, err := data.GetInt("count"); !errors.Is(err, dom.ErrNoData) {
+					return fmt.Errorf("missing int should wrap ErrNoData: %v", err)
+				}
+				if _, err := data.GetBool("missing"); !errors.Is(err, dom.ErrNoData) {
+					return fmt.Errorf("missing bool should wrap ErrNoData: %v", err)
+				}
+				data.Set("size", "big")
+				if _, err := data.GetInt("size"); err == nil || errors.Is(err, dom.ErrNoData) {
+					return fmt.Errorf("invalid int should fail to parse: %v", err)
+				}
+				return nil
+			},
+		},
+		{
+			name: "Namespaced Attributes",
+			setup: func() error {
+				svg, err := doc.CreateElementNS(dom.SVGNamespace, "svg")
+				if err != nil {
+					return fmt.Errorf("failed to create svg: %v", err)
+				}
+				svg.SetID("namespaced")
+				return testContainer.AppendChild(&dom.Node{Value: svg.Value})
+			},
+			validate: func() error {
+				svg := doc.GetElementByID("namespaced")
+				if svg.GetNamespaceURI() != dom.SVGNamespace || svg.GetTagName() != "svg" {
+					return fmt.Errorf("unexpected svg element: %s in %s", svg.GetTagName(), svg.GetNamespaceURI())
+				}
+				if err := svg.SetAttributes(map[string]string{"viewBox": "0 0 10 10", "width": "10"}); err != nil {
+					return fmt.Errorf("failed to set attributes: %v", err)
+				}
+				if svg.GetAttribute("viewBox") != "0 0 10 10" {
+					return fmt.Errorf("svg attribute names should keep their case")
+				}
+				if err := svg.SetAttributeNS(dom.XLinkNamespace, "xlink:href", "#shape"); err != nil {
+					return fmt.Errorf("failed to set namespaced attribute: %v", err)
+				}
+				if svg.GetAttributeNS(dom.XLinkNamespace, "href") != "#shape" || !svg.HasAttributeNS(dom.XLinkNamespace, "href") {
+					return fmt.Errorf("namespaced attribute should be found by local name")
+				}
+				if err := svg.SetAttributeNS("", "xlink:href", "#shape"); err == nil {
+					return fmt.Errorf("a prefix without a namespace should fail")
+				}
+				if got := strings.Join(svg.GetAttributeNames(), ","); got != "id,viewBox,width,xlink:href" {
+					return fmt.Errorf("unexpected attribute names: %s", got)
+				}
+				last := svg.Attributes()[3]
+				if last.LocalName != "href" || last.Prefix != "xlink" || last.NamespaceURI != dom.XLinkNamespace || last.Value != "#shape" {
+					return fmt.Errorf("unexpected attribute: %+v", last)
+				}
+				attributes := svg.Value.Get("attributes")
+				if js.Global().Get("Array").Call("isArray", attributes).MustBool() {
+					return fmt.Errorf("attributes should be a NamedNodeMap, not an array")
+				}
+				if named := attributes.Call("getNamedItem", "viewBox"); named.IsNull() || named.Get("value").MustString() != "0 0 10 10" {
+					return fmt.Errorf("getNamedItem should find the attribute")
+				}
+				svg.RemoveAttributeNS(dom.XLinkNamespace, "href")
+				if svg.HasAttributeNS(dom.XLinkNamespace, "href") {
+					return fmt.Errorf("namespaced attribute should be removed")
+				}
+
+				if on, err := svg.ToggleAttribute("hidden", true); err != nil || !on || !svg.HasAttribute("hidden") {
+					return fmt.Errorf("forced toggle should add the attribute")
+				}
+				if on, _ := svg.ToggleAttribute("hidden", false); on || svg.HasAttribute("hidden") {
+					return fmt.Errorf("toggle should remove the attribute")
+				}
+				if _, err := doc.CreateElementNS("", "svg:rect"); err == nil {
+					return fmt.Errorf("creating a prefixed element without a namespace should fail")
+				}
+				return nil
+			},
+		},
+		{
+			name: "Missing Lookups Return Nil",
+			setup: func() error {
+				return nil
+			},
+			validate: func() error {
+				if doc.GetElementByID("does-not-exist") != nil {
+					return fmt.Errorf("GetElementByID should return nil for a missing element")
+				}
+				el, err := doc.QuerySelector("#does-not-exist")
+				if err != nil {
+					return fmt.Errorf("unexpected querySelector error: %v", err)
+				}
+				if el != nil {
+					return fmt.Errorf("QuerySelector should return nil when nothing matches")
+				}
+				if _, err := doc.QuerySelector("[[invalid"); err == nil {
+					return fmt.Errorf("QuerySelector should return an error for an invalid selector")
+				}
+
+				detached := doc.CreateElement("div")
+				if detached.GetParentNode() != nil {
+					return fmt.Errorf("detached element should have no parent")
+				}
+				if detached.GetFirstChild() != nil || detached.GetLastChild() != nil {
+					return fmt.Errorf("empty element should have no children")
+				}
+				if detached.GetNextSibling() != nil || detached.GetPreviousSibling() != nil {
+					return fmt.Errorf("detached element should have no siblings")
+				}
+				if detached.GetAttribute("missing") != "" {
+					return fmt.Errorf("missing attribute should be an empty string")
+				}
+				if detached.GetStyle().GetParentRule() != nil {
+					return fmt.Errorf("inline style should have no parent rule")
+				}
+				return nil
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,435 @@
+//go:build js && wasm
+// +build js,wasm
+
+package test
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/abdorrahmani/go-wasm/dom"
+	"github.com/abdorrahmani/go-wasm/dom/form"
+	"github.com/abdorrahmani/go-wasm/js"
+)
+
+type address struct {
+	City string `form:"city"`
+}
+
+// Contact is embedded by pointer
+type Contact struct {
+	City string `form:"city"`
+}
+
+type profile struct {
+	address
+	Name     string    `form:"name"`
+	Age      int       `form:"age"`
+	Score    float64   `form:"score"`
+	Terms    bool      `form:"terms"`
+	Plan     string    `form:"plan"`
+	Tags     []string  `form:"tags"`
+	Colors   []string  `form:"colors"`
+	Born     time.Time `form:"born"`
+	Avatar   *dom.File `form:"avatar"`
+	Internal string    `form:"-"`
+}
+
+func TestFormControls(t *testing.T) {
+	if name := missingAPI("HTMLFormElement"); name != "" {
+		t.Skipf("%s is not available", name)
+	}
+	fmt.Println("Starting form control tests...")
+
+	doc := dom.Global()
+	testContainer := doc.CreateElement("div")
+	if err := doc.GetBody().AppendChild(&dom.Node{Value: testContainer.Value}); err != nil {
+		t.Fatalf("Failed to append test container: %v", err)
+	}
+	defer doc.GetBody().RemoveChild(&dom.Node{Value: testContainer.Value})
+
+	tests := []struct {
+		name     string
+		setup    func() error
+		validate func() error
+	}{
+		{
+			name: "Form Controls",
+			setup: func() error {
+				form := doc.CreateElement("form")
+				form.SetID("signup")
+				form.SetInnerHTML(`<input name="user" value="gopher"><input type="checkbox" name="terms">` +
+					`<input type="radio" name="plan" value="free" checked><input type="radio" name="plan" value="pro">` +
+					`<input type="number" name="age"><input type="date" name="born">` +
+					`<select name="color"><option>red</option><option value="g">green</option></select>` +
+					`<textarea name="bio">hello</textarea><button>Send</button>`)
+				return testContainer.AppendChild(&dom.Node{Value: form.Value})
+			},
+			validate: func() error {
+				form := doc.GetElementByID("signup").AsForm()
+				if form == nil || doc.GetElementByID("signup").AsInput() != nil {
+					return fmt.Errorf("unexpected element wrappers")
+				}
+				controls := form.Elements()
+				if len(controls) != 9 || controls[6].GetName() != "color" {
+					return fmt.Errorf("unexpected form controls: %d", len(controls))
+				}
+				user := controls[0].Element.AsInput()
+				if user.GetType() != "text" || user.GetValue() != "gopher" {
+					return fmt.Errorf("unexpected text input: %s %q", user.GetType(), user.GetValue())
+				}
+				if err := user.SetValue("rob"); err != nil || user.GetValue() != "rob" || user.GetDefaultValue() != "gopher" {
+					return fmt.Errorf("unexpected value after SetValue: %q, %v", user.GetValue(), err)
+				}
+				if err := user.SetSelectionRange(1, 2); err != nil {
+					return fmt.Errorf("failed to select text: %v", err)
+				}
+				if start, _ := user.GetSelectionStart(); start != 1 {
+					return fmt.Errorf("unexpected selection start: %d", start)
+				}
+				terms := controls[1].Element.AsInput()
+				if _, ok := terms.GetSelectionEnd(); ok || terms.SetSelectionRange(0, 0) == nil {
+					return fmt.Errorf("checkboxes should not support selection")
+				}
+				terms.Click()
+				if !terms.GetChecked() {
+					return fmt.Errorf("clicking a checkbox should check it")
+				}
+				free, pro := controls[2].Element.AsInput(), controls[3].Element.AsInput()
+				pro.SetChecked(true)
+				if free.GetChecked() || !pro.GetChecked() {
+					return fmt.Errorf("checking a radio button should uncheck its group")
+				}
+				age := controls[4].Element.AsInput()
+				if err := age.SetValueAsNumber(42); err != nil || age.GetValue() != "42" || age.GetValueAsNumber() != 42 {
+					return fmt.Errorf("unexpected number value: %q, %v", age.GetValue(), err)
+				}
+				if err := user.SetValueAsNumber(1); err == nil {
+					return fmt.Errorf("text inputs should not accept numbers")
+				}
+				born := controls[5].Element.AsInput()
+				if err := born.SetValueAsDate(time.Date(2009, time.November, 10, 0, 0, 0, 0, time.UTC)); err != nil || born.GetValue() != "2009-11-10" {
+					return fmt.Errorf("unexpected date value: %q, %v", born.GetValue(), err)
+				}
+				if date, ok := born.GetValueAsDate(); !ok || date.Year() != 2009 || date.Day() != 10 {
+					return fmt.Errorf("unexpected date: %v, %v", date, ok)
+				}
+				if user.GetFiles() != nil {
+					return fmt.Errorf("text inputs should have no files")
+				}
+				color := controls[6].Element.AsSelect()
+				if color.GetValue() != "red" || color.GetSelectedIndex() != 0 {
+					return fmt.Errorf("the first option should be selected by default")
+				}
+				color.SetValue("g")
+				selected := color.GetSelectedOptions()
+				if len(selected) != 1 || selected[0].GetText() != "green" || selected[0].GetIndex() != 1 || color.GetOptions()[0].GetSelected() {
+					return fmt.Errorf("unexpected selection after SetValue")
+				}
+				bio := controls[7].Element.AsTextArea()
+				if bio.GetValue() != "hello" {
+					return fmt.Errorf("unexpected textarea value: %q", bio.GetValue())
+				}
+				_ = bio.SetValue("bye")
+				form.Reset()
+				if user.GetValue() != "gopher" || bio.GetValue() != "hello" || terms.GetChecked() || !free.GetChecked() || color.GetValue() != "red" {
+					return fmt.Errorf("reset should restore the default values")
+				}
+				return nil
+			},
+		},
+		{
+			name: "Form Validation",
+			setup: func() error {
+				form := doc.CreateElement("form")
+				form.SetID("validated")
+				form.SetInnerHTML(`<input name="email" type="email" required><input type="number" min="1" max="5" value="3"><button id="submit-button">Send</button>`)
+				return testContainer.AppendChild(&dom.Node{Value: form.Value})
+			},
+			validate: func() error {
+				form := doc.GetElementByID("validated").AsForm()
+				email := form.Elements()[0].Element.AsInput()
+				submits := 0
+				var submitter *js.Value
+				listener := form.AddEventListener("submit", func(e *dom.Event) {
+					submits++
+					submitter = e.Value.Get("submitter")
+					e.PreventDefault()
+				})
+				defer listener.Remove()
+				if form.CheckValidity() || !email.GetValidity().ValueMissing {
+					return fmt.Errorf("a missing required value should be invalid")
+				}
+				_ = email.SetValue("not an email")
+				if validity := email.GetValidity(); !validity.TypeMismatch || validity.Valid || email.GetValidationMessage() == "" {
+					return fmt.Errorf("unexpected validity: %+v", validity)
+				}
+				if err := form.RequestSubmit(nil); err != nil || submits != 0 {
+					return fmt.Errorf("an invalid form should not be submitted: %v", err)
+				}
+				_ = email.SetValue("gopher@example.com")
+				number := form.Elements()[1].Element.AsInput()
+				_ = number.SetValue("9")
+				if !number.GetValidity().RangeOverflow || number.ReportValidity() {
+					return fmt.Errorf("a value above max should overflow")
+				}
+				_ = number.SetValue("2.5")
+				if validity := number.GetValidity(); !validity.StepMismatch || validity.RangeOverflow || validity.Valid {
+					return fmt.Errorf("a value off the step should mismatch: %+v", validity)
+				}
+				_ = number.SetValue("2")
+				email.SetCustomValidity("taken")
+				if email.CheckValidity() || !email.GetValidity().CustomError || email.GetValidationMessage() != "taken" {
+					return fmt.Errorf("a custom validity message should make the control invalid")
+				}
+				email.SetCustomValidity("")
+				doc.GetElementByID("submit-button").Click()
+				if submits != 1 || submitter == nil || submitter.Get("id").MustString() != "submit-button" {
+					return fmt.Errorf("clicking the submit button should submit the form")
+				}
+				if err := form.RequestSubmit(doc.GetBody()); err == nil {
+					return fmt.Errorf("a submitter outside the form should fail")
+				}
+				return nil
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fmt.Printf("Running test: %s\n", tt.name)
+			if err := tt.setup(); err != nil {
+				t.Errorf("setup failed: %v", err)
+				fmt.Printf("❌ Test setup failed: %s - %v\n", tt.name, err)
+				return
+			}
+			if err := tt.validate(); err != nil {
+				t.Errorf("validation failed: %v", err)
+				fmt.Printf("❌ Test failed: %s - %v\n", tt.name, err)
+			} else {
+				fmt.Printf("✅ Test passed: %s\n", tt.name)
+			}
+		})
+	}
+}
+
+func TestForm(t *testing.T) {
+	if name := missingAPI("HTMLFormElement"); name != "" {
+		t.Skipf("%s is not available", name)
+	}
+	fmt.Println("Starting form tests...")
+
+	doc := dom.Global()
+	newForm := func() *dom.FormElement {
+		f := doc.CreateElement("form")
+		f.SetInnerHTML(`<input name="name" value="Gopher" required><input type="number" name="age" value="13">` +
+			`<input name="score" value="9.5"><input type="checkbox" name="terms" checked><input name="city" value="Tehran">` +
+			`<input type="radio" name="plan" value="free"><input type="radio" name="plan" value="pro" checked>` +
+			`<input type="checkbox" name="tags" value="go" checked><input type="checkbox" name="tags" value="js">` +
+			`<input type="checkbox" name="tags" value="wasm" checked>` +
+			`<select name="colors" multiple><option selected>red</option><option>green</option><option selected>blue</option></select>` +
+			`<input type="date" name="born" value="2009-11-10"><input type="file" name="avatar">` +
+			`<input name="Internal" value="secret"><input name="disabled" value="x" disabled>`)
+		if err := doc.GetBody().AppendChild(&dom.Node{Value: f.Value}); err != nil {
+			t.Fatalf("Failed to append form: %v", err)
+		}
+		return f.AsForm()
+	}
+	remove := func(f *dom.FormElement) {
+		doc.GetBody().RemoveChild(&dom.Node{Value: f.Value})
+	}
+	control := func(f *dom.FormElement, name string) *js.Value {
+		return f.Value.Call("querySelector", fmt.Sprintf("[name=%q]", name))
+	}
+	fire := func(f *dom.FormElement, name, eventType string) {
+		target := &dom.Element{Value: control(f, name)}
+		target.DispatchEvent(dom.NewEvent(eventType, dom.EventInit{Bubbles: true}))
+	}
+
+	tests := []struct {
+		name     string
+		validate func() error
+	}{
+		{
+			name: "Decode Controls Into A Struct",
+			validate: func() error {
+				f := newForm()
+				defer remove(f)
+				var p profile
+				if err := form.Decode(&f.Element, &p); err != nil {
+					return fmt.Errorf("failed to decode: %v", err)
+				}
+				want := profile{
+					address: address{City: "Tehran"},
+					Name:    "Gopher", Age: 13, Score: 9.5, Terms: true, Plan: "pro",
+					Tags: []string{"go", "wasm"}, Colors: []string{"red", "blue"},
+					Born: time.Date(2009, time.November, 10, 0, 0, 0, 0, time.UTC),
+				}
+				if !reflect.DeepEqual(p, want) {
+					return fmt.Errorf("unexpected struct: %+v", p)
+				}
+				return nil
+			},
+		},
+		{
+			name: "Decode Reports Invalid Numbers",
+			validate: func() error {
+				f := newForm()
+				defer remove(f)
+				control(f, "score").Set("value", "high")
+				p := profile{Score: 1}
+				err := form.Decode(&f.Element, &p)
+				var errs form.Errors
+				if !errors.As(err, &errs) || len(errs) != 1 || errs[0].Field != "Score" || errs[0].Name != "score" {
+					return fmt.Errorf("unexpected error: %v", err)
+				}
+				if p.Score != 1 || p.Name != "Gopher" {
+					return fmt.Errorf("the invalid field should be unchanged and the others decoded: %+v", p)
+				}
+				if err := form.Decode(&f.Element, p); err == nil {
+					return fmt.Errorf("decoding into a non-pointer should fail")
+				}
+				return nil
+			},
+		},
+		{
+			name: "Populate Controls From A Struct",
+			validate: func() error {
+				f := newForm()
+				defer remove(f)
+				p := profile{
+					Name: "Rob", Age: 70, Plan: "free", Tags: []string{"js"}, Colors: []string{"green"},
+					Born: time.Date(1956, time.February, 1, 0, 0, 0, 0, time.UTC),
+				}
+				if err := form.Populate(&f.Element, &p); err != nil {
+					return fmt.Errorf("failed to populate: %v", err)
+				}
+				var got profile
+				if err := form.Decode(&f.Element, &got); err != nil {
+					return fmt.Errorf("failed to decode: %v", err)
+				}
+				if !reflect.DeepEqual(got, p) {
+					return fmt.Errorf("populated form decodes to %+v", got)
+				}
+				if control(f, "Internal").Get("value").MustString() != "secret" {
+					return fmt.Errorf("ignored fields should not be populated")
+				}
+				return nil
+			},
+		},
+		{
+			name: "Embedded Pointers And Duplicate Names",
+			validate: func() error {
+				f := newForm()
+				defer remove(f)
+				type person struct {
+					*Contact
+					Name string `form:"name"`
+				}
+				var p person
+				if err := form.Decode(&f.Element, &p); err != nil {
+					return fmt.Errorf("failed to decode: %v", err)
+				}
+				if p.Contact == nil || p.City != "Tehran" || p.Name != "Gopher" {
+					return fmt.Errorf("the embedded pointer should be set to a new struct: %+v", p)
+				}
+				if err := form.Populate(&f.Element, &person{Name: "Rob"}); err != nil {
+					return fmt.Errorf("failed to populate from a nil embedded pointer: %v", err)
+				}
+				if got := control(f, "city").Get("value").MustString(); got != "" {
+					return fmt.Errorf("the fields of a nil embedded pointer should be empty, got %q", got)
+				}
+
+				type hidden struct {
+					*address
+				}
+				if err := form.Decode(&f.Element, &hidden{}); err == nil {
+					return fmt.Errorf("an embedded pointer to an unexported struct should fail")
+				}
+				type duplicate struct {
+					address
+					Town string `form:"city"`
+				}
+				if err := form.Decode(&f.Element, &duplicate{}); err == nil {
+					return fmt.Errorf("decoding fields with the same name should fail")
+				}
+				if _, err := form.Bind(&f.Element, &duplicate{}); err == nil {
+					return fmt.Errorf("binding fields with the same name should fail")
+				}
+				return nil
+			},
+		},
+		{
+			name: "Binding Updates The Struct",
+			validate: func() error {
+				f := newForm()
+				defer remove(f)
+				p := profile{Name: "Ken", Age: 80}
+				binding, err := form.Bind(&f.Element, &p)
+				if err != nil {
+					return fmt.Errorf("failed to bind: %v", err)
+				}
+				defer binding.Unbind()
+				if got := control(f, "name").Get("value").MustString(); got != "Ken" {
+					return fmt.Errorf("binding should populate the form, got %q", got)
+				}
+
+				var changed []string
+				binding.OnChange(func(name string, err error) {
+					changed = append(changed, name)
+				})
+				control(f, "age").Set("value", "81")
+				fire(f, "age", "input")
+				if p.Age != 81 || len(changed) != 1 || changed[0] != "age" {
+					return fmt.Errorf("input events should update the struct: %d, %v", p.Age, changed)
+				}
+
+				control(f, "name").Set("value", "")
+				fire(f, "name", "change")
+				var validation *form.ValidationError
+				if err := binding.Err("name"); !errors.As(err, &validation) || !validation.Validity.ValueMissing {
+					return fmt.Errorf("a missing required value should be reported: %v", err)
+				}
+				if errs := binding.Errors(); len(errs) != 1 || errs[0].Field != "Name" {
+					return fmt.Errorf("unexpected errors: %v", errs)
+				}
+				control(f, "name").Set("value", "Ken")
+				fire(f, "name", "input")
+				if binding.Err("name") != nil || binding.Validate() != nil {
+					return fmt.Errorf("the error should clear once the field is valid")
+				}
+
+				p.Score = 2.5
+				if err := binding.Sync(); err != nil {
+					return fmt.Errorf("failed to sync: %v", err)
+				}
+				if got := control(f, "score").Get("value").MustString(); got != "2.5" {
+					return fmt.Errorf("sync should populate the form, got %q", got)
+				}
+
+				binding.Unbind()
+				control(f, "age").Set("value", "1")
+				fire(f, "age", "input")
+				if p.Age != 81 {
+					return fmt.Errorf("an unbound struct should not change")
+				}
+				return nil
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fmt.Printf("Running test: %s\n", tt.name)
+			if err := tt.validate(); err != nil {
+				t.Errorf("validation failed: %v", err)
+				fmt.Printf("❌ Test failed: %s - %v\n", tt.name, err)
+			} else {
+				fmt.Printf("✅ Test passed: %s\n", tt.name)
+			}
+		})
+	}
+}
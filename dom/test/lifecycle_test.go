@@ -0,0 +1,202 @@
+//go:build js && wasm
+// +build js,wasm
+
+package test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/abdorrahmani/go-wasm/dom"
+	"github.com/abdorrahmani/go-wasm/js"
+)
+
+// closed reports whether ch is closed without blocking
+func closed(ch <-chan struct{}) bool {
+	select {
+	case <-ch:
+		return true
+	default:
+		return false
+	}
+}
+
+func TestLifecycle(t *testing.T) {
+	fmt.Println("Starting lifecycle tests...")
+
+	doc := dom.Global()
+	w := dom.GetWindow()
+	live := js.LiveCallbacks()
+
+	tests := []struct {
+		name     string
+		validate func() error
+	}{
+		{
+			name: "Ready After Loading",
+			validate: func() error {
+				if !closed(doc.Ready()) || !closed(w.Loaded()) {
+					return fmt.Errorf("a complete document should already be ready and loaded")
+				}
+				if err := doc.WhenReady(context.Background()); err != nil {
+					return fmt.Errorf("unexpected error: %v", err)
+				}
+				return nil
+			},
+		},
+		{
+			name: "Ready While Loading",
+			validate: func() error {
+				setReadyState(dom.ReadyStateLoading)
+				defer setReadyState(dom.ReadyStateComplete)
+
+				var events []string
+				states := doc.OnReadyStateChange(func(state string) {
+					events = append(events, state)
+				})
+				defer states.Remove()
+				parsed := doc.AddEventListener("DOMContentLoaded", func(*dom.Event) {
+					events = append(events, "DOMContentLoaded")
+				})
+				defer parsed.Remove()
+				load := w.AddEventListener("load", func(*dom.Event) {
+					events = append(events, "load")
+				})
+				defer load.Remove()
+
+				ready, loaded := doc.Ready(), w.Loaded()
+				if closed(ready) || closed(loaded) || doc.IsReady() {
+					return fmt.Errorf("a loading document should not be ready")
+				}
+				if doc.Ready() != ready || w.Loaded() != loaded {
+					return fmt.Errorf("waiting again should share the pending channels")
+				}
+				setReadyState(dom.ReadyStateInteractive)
+				if !closed(ready) || closed(loaded) {
+					return fmt.Errorf("a parsed document should be ready but not loaded")
+				}
+				setReadyState(dom.ReadyStateComplete)
+				if !closed(loaded) || !doc.IsReady() {
+					return fmt.Errorf("the page should be loaded")
+				}
+				want := "[interactive DOMContentLoaded complete load]"
+				if got := fmt.Sprint(events); got != want {
+					return fmt.Errorf("expected events %s, got %s", want, got)
+				}
+				return nil
+			},
+		},
+		{
+			name: "Canceled Wait",
+			validate: func() error {
+				setReadyState(dom.ReadyStateLoading)
+				defer setReadyState(dom.ReadyStateComplete)
+
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+				if err := doc.WhenReady(ctx); err != context.Canceled {
+					return fmt.Errorf("expected context.Canceled, got %v", err)
+				}
+				if err := w.WhenLoaded(ctx); err != context.Canceled {
+					return fmt.Errorf("expected context.Canceled, got %v", err)
+				}
+				if n := js.LiveCallbacks(); n != live {
+					return fmt.Errorf("abandoned waits should release their listeners, %d live callbacks", n-live)
+				}
+				return nil
+			},
+		},
+		{
+			name: "Visibility Changes",
+			validate: func() error {
+				if doc.GetVisibilityState() != dom.VisibilityVisible || doc.IsHidden() {
+					return fmt.Errorf("the page should start out visible")
+				}
+				var states []dom.VisibilityState
+				listener := doc.OnVisibilityChange(func(state dom.VisibilityState) {
+					states = append(states, state)
+				})
+				defer listener.Remove()
+
+				setVisibilityState("hidden")
+				if !doc.IsHidden() {
+					return fmt.Errorf("the page should be hidden")
+				}
+				setVisibilityState("visible")
+				if len(states) != 2 || states[0] != dom.VisibilityHidden || states[1] != dom.VisibilityVisible {
+					return fmt.Errorf("unexpected states: %v", states)
+				}
+				return nil
+			},
+		},
+		{
+			name: "Page Show And Hide",
+			validate: func() error {
+				var events []string
+				show := w.OnPageShow(func(e *dom.PageTransitionEvent) {
+					events = append(events, fmt.Sprintf("%s %v", e.GetType(), e.GetPersisted()))
+				})
+				defer show.Remove()
+				hide := w.OnPageHide(func(e *dom.PageTransitionEvent) {
+					events = append(events, fmt.Sprintf("%s %v", e.GetType(), e.GetPersisted()))
+				})
+				defer hide.Remove()
+
+				for _, eventType := range []string{"pagehide", "pageshow"} {
+					init := js.Global().Call("Object")
+					init.Set("persisted", true)
+					event := js.Global().Get("PageTransitionEvent").New(eventType, init)
+					w.DispatchEvent(&dom.Event{Value: event})
+				}
+				want := "[pagehide true pageshow true]"
+				if got := fmt.Sprint(events); got != want {
+					return fmt.Errorf("expected events %s, got %s", want, got)
+				}
+				return nil
+			},
+		},
+		{
+			name: "Before Unload Prompt",
+			validate: func() error {
+				dirty := true
+				listener := w.OnBeforeUnload(func(e *dom.BeforeUnloadEvent) {
+					if dirty {
+						e.Prompt()
+					}
+				})
+				defer listener.Remove()
+
+				if w.DispatchEvent(dom.NewEvent("beforeunload", dom.EventInit{Cancelable: true})) {
+					return fmt.Errorf("unsaved changes should ask for confirmation")
+				}
+				dirty = false
+				if !w.DispatchEvent(dom.NewEvent("beforeunload", dom.EventInit{Cancelable: true})) {
+					return fmt.Errorf("a clean page should unload without asking")
+				}
+				return nil
+			},
+		},
+		{
+			name: "Callbacks Are Released",
+			validate: func() error {
+				if n := js.LiveCallbacks(); n != live {
+					return fmt.Errorf("expected %d live callbacks, got %d", live, n)
+				}
+				return nil
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fmt.Printf("Running test: %s\n", tt.name)
+			if err := tt.validate(); err != nil {
+				t.Errorf("validation failed: %v", err)
+				fmt.Printf("❌ Test failed: %s - %v\n", tt.name, err)
+			} else {
+				fmt.Printf("✅ Test passed: %s\n", tt.name)
+			}
+		})
+	}
+}
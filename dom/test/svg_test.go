@@ -0,0 +1,178 @@
+//go:build js && wasm
+// +build js,wasm
+
+package test
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/abdorrahmani/go-wasm/dom"
+	"github.com/abdorrahmani/go-wasm/dom/svg"
+)
+
+// approx reports whether a and b are equal within the error of flattened curves
+func approx(a, b float64) bool {
+	return math.Abs(a-b) <= 0.01*math.Max(1, math.Abs(b))
+}
+
+func TestSVG(t *testing.T) {
+	fmt.Println("Starting SVG tests...")
+
+	doc := dom.Global()
+	drawing := svg.NewSVG(doc, 100, 50)
+	if err := doc.GetBody().AppendChild(&dom.Node{Value: drawing.Value}); err != nil {
+		t.Fatalf("Failed to append drawing: %v", err)
+	}
+	defer doc.GetBody().RemoveChild(&dom.Node{Value: drawing.Value})
+
+	// The DOM installed under Node.js does not compute geometry
+	geometry := !svg.NewRect(doc, 0, 0, 1, 1).Value.Get("getBBox").IsUndefined()
+
+	tests := []struct {
+		name string
+		// geometry is set for tests that need lengths and bounding boxes
+		geometry bool
+		validate func() error
+	}{
+		{
+			name: "Elements Use The SVG Namespace",
+			validate: func() error {
+				circle := svg.NewCircle(doc, 5, 5, 5)
+				if err := drawing.Append(circle); err != nil {
+					return fmt.Errorf("failed to append circle: %v", err)
+				}
+				if circle.GetNamespaceURI() != dom.SVGNamespace || circle.GetTagName() != "circle" {
+					return fmt.Errorf("unexpected circle: %s in %s", circle.GetTagName(), circle.GetNamespaceURI())
+				}
+				if circle.GetCenter() != (svg.Point{X: 5, Y: 5}) || circle.GetRadius() != 5 {
+					return fmt.Errorf("unexpected circle geometry: %v, %v", circle.GetCenter(), circle.GetRadius())
+				}
+				return nil
+			},
+		},
+		{
+			name: "View Box",
+			validate: func() error {
+				drawing.SetViewBox(svg.ViewBox{MinX: -10, MinY: 0, Width: 200, Height: 100.5})
+				if got := drawing.GetAttribute("viewBox"); got != "-10 0 200 100.5" {
+					return fmt.Errorf("unexpected viewBox attribute: %q", got)
+				}
+				viewBox, ok := drawing.GetViewBox()
+				if !ok || viewBox != (svg.ViewBox{MinX: -10, MinY: 0, Width: 200, Height: 100.5}) {
+					return fmt.Errorf("unexpected view box: %+v, %v", viewBox, ok)
+				}
+				drawing.SetAttribute("viewBox", "0 0 10")
+				if _, ok := drawing.GetViewBox(); ok {
+					return fmt.Errorf("an incomplete view box should be invalid")
+				}
+				return nil
+			},
+		},
+		{
+			name: "Path Data Builder",
+			validate: func() error {
+				d := new(svg.PathData).MoveTo(0, 0).HorizontalTo(10).VerticalTo(10.5).
+					CubicTo(1, 2, 3, 4, 5, 6).SmoothCubicTo(7, 8, 9, 10).QuadTo(1, 1, 2, 2).SmoothQuadTo(3, 3).
+					ArcTo(5, 5, 0, false, true, 20, 20).LineTo(-1, 0).Close()
+				want := "M 0 0 H 10 V 10.5 C 1 2 3 4 5 6 S 7 8 9 10 Q 1 1 2 2 T 3 3 A 5 5 0 0 1 20 20 L -1 0 Z"
+				if got := d.String(); got != want {
+					return fmt.Errorf("unexpected path data: %q", got)
+				}
+				return nil
+			},
+		},
+		{
+			name:     "Path Length And Points",
+			geometry: true,
+			validate: func() error {
+				path := svg.NewPath(doc, new(svg.PathData).MoveTo(0, 0).LineTo(30, 0).LineTo(30, 40))
+				if err := drawing.Append(path); err != nil {
+					return fmt.Errorf("failed to append path: %v", err)
+				}
+				if got := path.GetTotalLength(); !approx(got, 70) {
+					return fmt.Errorf("unexpected length: %v", got)
+				}
+				p, err := path.GetPointAtLength(50)
+				if err != nil || !approx(p.X, 30) || !approx(p.Y, 20) {
+					return fmt.Errorf("unexpected point: %+v, %v", p, err)
+				}
+				arc := svg.NewPath(doc, new(svg.PathData).MoveTo(0, 0).ArcTo(10, 10, 0, false, true, 20, 0))
+				if err := drawing.Append(arc); err != nil {
+					return fmt.Errorf("failed to append arc: %v", err)
+				}
+				if got := arc.GetTotalLength(); !approx(got, 10*math.Pi) {
+					return fmt.Errorf("unexpected arc length: %v", got)
+				}
+				if _, err := svg.NewPath(doc, new(svg.PathData)).GetPointAtLength(1); err == nil {
+					return fmt.Errorf("an empty path should have no points")
+				}
+				return nil
+			},
+		},
+		{
+			name:     "Shape Geometry",
+			geometry: true,
+			validate: func() error {
+				rect := svg.NewRect(doc, 10, 20, 30, 40)
+				line := svg.NewLine(doc, 0, 0, 3, 4)
+				polyline := svg.NewPolyline(doc, svg.Point{X: 0, Y: 0}, svg.Point{X: 10, Y: 0}, svg.Point{X: 10, Y: 5})
+				circle := svg.NewCircle(doc, 0, 0, 10)
+				if err := drawing.Append(rect, line, polyline, circle); err != nil {
+					return fmt.Errorf("failed to append shapes: %v", err)
+				}
+				if got := rect.GetTotalLength(); !approx(got, 140) {
+					return fmt.Errorf("unexpected rect perimeter: %v", got)
+				}
+				if got := line.GetTotalLength(); !approx(got, 5) || line.GetEnd() != (svg.Point{X: 3, Y: 4}) {
+					return fmt.Errorf("unexpected line: %v, %v", got, line.GetEnd())
+				}
+				if got := polyline.GetPoints(); len(got) != 3 || got[2] != (svg.Point{X: 10, Y: 5}) {
+					return fmt.Errorf("unexpected polyline points: %v", got)
+				}
+				if got := circle.GetTotalLength(); !approx(got, 20*math.Pi) {
+					return fmt.Errorf("unexpected circumference: %v", got)
+				}
+				box := rect.GetBBox()
+				if box.GetX() != 10 || box.GetY() != 20 || box.GetWidth() != 30 || box.GetHeight() != 40 {
+					return fmt.Errorf("unexpected rect bounding box: %v %v %v %v", box.GetX(), box.GetY(), box.GetWidth(), box.GetHeight())
+				}
+				return nil
+			},
+		},
+		{
+			name:     "Group Bounding Box",
+			geometry: true,
+			validate: func() error {
+				group := svg.NewG(doc)
+				if err := group.Append(svg.NewRect(doc, 0, 0, 10, 10), svg.NewCircle(doc, 20, 20, 5)); err != nil {
+					return fmt.Errorf("failed to build group: %v", err)
+				}
+				if err := drawing.Append(group); err != nil {
+					return fmt.Errorf("failed to append group: %v", err)
+				}
+				box := group.GetBBox()
+				if !approx(box.GetX(), 0) || !approx(box.GetY(), 0) || !approx(box.GetWidth(), 25) || !approx(box.GetHeight(), 25) {
+					return fmt.Errorf("unexpected group bounding box: %v %v %v %v", box.GetX(), box.GetY(), box.GetWidth(), box.GetHeight())
+				}
+				return nil
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.geometry && !geometry {
+				t.Skip("the DOM does not compute SVG geometry")
+			}
+			fmt.Printf("Running test: %s\n", tt.name)
+			if err := tt.validate(); err != nil {
+				t.Errorf("validation failed: %v", err)
+				fmt.Printf("❌ Test failed: %s - %v\n", tt.name, err)
+			} else {
+				fmt.Printf("✅ Test passed: %s\n", tt.name)
+			}
+		})
+	}
+}
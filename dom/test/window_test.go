@@ -0,0 +1,263 @@
+//go:build js && wasm
+// +build js,wasm
+
+package test
+
+import (
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/abdorrahmani/go-wasm/dom"
+	"github.com/abdorrahmani/go-wasm/js"
+)
+
+func TestWindow(t *testing.T) {
+	fmt.Println("Starting window tests...")
+
+	w := dom.GetWindow()
+	live := js.LiveCallbacks()
+
+	tests := []struct {
+		name string
+		// requires lists the globals the test needs
+		requires []string
+		validate func() error
+	}{
+		{
+			name: "Timeout Fires Once",
+			validate: func() error {
+				var calls atomic.Int32
+				timer := w.SetTimeout(10*time.Millisecond, func() { calls.Add(1) })
+				wait(50 * time.Millisecond)
+				if calls.Load() != 1 {
+					return fmt.Errorf("expected 1 call, got %d", calls.Load())
+				}
+				if !timer.Stopped() || timer.Stop() {
+					return fmt.Errorf("a fired timeout should count as stopped")
+				}
+				return nil
+			},
+		},
+		{
+			name: "Stopped Timeout Does Not Fire",
+			validate: func() error {
+				var calls atomic.Int32
+				timer := w.SetTimeout(time.Hour, func() { calls.Add(1) })
+				if !timer.Stop() {
+					return fmt.Errorf("Stop should report the pending timeout")
+				}
+				wait(20 * time.Millisecond)
+				if calls.Load() != 0 {
+					return fmt.Errorf("the stopped timeout fired")
+				}
+				return nil
+			},
+		},
+		{
+			name: "Interval Repeats Until Stopped",
+			validate: func() error {
+				var calls atomic.Int32
+				timer := w.SetInterval(10*time.Millisecond, func() { calls.Add(1) })
+				wait(100 * time.Millisecond)
+				timer.Stop()
+				n := calls.Load()
+				if n < 2 {
+					return fmt.Errorf("expected at least 2 calls, got %d", n)
+				}
+				wait(50 * time.Millisecond)
+				if calls.Load() != n {
+					return fmt.Errorf("the interval kept firing after Stop")
+				}
+				return nil
+			},
+		},
+		{
+			name:     "Animation Loop",
+			requires: []string{"requestAnimationFrame"},
+			validate: func() error {
+				var timestamps []float64
+				done := make(chan struct{})
+				var loop *dom.AnimationLoop
+				loop = w.RequestAnimationFrame(func(timestamp float64) {
+					timestamps = append(timestamps, timestamp)
+					if len(timestamps) == 3 {
+						loop.Stop()
+						close(done)
+					}
+				})
+				for i := 0; i < 100 && !loop.Stopped(); i++ {
+					wait(20 * time.Millisecond)
+				}
+				select {
+				case <-done:
+				default:
+					return fmt.Errorf("expected 3 frames, got %d", len(timestamps))
+				}
+				// Browsers may give consecutive frames the same timestamp
+				for i := 1; i < len(timestamps); i++ {
+					if timestamps[i] < timestamps[i-1] {
+						return fmt.Errorf("timestamps should not decrease: %v", timestamps)
+					}
+				}
+				wait(50 * time.Millisecond)
+				if len(timestamps) != 3 {
+					return fmt.Errorf("the loop kept running after Stop")
+				}
+				return nil
+			},
+		},
+		{
+			// Without requestIdleCallback, as under Node.js, the callbacks
+			// run from a timeout
+			name: "Idle Callbacks",
+			validate: func() error {
+				var remaining time.Duration
+				var timedOut bool
+				var calls atomic.Int32
+				w.RequestIdleCallback(func(deadline *dom.IdleDeadline) {
+					remaining = deadline.TimeRemaining()
+					timedOut = deadline.GetDidTimeout()
+					calls.Add(1)
+				}, time.Second)
+				canceled := w.RequestIdleCallback(func(*dom.IdleDeadline) { calls.Add(10) }, 0)
+				canceled.Stop()
+				wait(50 * time.Millisecond)
+				if calls.Load() != 1 {
+					return fmt.Errorf("expected only the first callback to run, got %d", calls.Load())
+				}
+				if remaining <= 0 || remaining > 50*time.Millisecond || timedOut {
+					return fmt.Errorf("unexpected deadline: %v, timed out %v", remaining, timedOut)
+				}
+				return nil
+			},
+		},
+		{
+			name:     "Viewport And Scrolling",
+			requires: []string{"innerWidth", "scrollTo"},
+			validate: func() error {
+				if w.GetInnerWidth() <= 0 || w.GetInnerHeight() <= 0 || w.GetDevicePixelRatio() <= 0 {
+					return fmt.Errorf("unexpected viewport: %dx%d at %v", w.GetInnerWidth(), w.GetInnerHeight(), w.GetDevicePixelRatio())
+				}
+				// Browsers only scroll pages larger than the viewport
+				doc := dom.Global()
+				page := doc.CreateElement("div")
+				page.GetStyle().SetProperty("width", "5000px")
+				page.GetStyle().SetProperty("height", "5000px")
+				if err := doc.GetBody().AppendChild(&dom.Node{Value: page.Value}); err != nil {
+					return fmt.Errorf("failed to append page: %v", err)
+				}
+				defer doc.GetBody().RemoveChild(&dom.Node{Value: page.Value})
+
+				w.ScrollTo(10, 20)
+				w.ScrollBy(5, -30)
+				if w.GetScrollX() != 15 || w.GetScrollY() != 0 {
+					return fmt.Errorf("unexpected scroll position: %v, %v", w.GetScrollX(), w.GetScrollY())
+				}
+				w.ScrollToWithBehavior(0, 40, dom.ScrollInstant)
+				defer w.ScrollTo(0, 0)
+				if w.GetScrollX() != 0 || w.GetScrollY() != 40 {
+					return fmt.Errorf("unexpected scroll position: %v, %v", w.GetScrollX(), w.GetScrollY())
+				}
+				return nil
+			},
+		},
+		{
+			name:     "Media Queries",
+			requires: []string{"matchMedia", "MediaQueryListEvent"},
+			validate: func() error {
+				wide := w.MatchMedia("(MIN-WIDTH:600px)")
+				if wide.GetMedia() != "(min-width: 600px)" || !wide.GetMatches() {
+					return fmt.Errorf("unexpected query: %q matches %v", wide.GetMedia(), wide.GetMatches())
+				}
+				if m := w.MatchMedia("print, (orientation: landscape)"); !m.GetMatches() {
+					return fmt.Errorf("%q should match", m.GetMedia())
+				}
+				if m := w.MatchMedia("(min-width: wide)"); m.GetMatches() {
+					return fmt.Errorf("an invalid query should not match")
+				}
+
+				// Pages cannot resize their window, so the change events are
+				// dispatched as the browser would
+				var changes []bool
+				listener := wide.OnChange(func(e *dom.MediaQueryListEvent) {
+					if e.GetMedia() == wide.GetMedia() {
+						changes = append(changes, e.GetMatches())
+					}
+				})
+				defer listener.Remove()
+				for _, matches := range []bool{false, true} {
+					init := js.Global().Call("Object")
+					init.Set("media", wide.GetMedia())
+					init.Set("matches", matches)
+					wide.Value.Call("dispatchEvent", js.Global().Get("MediaQueryListEvent").New("change", init))
+				}
+				if len(changes) != 2 || changes[0] || !changes[1] {
+					return fmt.Errorf("unexpected changes: %v", changes)
+				}
+				return nil
+			},
+		},
+		{
+			name:     "Dialogs",
+			requires: []string{"alert", "confirm", "prompt"},
+			validate: func() error {
+				w.Alert("hello")
+				if w.Confirm("sure?") {
+					return fmt.Errorf("confirm should be dismissed")
+				}
+				if value, ok := w.Prompt("name?", "gopher"); ok || value != "" {
+					return fmt.Errorf("prompt should be canceled, got %q, %v", value, ok)
+				}
+				return nil
+			},
+		},
+		{
+			name: "Document And Window Events",
+			validate: func() error {
+				doc := dom.Global()
+				view := doc.GetDefaultView()
+				if view == nil || !view.GetDocument().GetBody().IsSameNode(doc.GetBody()) {
+					return fmt.Errorf("the document should be shown in the window")
+				}
+				var phases []int
+				listener := w.AddEventListener("ping", func(e *dom.Event) {
+					phases = append(phases, e.GetEventPhase())
+				})
+				defer w.RemoveEventListener(listener)
+				doc.GetBody().DispatchEvent(dom.NewEvent("ping", dom.EventInit{Bubbles: true}))
+				w.DispatchEvent(dom.NewEvent("ping", dom.EventInit{}))
+				if len(phases) != 2 || phases[0] != 3 || phases[1] != 2 {
+					return fmt.Errorf("unexpected event phases: %v", phases)
+				}
+				return nil
+			},
+		},
+		{
+			name: "Callbacks Are Released",
+			validate: func() error {
+				wait(20 * time.Millisecond)
+				if n := js.LiveCallbacks(); n != live {
+					return fmt.Errorf("expected %d live callbacks, got %d", live, n)
+				}
+				return nil
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if name := missingAPI(tt.requires...); name != "" {
+				t.Skipf("%s is not available", name)
+			}
+			fmt.Printf("Running test: %s\n", tt.name)
+			if err := tt.validate(); err != nil {
+				t.Errorf("validation failed: %v", err)
+				fmt.Printf("❌ Test failed: %s - %v\n", tt.name, err)
+			} else {
+				fmt.Printf("✅ Test passed: %s\n", tt.name)
+			}
+		})
+	}
+}
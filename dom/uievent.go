@@ -0,0 +1,514 @@
+//go:build js && wasm
+// +build js,wasm
+
+package dom
+
+import (
+	"strconv"
+
+	"github.com/abdorrahmani/go-wasm/js"
+)
+
+// MouseEvent represents a mouse event
+type MouseEvent struct {
+	Event
+}
+
+// MouseEventInit configures a mouse event created from Go
+type MouseEventInit struct {
+	EventInit
+	ScreenX, ScreenY float64
+	ClientX, ClientY float64
+	// Button is the button that changed state, and Buttons the buttons held down
+	Button, Buttons int
+	// RelatedTarget is the secondary target, such as the element the pointer left
+	RelatedTarget *Element
+	AltKey        bool
+	CtrlKey       bool
+	MetaKey       bool
+	ShiftKey      bool
+}
+
+// NewMouseEvent creates a mouse event that can be passed to DispatchEvent
+func NewMouseEvent(eventType string, init MouseEventInit) *MouseEvent {
+	options := init.EventInit.object()
+	options.Set("screenX", init.ScreenX)
+	options.Set("screenY", init.ScreenY)
+	options.Set("clientX", init.ClientX)
+	options.Set("clientY", init.ClientY)
+	options.Set("button", init.Button)
+	options.Set("buttons", init.Buttons)
+	if init.RelatedTarget != nil {
+		options.Set("relatedTarget", init.RelatedTarget.Value)
+	}
+	options.Set("altKey", init.AltKey)
+	options.Set("ctrlKey", init.CtrlKey)
+	options.Set("metaKey", init.MetaKey)
+	options.Set("shiftKey", init.ShiftKey)
+	return &MouseEvent{
+		Event: Event{
+			Value: js.Global().Get("MouseEvent").New(eventType, options),
+		},
+	}
+}
+
+// GetButton returns the button that was pressed
+func (e *MouseEvent) GetButton() int {
+	return e.Value.Get("button").MustInt()
+}
+
+// GetButtons returns the buttons that are pressed
+func (e *MouseEvent) GetButtons() int {
+	return e.Value.Get("buttons").MustInt()
+}
+
+// GetClientX returns the X coordinate relative to the viewport
+func (e *MouseEvent) GetClientX() float64 {
+	return e.Value.Get("clientX").MustFloat()
+}
+
+// GetClientY returns the Y coordinate relative to the viewport
+func (e *MouseEvent) GetClientY() float64 {
+	return e.Value.Get("clientY").MustFloat()
+}
+
+// GetScreenX returns the X coordinate relative to the screen
+func (e *MouseEvent) GetScreenX() float64 {
+	return e.Value.Get("screenX").MustFloat()
+}
+
+// GetScreenY returns the Y coordinate relative to the screen
+func (e *MouseEvent) GetScreenY() float64 {
+	return e.Value.Get("screenY").MustFloat()
+}
+
+// GetMovementX returns the X coordinate of the mouse movement
+func (e *MouseEvent) GetMovementX() float64 {
+	return e.Value.Get("movementX").MustFloat()
+}
+
+// GetMovementY returns the Y coordinate of the mouse movement
+func (e *MouseEvent) GetMovementY() float64 {
+	return e.Value.Get("movementY").MustFloat()
+}
+
+// GetOffsetX returns the offset X coordinate
+func (e *MouseEvent) GetOffsetX() float64 {
+	return e.Value.Get("offsetX").MustFloat()
+}
+
+// GetOffsetY returns the offset Y coordinate
+func (e *MouseEvent) GetOffsetY() float64 {
+	return e.Value.Get("offsetY").MustFloat()
+}
+
+// GetPageX returns the page X coordinate
+func (e *MouseEvent) GetPageX() float64 {
+	return e.Value.Get("pageX").MustFloat()
+}
+
+// GetPageY returns the page Y coordinate
+func (e *MouseEvent) GetPageY() float64 {
+	return e.Value.Get("pageY").MustFloat()
+}
+
+// GetX returns the X coordinate
+func (e *MouseEvent) GetX() float64 {
+	return e.Value.Get("x").MustFloat()
+}
+
+// GetY returns the Y coordinate
+func (e *MouseEvent) GetY() float64 {
+	return e.Value.Get("y").MustFloat()
+}
+
+// GetAltKey returns true if the Alt key was pressed
+func (e *MouseEvent) GetAltKey() bool {
+	return e.Value.Get("altKey").MustBool()
+}
+
+// GetCtrlKey returns true if the Ctrl key was pressed
+func (e *MouseEvent) GetCtrlKey() bool {
+	return e.Value.Get("ctrlKey").MustBool()
+}
+
+// GetMetaKey returns true if the Meta key was pressed
+func (e *MouseEvent) GetMetaKey() bool {
+	return e.Value.Get("metaKey").MustBool()
+}
+
+// GetShiftKey returns true if the Shift key was pressed
+func (e *MouseEvent) GetShiftKey() bool {
+	return e.Value.Get("shiftKey").MustBool()
+}
+
+// GetRelatedTarget returns the related target, or nil if there is none
+func (e *MouseEvent) GetRelatedTarget() *Element {
+	return wrapElement(e.Value.Get("relatedTarget"))
+}
+
+// FocusEvent represents a focus event such as focus, blur, focusin or focusout
+type FocusEvent struct {
+	Event
+}
+
+// GetRelatedTarget returns the element losing or gaining focus, or nil if there is none
+func (e *FocusEvent) GetRelatedTarget() *Element {
+	return wrapElement(e.Value.Get("relatedTarget"))
+}
+
+// KeyLocation constants
+const (
+	KeyLocationStandard = 0
+	KeyLocationLeft     = 1
+	KeyLocationRight    = 2
+	KeyLocationNumpad   = 3
+)
+
+// KeyboardEvent represents a keyboard event
+type KeyboardEvent struct {
+	Event
+}
+
+// KeyboardEventInit configures a keyboard event created from Go
+type KeyboardEventInit struct {
+	EventInit
+	Key         string
+	Code        string
+	Location    int
+	Repeat      bool
+	IsComposing bool
+	AltKey      bool
+	CtrlKey     bool
+	MetaKey     bool
+	ShiftKey    bool
+}
+
+// NewKeyboardEvent creates a keyboard event that can be passed to DispatchEvent
+func NewKeyboardEvent(eventType string, init KeyboardEventInit) *KeyboardEvent {
+	options := init.EventInit.object()
+	options.Set("key", init.Key)
+	options.Set("code", init.Code)
+	options.Set("location", init.Location)
+	options.Set("repeat", init.Repeat)
+	options.Set("isComposing", init.IsComposing)
+	options.Set("altKey", init.AltKey)
+	options.Set("ctrlKey", init.CtrlKey)
+	options.Set("metaKey", init.MetaKey)
+	options.Set("shiftKey", init.ShiftKey)
+	return &KeyboardEvent{
+		Event: Event{
+			Value: js.Global().Get("KeyboardEvent").New(eventType, options),
+		},
+	}
+}
+
+// GetKey returns the value of the key, such as "a" or "Enter"
+func (e *KeyboardEvent) GetKey() string {
+	return e.Value.Get("key").MustString()
+}
+
+// GetCode returns the physical key, such as "KeyA" or "Enter"
+func (e *KeyboardEvent) GetCode() string {
+	return e.Value.Get("code").MustString()
+}
+
+// GetLocation returns the location of the key on the keyboard
+func (e *KeyboardEvent) GetLocation() int {
+	return e.Value.Get("location").MustInt()
+}
+
+// GetRepeat returns true if the key is being held down
+func (e *KeyboardEvent) GetRepeat() bool {
+	return e.Value.Get("repeat").MustBool()
+}
+
+// GetIsComposing returns true if the event is part of a composition session
+func (e *KeyboardEvent) GetIsComposing() bool {
+	return e.Value.Get("isComposing").MustBool()
+}
+
+// GetAltKey returns true if the Alt key was pressed
+func (e *KeyboardEvent) GetAltKey() bool {
+	return e.Value.Get("altKey").MustBool()
+}
+
+// GetCtrlKey returns true if the Ctrl key was pressed
+func (e *KeyboardEvent) GetCtrlKey() bool {
+	return e.Value.Get("ctrlKey").MustBool()
+}
+
+// GetMetaKey returns true if the Meta key was pressed
+func (e *KeyboardEvent) GetMetaKey() bool {
+	return e.Value.Get("metaKey").MustBool()
+}
+
+// GetShiftKey returns true if the Shift key was pressed
+func (e *KeyboardEvent) GetShiftKey() bool {
+	return e.Value.Get("shiftKey").MustBool()
+}
+
+// GetModifierState returns true if the modifier key, such as "CapsLock", was active
+func (e *KeyboardEvent) GetModifierState(key string) bool {
+	return e.Value.Call("getModifierState", key).MustBool()
+}
+
+// InputEvent represents an input or beforeinput event
+type InputEvent struct {
+	Event
+}
+
+// GetData returns the inserted text, or an empty string if there is none
+func (e *InputEvent) GetData() string {
+	return e.Value.Get("data").TryString("")
+}
+
+// GetInputType returns the kind of change, such as "insertText" or "deleteContentBackward"
+func (e *InputEvent) GetInputType() string {
+	return e.Value.Get("inputType").MustString()
+}
+
+// GetIsComposing returns true if the event is part of a composition session
+func (e *InputEvent) GetIsComposing() bool {
+	return e.Value.Get("isComposing").MustBool()
+}
+
+// PointerEvent represents a pointer event
+type PointerEvent struct {
+	MouseEvent
+}
+
+// GetPointerID returns the unique identifier of the pointer
+func (e *PointerEvent) GetPointerID() int {
+	return e.Value.Get("pointerId").MustInt()
+}
+
+// GetWidth returns the width of the contact geometry
+func (e *PointerEvent) GetWidth() float64 {
+	return e.Value.Get("width").MustFloat()
+}
+
+// GetHeight returns the height of the contact geometry
+func (e *PointerEvent) GetHeight() float64 {
+	return e.Value.Get("height").MustFloat()
+}
+
+// GetPressure returns the normalized pressure of the pointer, from 0 to 1
+func (e *PointerEvent) GetPressure() float64 {
+	return e.Value.Get("pressure").MustFloat()
+}
+
+// GetTangentialPressure returns the normalized tangential pressure, from -1 to 1
+func (e *PointerEvent) GetTangentialPressure() float64 {
+	return e.Value.Get("tangentialPressure").MustFloat()
+}
+
+// GetTiltX returns the tilt of the pointer along the X axis in degrees
+func (e *PointerEvent) GetTiltX() float64 {
+	return e.Value.Get("tiltX").MustFloat()
+}
+
+// GetTiltY returns the tilt of the pointer along the Y axis in degrees
+func (e *PointerEvent) GetTiltY() float64 {
+	return e.Value.Get("tiltY").MustFloat()
+}
+
+// GetTwist returns the clockwise rotation of the pointer in degrees
+func (e *PointerEvent) GetTwist() float64 {
+	return e.Value.Get("twist").MustFloat()
+}
+
+// GetPointerType returns the device type, such as "mouse", "pen" or "touch"
+func (e *PointerEvent) GetPointerType() string {
+	return e.Value.Get("pointerType").MustString()
+}
+
+// GetIsPrimary returns true if the pointer is the primary pointer of its type
+func (e *PointerEvent) GetIsPrimary() bool {
+	return e.Value.Get("isPrimary").MustBool()
+}
+
+// DeltaMode constants
+const (
+	DeltaModePixel = 0
+	DeltaModeLine  = 1
+	DeltaModePage  = 2
+)
+
+// WheelEvent represents a wheel event
+type WheelEvent struct {
+	MouseEvent
+}
+
+// GetDeltaX returns the horizontal scroll amount
+func (e *WheelEvent) GetDeltaX() float64 {
+	return e.Value.Get("deltaX").MustFloat()
+}
+
+// GetDeltaY returns the vertical scroll amount
+func (e *WheelEvent) GetDeltaY() float64 {
+	return e.Value.Get("deltaY").MustFloat()
+}
+
+// GetDeltaZ returns the scroll amount along the Z axis
+func (e *WheelEvent) GetDeltaZ() float64 {
+	return e.Value.Get("deltaZ").MustFloat()
+}
+
+// GetDeltaMode returns the unit of the delta values
+func (e *WheelEvent) GetDeltaMode() int {
+	return e.Value.Get("deltaMode").MustInt()
+}
+
+// TouchEvent represents a touch event
+type TouchEvent struct {
+	Event
+}
+
+// touchList converts a TouchList to a slice
+func touchList(v *js.Value) []*Touch {
+	length := v.TryLength(0)
+	touches := make([]*Touch, length)
+	for i := 0; i < length; i++ {
+		touches[i] = &Touch{
+			Value: v.Get(strconv.Itoa(i)),
+		}
+	}
+	return touches
+}
+
+// GetTouches returns all current touch points
+func (e *TouchEvent) GetTouches() []*Touch {
+	return touchList(e.Value.Get("touches"))
+}
+
+// GetTargetTouches returns the touch points that started on the target element
+func (e *TouchEvent) GetTargetTouches() []*Touch {
+	return touchList(e.Value.Get("targetTouches"))
+}
+
+// GetChangedTouches returns the touch points that changed in this event
+func (e *TouchEvent) GetChangedTouches() []*Touch {
+	return touchList(e.Value.Get("changedTouches"))
+}
+
+// GetAltKey returns true if the Alt key was pressed
+func (e *TouchEvent) GetAltKey() bool {
+	return e.Value.Get("altKey").MustBool()
+}
+
+// GetCtrlKey returns true if the Ctrl key was pressed
+func (e *TouchEvent) GetCtrlKey() bool {
+	return e.Value.Get("ctrlKey").MustBool()
+}
+
+// GetMetaKey returns true if the Meta key was pressed
+func (e *TouchEvent) GetMetaKey() bool {
+	return e.Value.Get("metaKey").MustBool()
+}
+
+// GetShiftKey returns true if the Shift key was pressed
+func (e *TouchEvent) GetShiftKey() bool {
+	return e.Value.Get("shiftKey").MustBool()
+}
+
+// Touch represents a single touch point
+type Touch struct {
+	Value *js.Value
+}
+
+// GetIdentifier returns the unique identifier of the touch point
+func (t *Touch) GetIdentifier() int {
+	return t.Value.Get("identifier").MustInt()
+}
+
+// GetTarget returns the element the touch point started on, or nil if there is none
+func (t *Touch) GetTarget() *Element {
+	return wrapElement(t.Value.Get("target"))
+}
+
+// GetClientX returns the X coordinate relative to the viewport
+func (t *Touch) GetClientX() float64 {
+	return t.Value.Get("clientX").MustFloat()
+}
+
+// GetClientY returns the Y coordinate relative to the viewport
+func (t *Touch) GetClientY() float64 {
+	return t.Value.Get("clientY").MustFloat()
+}
+
+// GetScreenX returns the X coordinate relative to the screen
+func (t *Touch) GetScreenX() float64 {
+	return t.Value.Get("screenX").MustFloat()
+}
+
+// GetScreenY returns the Y coordinate relative to the screen
+func (t *Touch) GetScreenY() float64 {
+	return t.Value.Get("screenY").MustFloat()
+}
+
+// GetPageX returns the X coordinate relative to the document
+func (t *Touch) GetPageX() float64 {
+	return t.Value.Get("pageX").MustFloat()
+}
+
+// GetPageY returns the Y coordinate relative to the document
+func (t *Touch) GetPageY() float64 {
+	return t.Value.Get("pageY").MustFloat()
+}
+
+// GetRadiusX returns the X radius of the contact area
+func (t *Touch) GetRadiusX() float64 {
+	return t.Value.Get("radiusX").MustFloat()
+}
+
+// GetRadiusY returns the Y radius of the contact area
+func (t *Touch) GetRadiusY() float64 {
+	return t.Value.Get("radiusY").MustFloat()
+}
+
+// GetRotationAngle returns the rotation of the contact area in degrees
+func (t *Touch) GetRotationAngle() float64 {
+	return t.Value.Get("rotationAngle").MustFloat()
+}
+
+// GetForce returns the normalized pressure of the touch, from 0 to 1
+func (t *Touch) GetForce() float64 {
+	return t.Value.Get("force").MustFloat()
+}
+
+// CustomEvent represents an event carrying application data in its detail
+type CustomEvent struct {
+	Event
+}
+
+// NewCustomEvent creates a custom event that does not bubble.
+// The detail is marshalled with js.Marshal.
+func NewCustomEvent(eventType string, detail interface{}) (*CustomEvent, error) {
+	return NewCustomEventWithOptions(eventType, detail, EventInit{})
+}
+
+// NewCustomEventWithOptions creates a custom event configured by init
+func NewCustomEventWithOptions(eventType string, detail interface{}, init EventInit) (*CustomEvent, error) {
+	value, err := js.Marshal(detail)
+	if err != nil {
+		return nil, err
+	}
+	options := init.object()
+	options.Set("detail", value)
+	return &CustomEvent{
+		Event: Event{
+			Value: js.Global().Get("CustomEvent").New(eventType, options),
+		},
+	}, nil
+}
+
+// GetDetail returns the detail of the event as a JavaScript value
+func (e *CustomEvent) GetDetail() *js.Value {
+	return e.Value.Get("detail")
+}
+
+// Detail decodes the detail of the event into target, which must be a pointer
+func (e *CustomEvent) Detail(target interface{}) error {
+	return e.GetDetail().Decode(target)
+}
@@ -0,0 +1,364 @@
+//go:build js && wasm
+// +build js,wasm
+
+package dom
+
+import (
+	"sync"
+	"time"
+
+	"github.com/abdorrahmani/go-wasm/js"
+)
+
+// Window represents the browser window, which is the global object of the page
+type Window struct {
+	Value *js.Value
+}
+
+// GetWindow returns the global window object
+func GetWindow() *Window {
+	return &Window{
+		Value: js.Global(),
+	}
+}
+
+// GetDocument returns the document shown in the window
+func (w *Window) GetDocument() *Document {
+	return &Document{
+		Value: w.Value.Get("document"),
+	}
+}
+
+// GetDefaultView returns the window showing the document, or nil if there is none
+func (d *Document) GetDefaultView() *Window {
+	value := d.Value.Get("defaultView")
+	if value.IsNull() || value.IsUndefined() {
+		return nil
+	}
+	return &Window{Value: value}
+}
+
+// AddEventListener adds an event listener, such as for resize, and returns a
+// handle that removes it
+func (w *Window) AddEventListener(eventType string, handler func(*Event)) *Listener {
+	return addEventListener(w.Value, eventType, handler, AddEventListenerOptions{})
+}
+
+// AddEventListenerWithOptions adds an event listener configured by opts
+func (w *Window) AddEventListenerWithOptions(eventType string, handler func(*Event), opts AddEventListenerOptions) *Listener {
+	return addEventListener(w.Value, eventType, handler, opts)
+}
+
+// RemoveEventListener removes a listener returned by AddEventListener
+func (w *Window) RemoveEventListener(listener *Listener) {
+	listener.Remove()
+}
+
+// DispatchEvent dispatches an event
+func (w *Window) DispatchEvent(event *Event) bool {
+	return w.Value.Call("dispatchEvent", event.Value).MustBool()
+}
+
+// Timer is a handle to a pending timeout, interval or idle callback
+type Timer struct {
+	window   *js.Value
+	id       *js.Value
+	cancel   string
+	callback *js.Callback
+	mu       sync.Mutex
+	stopped  bool
+}
+
+// milliseconds converts a duration to the milliseconds JavaScript timers expect
+func milliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
+// SetTimeout calls fn once after d has passed
+func (w *Window) SetTimeout(d time.Duration, fn func()) *Timer {
+	t := &Timer{window: w.Value, cancel: "clearTimeout"}
+	t.callback = js.NewOnceCallback(func(args []*js.Value) {
+		if t.finish() {
+			fn()
+		}
+	})
+	t.id = w.Value.Call("setTimeout", t.callback.Value(), milliseconds(d))
+	return t
+}
+
+// SetInterval calls fn every time d has passed, until the timer is stopped
+func (w *Window) SetInterval(d time.Duration, fn func()) *Timer {
+	t := &Timer{window: w.Value, cancel: "clearInterval"}
+	t.callback = js.NewCallback(func(args []*js.Value) {
+		if !t.Stopped() {
+			fn()
+		}
+	})
+	t.id = w.Value.Call("setInterval", t.callback.Value(), milliseconds(d))
+	return t
+}
+
+// IdleDeadline tells an idle callback how long it may run
+type IdleDeadline struct {
+	Value *js.Value
+	// end is the end of the idle period when Value is nil, because the
+	// browser has no requestIdleCallback
+	end time.Time
+}
+
+// idlePeriod is the idle period given to callbacks in browsers without
+// requestIdleCallback, the longest one browsers give
+const idlePeriod = 50 * time.Millisecond
+
+// TimeRemaining returns how much of the idle period is left
+func (d *IdleDeadline) TimeRemaining() time.Duration {
+	if d.Value == nil {
+		return max(time.Until(d.end), 0)
+	}
+	return time.Duration(d.Value.Call("timeRemaining").MustFloat() * float64(time.Millisecond))
+}
+
+// GetDidTimeout returns true if the callback runs because its timeout expired
+// rather than because the browser is idle
+func (d *IdleDeadline) GetDidTimeout() bool {
+	if d.Value == nil {
+		return false
+	}
+	return d.Value.Get("didTimeout").MustBool()
+}
+
+// RequestIdleCallback calls fn once the browser is idle. A positive timeout
+// makes the browser call fn after that time even if it never becomes idle.
+// Browsers without requestIdleCallback, such as Safari, call fn from a
+// timeout right away.
+func (w *Window) RequestIdleCallback(fn func(*IdleDeadline), timeout time.Duration) *Timer {
+	t := &Timer{window: w.Value, cancel: "cancelIdleCallback"}
+	t.callback = js.NewOnceCallback(func(args []*js.Value) {
+		if !t.finish() {
+			return
+		}
+		if len(args) == 0 {
+			fn(&IdleDeadline{end: time.Now().Add(idlePeriod)})
+			return
+		}
+		fn(&IdleDeadline{Value: args[0]})
+	})
+	options := js.Global().Call("Object")
+	if timeout > 0 {
+		options.Set("timeout", milliseconds(timeout))
+	}
+	id, err := w.Value.CallE("requestIdleCallback", t.callback.Value(), options)
+	if err != nil {
+		t.cancel = "clearTimeout"
+		id = w.Value.Call("setTimeout", t.callback.Value(), 0)
+	}
+	t.id = id
+	return t
+}
+
+// finish marks a one-shot timer as fired. It returns false if it was stopped.
+func (t *Timer) finish() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.stopped {
+		return false
+	}
+	t.stopped = true
+	return true
+}
+
+// Stopped reports whether the timer was stopped or, for one-shot timers, has fired
+func (t *Timer) Stopped() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.stopped
+}
+
+// Stop cancels the timer and releases its callback. It returns false if the
+// timer was already stopped or, for one-shot timers, has already fired.
+func (t *Timer) Stop() bool {
+	t.mu.Lock()
+	if t.stopped {
+		t.mu.Unlock()
+		return false
+	}
+	t.stopped = true
+	t.mu.Unlock()
+
+	t.window.Call(t.cancel, t.id)
+	t.callback.Release()
+	return true
+}
+
+// AnimationLoop calls a function before every repaint until it is stopped
+type AnimationLoop struct {
+	window   *js.Value
+	id       *js.Value
+	callback *js.Callback
+	mu       sync.Mutex
+	stopped  bool
+}
+
+// RequestAnimationFrame calls fn before every repaint with the time of the
+// frame in milliseconds, until the loop is stopped. fn may stop the loop itself.
+func (w *Window) RequestAnimationFrame(fn func(timestamp float64)) *AnimationLoop {
+	l := &AnimationLoop{window: w.Value}
+	l.callback = js.NewCallback(func(args []*js.Value) {
+		if l.Stopped() {
+			return
+		}
+		fn(args[0].MustFloat())
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		if !l.stopped {
+			l.id = l.window.Call("requestAnimationFrame", l.callback.Value())
+		}
+	})
+	l.id = w.Value.Call("requestAnimationFrame", l.callback.Value())
+	return l
+}
+
+// Stopped reports whether the loop has been stopped
+func (l *AnimationLoop) Stopped() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.stopped
+}
+
+// Stop cancels the next frame and releases the callback.
+// Calling Stop more than once is a no-op.
+func (l *AnimationLoop) Stop() {
+	l.mu.Lock()
+	if l.stopped {
+		l.mu.Unlock()
+		return
+	}
+	l.stopped = true
+	id := l.id
+	l.mu.Unlock()
+
+	l.window.Call("cancelAnimationFrame", id)
+	l.callback.Release()
+}
+
+// GetInnerWidth returns the width of the viewport in CSS pixels
+func (w *Window) GetInnerWidth() int {
+	return w.Value.Get("innerWidth").MustInt()
+}
+
+// GetInnerHeight returns the height of the viewport in CSS pixels
+func (w *Window) GetInnerHeight() int {
+	return w.Value.Get("innerHeight").MustInt()
+}
+
+// GetDevicePixelRatio returns the number of device pixels per CSS pixel
+func (w *Window) GetDevicePixelRatio() float64 {
+	return w.Value.Get("devicePixelRatio").MustFloat()
+}
+
+// GetScrollX returns how far the document is scrolled horizontally
+func (w *Window) GetScrollX() float64 {
+	return w.Value.Get("scrollX").MustFloat()
+}
+
+// GetScrollY returns how far the document is scrolled vertically
+func (w *Window) GetScrollY() float64 {
+	return w.Value.Get("scrollY").MustFloat()
+}
+
+// ScrollBehavior is how scrolling moves to its destination
+type ScrollBehavior string
+
+// Scroll behaviors
+const (
+	ScrollAuto    ScrollBehavior = "auto"
+	ScrollSmooth  ScrollBehavior = "smooth"
+	ScrollInstant ScrollBehavior = "instant"
+)
+
+// ScrollTo scrolls the document to the given position
+func (w *Window) ScrollTo(x, y float64) {
+	w.Value.Call("scrollTo", x, y)
+}
+
+// ScrollToWithBehavior scrolls the document to the given position, for
+// example smoothly
+func (w *Window) ScrollToWithBehavior(x, y float64, behavior ScrollBehavior) {
+	options := js.Global().Call("Object")
+	options.Set("left", x)
+	options.Set("top", y)
+	options.Set("behavior", string(behavior))
+	w.Value.Call("scrollTo", options)
+}
+
+// ScrollBy scrolls the document by the given amounts
+func (w *Window) ScrollBy(dx, dy float64) {
+	w.Value.Call("scrollBy", dx, dy)
+}
+
+// MediaQueryList tells whether the document matches a media query and
+// notifies listeners when that changes
+type MediaQueryList struct {
+	Value *js.Value
+}
+
+// MatchMedia parses a media query such as "(max-width: 600px)".
+// An invalid query never matches and its media is "not all".
+func (w *Window) MatchMedia(query string) *MediaQueryList {
+	return &MediaQueryList{
+		Value: w.Value.Call("matchMedia", query),
+	}
+}
+
+// GetMedia returns the serialized media query
+func (m *MediaQueryList) GetMedia() string {
+	return m.Value.Get("media").MustString()
+}
+
+// GetMatches returns true if the document currently matches the query
+func (m *MediaQueryList) GetMatches() bool {
+	return m.Value.Get("matches").MustBool()
+}
+
+// OnChange calls handler whenever the result of the query changes and returns
+// a handle that removes the listener
+func (m *MediaQueryList) OnChange(handler func(*MediaQueryListEvent)) *Listener {
+	return addEventListener(m.Value, "change", func(e *Event) {
+		handler(&MediaQueryListEvent{Event: *e})
+	}, AddEventListenerOptions{})
+}
+
+// MediaQueryListEvent is fired when the result of a media query changes
+type MediaQueryListEvent struct {
+	Event
+}
+
+// GetMedia returns the serialized media query
+func (e *MediaQueryListEvent) GetMedia() string {
+	return e.Value.Get("media").MustString()
+}
+
+// GetMatches returns true if the document now matches the query
+func (e *MediaQueryListEvent) GetMatches() bool {
+	return e.Value.Get("matches").MustBool()
+}
+
+// Alert shows a message and waits until the user dismisses it
+func (w *Window) Alert(message string) {
+	w.Value.Call("alert", message)
+}
+
+// Confirm asks the user to confirm a message and returns true if they accepted
+func (w *Window) Confirm(message string) bool {
+	return w.Value.Call("confirm", message).MustBool()
+}
+
+// Prompt asks the user for text, offering defaultValue. It returns false if
+// the user canceled the dialog.
+func (w *Window) Prompt(message, defaultValue string) (string, bool) {
+	value := w.Value.Call("prompt", message, defaultValue)
+	if value.IsNull() || value.IsUndefined() {
+		return "", false
+	}
+	return value.MustString(), true
+}
@@ -18,13 +18,8 @@ func main() {
 		return
 	}
 
-	// Wait for DOM to be ready
-	for i := 0; i < 10; i++ {
-		if doc.IsReady() {
-			break
-		}
-		time.Sleep(100 * time.Millisecond)
-	}
+	// Wait for the DOM to be parsed
+	<-doc.Ready()
 
 	// Get elements
 	output := doc.GetElementByID("output")
@@ -41,6 +36,11 @@ func main() {
 	changeStyleBtn := doc.GetElementByID("changeStyle")
 	addEventBtn := doc.GetElementByID("addEvent")
 
+	if addElementBtn == nil || changeStyleBtn == nil || addEventBtn == nil {
+		fmt.Println("Failed to get control buttons")
+		return
+	}
+
 	// Add Element button click handler
 	addElementBtn.AddEventListener("click", func(e *dom.Event) {
 		// Create new element
@@ -55,11 +55,7 @@ func main() {
 	// Change Style button click handler
 	changeStyleBtn.AddEventListener("click", func(e *dom.Event) {
 		// Toggle highlight class
-		if output.GetClassName() == "box highlight" {
-			output.SetClassName("box")
-		} else {
-			output.SetClassName("box highlight")
-		}
+		output.ClassList().Toggle("highlight")
 
 		// Change some inline styles
 		style := output.GetStyle()
@@ -82,12 +78,17 @@ func main() {
 			eventType := e.GetType()
 			timestamp := e.GetTimeStamp()
 
+			tagName := "unknown"
+			if target != nil {
+				tagName = target.GetTagName()
+			}
+
 			// Create event details element
 			details := doc.CreateElement("p")
 			details.SetTextContent(fmt.Sprintf(
 				"Event: %s\nTarget: %s\nTime: %f",
 				eventType,
-				target.GetTagName(),
+				tagName,
 				timestamp,
 			))
 
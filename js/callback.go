@@ -0,0 +1,166 @@
+//go:build js && wasm
+// +build js,wasm
+
+package js
+
+import (
+	"sync"
+	"sync/atomic"
+	"syscall/js"
+)
+
+// liveCallbacks counts callbacks that have been created but not yet released
+var liveCallbacks int64
+
+// LiveCallbacks returns the number of callbacks that have not been released yet.
+// It is meant for debugging and for asserting in tests that code does not leak callbacks.
+func LiveCallbacks() int {
+	return int(atomic.LoadInt64(&liveCallbacks))
+}
+
+// Callback is a Go function exposed to JavaScript.
+// Callbacks hold resources on both sides of the boundary and must be released
+// with Release, by their Scope, or automatically when created as one-shot.
+type Callback struct {
+	fn       js.Func
+	scope    *Scope
+	once     bool
+	mu       sync.Mutex
+	released bool
+}
+
+// NewCallback creates a new JavaScript callback function
+func NewCallback(fn func([]*Value)) *Callback {
+	return newCallback(fn, false)
+}
+
+// NewOnceCallback creates a JavaScript callback function that releases itself
+// after its first invocation
+func NewOnceCallback(fn func([]*Value)) *Callback {
+	return newCallback(fn, true)
+}
+
+func newCallback(fn func([]*Value), once bool) *Callback {
+	c := &Callback{once: once}
+	c.fn = js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		if c.once {
+			defer c.Release()
+		}
+		wrappedArgs := make([]*Value, len(args))
+		for i, arg := range args {
+			wrappedArgs[i] = &Value{value: arg}
+		}
+		fn(wrappedArgs)
+		return nil
+	})
+	atomic.AddInt64(&liveCallbacks, 1)
+	return c
+}
+
+// Value returns the JavaScript function backing the callback
+func (c *Callback) Value() *Value {
+	return &Value{value: c.fn.Value}
+}
+
+// Released reports whether the callback has been released
+func (c *Callback) Released() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.released
+}
+
+// Release frees the callback. JavaScript must not invoke it afterwards.
+// Calling Release more than once is a no-op.
+func (c *Callback) Release() {
+	c.mu.Lock()
+	if c.released {
+		c.mu.Unlock()
+		return
+	}
+	c.released = true
+	scope := c.scope
+	c.mu.Unlock()
+
+	c.fn.Release()
+	atomic.AddInt64(&liveCallbacks, -1)
+	if scope != nil {
+		scope.remove(c)
+	}
+}
+
+// Scope owns a group of callbacks so they can be released at once,
+// e.g. when a component is torn down
+type Scope struct {
+	mu        sync.Mutex
+	callbacks map[*Callback]struct{}
+	released  bool
+}
+
+// NewScope creates an empty callback scope
+func NewScope() *Scope {
+	return &Scope{
+		callbacks: make(map[*Callback]struct{}),
+	}
+}
+
+// NewCallback creates a callback owned by the scope
+func (s *Scope) NewCallback(fn func([]*Value)) *Callback {
+	c := newCallback(fn, false)
+	s.Add(c)
+	return c
+}
+
+// NewOnceCallback creates a one-shot callback owned by the scope
+func (s *Scope) NewOnceCallback(fn func([]*Value)) *Callback {
+	c := newCallback(fn, true)
+	s.Add(c)
+	return c
+}
+
+// Add hands ownership of an existing callback to the scope.
+// Adding to a scope that was already released releases the callback immediately.
+func (s *Scope) Add(c *Callback) {
+	s.mu.Lock()
+	if s.released {
+		s.mu.Unlock()
+		c.Release()
+		return
+	}
+	c.mu.Lock()
+	if c.released {
+		c.mu.Unlock()
+		s.mu.Unlock()
+		return
+	}
+	c.scope = s
+	c.mu.Unlock()
+	s.callbacks[c] = struct{}{}
+	s.mu.Unlock()
+}
+
+// Len returns the number of live callbacks owned by the scope
+func (s *Scope) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.callbacks)
+}
+
+// Release releases every callback owned by the scope.
+// Callbacks added afterwards are released immediately.
+func (s *Scope) Release() {
+	s.mu.Lock()
+	s.released = true
+	callbacks := s.callbacks
+	s.callbacks = make(map[*Callback]struct{})
+	s.mu.Unlock()
+
+	for c := range callbacks {
+		c.Release()
+	}
+}
+
+func (s *Scope) remove(c *Callback) {
+	s.mu.Lock()
+	delete(s.callbacks, c)
+	s.mu.Unlock()
+}
@@ -0,0 +1,116 @@
+//go:build js && wasm
+// +build js,wasm
+
+package js
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestCallbacks(t *testing.T) {
+	fmt.Println("Starting callback lifecycle tests...")
+
+	tests := []struct {
+		name     string
+		validate func() error
+	}{
+		{
+			name: "Release decrements the live counter once",
+			validate: func() error {
+				before := LiveCallbacks()
+				cb := NewCallback(func(args []*Value) {})
+				if LiveCallbacks() != before+1 {
+					return fmt.Errorf("expected %d live callbacks, got %d", before+1, LiveCallbacks())
+				}
+				cb.Release()
+				cb.Release()
+				if LiveCallbacks() != before {
+					return fmt.Errorf("expected %d live callbacks after release, got %d", before, LiveCallbacks())
+				}
+				if !cb.Released() {
+					return fmt.Errorf("callback should report itself as released")
+				}
+				return nil
+			},
+		},
+		{
+			name: "Callbacks can be passed to JavaScript",
+			validate: func() error {
+				var got int
+				cb := NewCallback(func(args []*Value) {
+					got = args[0].MustInt()
+				})
+				defer cb.Release()
+				cb.Value().Raw().Invoke(7)
+				obj := Global().Call("Object")
+				obj.Set("fn", cb)
+				obj.Call("fn", 9)
+				if got != 9 {
+					return fmt.Errorf("expected 9, got %d", got)
+				}
+				return nil
+			},
+		},
+		{
+			name: "One-shot callbacks release themselves",
+			validate: func() error {
+				before := LiveCallbacks()
+				calls := 0
+				cb := NewOnceCallback(func(args []*Value) {
+					calls++
+				})
+				cb.Value().Raw().Invoke()
+				if calls != 1 {
+					return fmt.Errorf("expected 1 call, got %d", calls)
+				}
+				if !cb.Released() || LiveCallbacks() != before {
+					return fmt.Errorf("one-shot callback should be released after first call")
+				}
+				return nil
+			},
+		},
+		{
+			name: "Scope releases all of its callbacks",
+			validate: func() error {
+				before := LiveCallbacks()
+				scope := NewScope()
+				scope.NewCallback(func(args []*Value) {})
+				once := scope.NewOnceCallback(func(args []*Value) {})
+				standalone := NewCallback(func(args []*Value) {})
+				scope.Add(standalone)
+				if scope.Len() != 3 || LiveCallbacks() != before+3 {
+					return fmt.Errorf("expected 3 callbacks in scope, got %d", scope.Len())
+				}
+
+				once.Value().Raw().Invoke()
+				if scope.Len() != 2 {
+					return fmt.Errorf("released callbacks should leave the scope, got %d", scope.Len())
+				}
+
+				scope.Release()
+				if scope.Len() != 0 || LiveCallbacks() != before {
+					return fmt.Errorf("scope release leaked %d callbacks", LiveCallbacks()-before)
+				}
+
+				late := scope.NewCallback(func(args []*Value) {})
+				if !late.Released() || LiveCallbacks() != before {
+					return fmt.Errorf("callbacks added to a released scope should be released")
+				}
+				return nil
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fmt.Printf("Running test: %s\n", tt.name)
+			if err := tt.validate(); err != nil {
+				t.Errorf("test failed: %v", err)
+				fmt.Printf("❌ Test failed: %s - %v\n", tt.name, err)
+			} else {
+				fmt.Printf("✅ Test passed: %s\n", tt.name)
+			}
+		})
+	}
+}
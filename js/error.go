@@ -0,0 +1,114 @@
+//go:build js && wasm
+// +build js,wasm
+
+package js
+
+import (
+	"errors"
+	"strings"
+	"syscall/js"
+)
+
+// Error is a JavaScript exception converted into a Go error
+type Error struct {
+	// Name is the error name, e.g. "TypeError" or "SyntaxError"
+	Name string
+	// Message is the error message
+	Message string
+	// Stack is the JavaScript stack trace, if available
+	Stack string
+	// Value is the thrown JavaScript value
+	Value *Value
+}
+
+// Error implements the error interface
+func (e *Error) Error() string {
+	if e.Name == "" {
+		return e.Message
+	}
+	if e.Message == "" {
+		return e.Name
+	}
+	return e.Name + ": " + e.Message
+}
+
+// newError builds an *Error from a thrown JavaScript value
+func newError(thrown js.Value) *Error {
+	e := &Error{Value: &Value{value: thrown}}
+	if thrown.Type() != js.TypeObject {
+		e.Message = js.Global().Call("String", thrown).String()
+		return e
+	}
+	if name := thrown.Get("name"); name.Type() == js.TypeString {
+		e.Name = name.String()
+	}
+	if message := thrown.Get("message"); message.Type() == js.TypeString {
+		e.Message = message.String()
+	}
+	if stack := thrown.Get("stack"); stack.Type() == js.TypeString {
+		e.Stack = stack.String()
+	}
+	return e
+}
+
+// catch recovers a panic raised by syscall/js and stores it in err.
+// Panics that did not originate from JavaScript are re-raised.
+func catch(err *error) {
+	r := recover()
+	if r == nil {
+		return
+	}
+	switch e := r.(type) {
+	case js.Error:
+		*err = newError(e.Value)
+	case *js.ValueError:
+		*err = e
+	case string:
+		if !strings.HasPrefix(e, "ValueOf:") && !strings.HasPrefix(e, "syscall/js:") {
+			panic(r)
+		}
+		*err = errors.New(e)
+	default:
+		panic(r)
+	}
+}
+
+// GetE returns a property of the JavaScript value, returning an error if a getter throws
+func (v *Value) GetE(name string) (result *Value, err error) {
+	defer catch(&err)
+	if t := v.value.Type(); t != js.TypeObject && t != js.TypeFunction {
+		return &Value{value: v.value.Get(name)}, nil
+	}
+	// Property access goes through Reflect.get because syscall/js only catches
+	// exceptions thrown by calls, not by getters
+	return &Value{value: js.Global().Get("Reflect").Call("get", v.value, name)}, nil
+}
+
+// SetE sets a property of the JavaScript value, returning an error if a setter throws
+func (v *Value) SetE(name string, value interface{}) (err error) {
+	defer catch(&err)
+	if t := v.value.Type(); t != js.TypeObject && t != js.TypeFunction {
+		v.value.Set(name, unwrap(value))
+		return nil
+	}
+	js.Global().Get("Reflect").Call("set", v.value, name, unwrap(value))
+	return nil
+}
+
+// CallE calls a method of the JavaScript value, returning an error if it throws
+func (v *Value) CallE(method string, args ...interface{}) (result *Value, err error) {
+	defer catch(&err)
+	return &Value{value: v.value.Call(method, unwrapArgs(args)...)}, nil
+}
+
+// InvokeE calls the JavaScript value as a function, returning an error if it throws
+func (v *Value) InvokeE(args ...interface{}) (result *Value, err error) {
+	defer catch(&err)
+	return &Value{value: v.value.Invoke(unwrapArgs(args)...)}, nil
+}
+
+// NewE uses the JavaScript value as a constructor, returning an error if it throws
+func (v *Value) NewE(args ...interface{}) (result *Value, err error) {
+	defer catch(&err)
+	return &Value{value: v.value.New(unwrapArgs(args)...)}, nil
+}
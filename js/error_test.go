@@ -0,0 +1,124 @@
+//go:build js && wasm
+// +build js,wasm
+
+package js
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestErrors(t *testing.T) {
+	fmt.Println("Starting JavaScript exception tests...")
+
+	throwing := func(body string) *Value {
+		return Global().Call("Function", body)
+	}
+
+	tests := []struct {
+		name     string
+		validate func() error
+	}{
+		{
+			name: "CallE on missing method returns an error",
+			validate: func() error {
+				if _, err := Global().Call("Object").CallE("missing"); err == nil {
+					return fmt.Errorf("expected error calling a missing method")
+				}
+				return nil
+			},
+		},
+		{
+			name: "CallE converts thrown TypeError",
+			validate: func() error {
+				_, err := Global().Get("Object").CallE("defineProperty", 1, "x", Global().Call("Object"))
+				var jsErr *Error
+				if !errors.As(err, &jsErr) {
+					return fmt.Errorf("expected *Error, got %T: %v", err, err)
+				}
+				if jsErr.Name != "TypeError" {
+					return fmt.Errorf("expected TypeError, got %q", jsErr.Name)
+				}
+				if jsErr.Message == "" || jsErr.Stack == "" {
+					return fmt.Errorf("message and stack should be populated: %+v", jsErr)
+				}
+				return nil
+			},
+		},
+		{
+			name: "CallE returns the result when nothing throws",
+			validate: func() error {
+				result, err := Global().Get("Math").CallE("max", 1, 3, 2)
+				if err != nil {
+					return err
+				}
+				if result.MustInt() != 3 {
+					return fmt.Errorf("expected 3, got %v", result.TryInt(0))
+				}
+				return nil
+			},
+		},
+		{
+			name: "GetE and SetE catch throwing accessors",
+			validate: func() error {
+				obj := Global().Call("Object")
+				descriptor := Global().Call("Object")
+				descriptor.Set("get", throwing("throw new RangeError('bad get')"))
+				descriptor.Set("set", throwing("throw new RangeError('bad set')"))
+				Global().Get("Object").Call("defineProperty", obj, "prop", descriptor)
+
+				if _, err := obj.GetE("prop"); err == nil || err.Error() != "RangeError: bad get" {
+					return fmt.Errorf("unexpected GetE error: %v", err)
+				}
+				if err := obj.SetE("prop", 1); err == nil || err.Error() != "RangeError: bad set" {
+					return fmt.Errorf("unexpected SetE error: %v", err)
+				}
+				return nil
+			},
+		},
+		{
+			name: "InvokeE and NewE catch exceptions",
+			validate: func() error {
+				if _, err := throwing("throw 'plain string'").InvokeE(); err == nil || err.Error() != "plain string" {
+					return fmt.Errorf("unexpected InvokeE error: %v", err)
+				}
+				if _, err := Global().Get("Math").Get("max").NewE(); err == nil {
+					return fmt.Errorf("expected error constructing a non-constructor")
+				}
+				date, err := Global().Get("Date").NewE(0)
+				if err != nil {
+					return err
+				}
+				if date.Call("getTime").MustInt() != 0 {
+					return fmt.Errorf("NewE should construct the object")
+				}
+				return nil
+			},
+		},
+		{
+			name: "Rejected promises unwrap to *Error",
+			validate: func() error {
+				_, err := Global().Get("Promise").Call("reject", Global().Get("SyntaxError").Call("call", nil, "oops")).Await(context.Background())
+				var jsErr *Error
+				if !errors.As(err, &jsErr) || jsErr.Name != "SyntaxError" {
+					return fmt.Errorf("expected SyntaxError, got %v", err)
+				}
+				return nil
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fmt.Printf("Running test: %s\n", tt.name)
+			if err := tt.validate(); err != nil {
+				t.Errorf("test failed: %v", err)
+				fmt.Printf("❌ Test failed: %s - %v\n", tt.name, err)
+			} else {
+				fmt.Printf("✅ Test passed: %s\n", tt.name)
+			}
+		})
+	}
+}
@@ -0,0 +1,743 @@
+//go:build js && wasm
+// +build js,wasm
+
+package js
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall/js"
+	"time"
+)
+
+// Marshaler is implemented by types that can convert themselves into a JavaScript value
+type Marshaler interface {
+	MarshalJS() (*Value, error)
+}
+
+// Unmarshaler is implemented by types that can populate themselves from a JavaScript value
+type Unmarshaler interface {
+	UnmarshalJS(v *Value) error
+}
+
+// MarshalError describes a Go value that could not be converted to JavaScript
+type MarshalError struct {
+	Path string
+	Msg  string
+}
+
+// Error implements the error interface
+func (e *MarshalError) Error() string {
+	if e.Path == "" {
+		return e.Msg
+	}
+	return e.Path + ": " + e.Msg
+}
+
+// DecodeError describes a JavaScript value that could not be stored in the Go target
+type DecodeError struct {
+	Path string
+	Msg  string
+}
+
+// Error implements the error interface
+func (e *DecodeError) Error() string {
+	if e.Path == "" {
+		return e.Msg
+	}
+	return e.Path + ": " + e.Msg
+}
+
+var (
+	valueType       = reflect.TypeOf((*Value)(nil))
+	rawValueType    = reflect.TypeOf(js.Value{})
+	funcType        = reflect.TypeOf(js.Func{})
+	timeType        = reflect.TypeOf(time.Time{})
+	marshalerType   = reflect.TypeOf((*Marshaler)(nil)).Elem()
+	unmarshalerType = reflect.TypeOf((*Unmarshaler)(nil)).Elem()
+)
+
+// Marshal converts a Go value into a JavaScript value without a JSON round-trip.
+//
+// Structs become plain objects keyed by their `js` struct tag (falling back to
+// the `json` tag and then the field name), maps with string or integer keys
+// become objects, slices and arrays become arrays, []byte becomes a Uint8Array
+// and time.Time becomes a Date. *Value, js.Value and js.Func are passed through
+// unchanged, so existing JavaScript objects and callbacks can be embedded.
+func Marshal(v interface{}) (*Value, error) {
+	e := &encoder{seen: make(map[interface{}]bool)}
+	value, err := e.encode(reflect.ValueOf(v), "")
+	if err != nil {
+		return nil, err
+	}
+	return &Value{value: value}, nil
+}
+
+// MustMarshal converts a Go value into a JavaScript value, panicking on error
+func MustMarshal(v interface{}) *Value {
+	value, err := Marshal(v)
+	if err != nil {
+		panic(fmt.Sprintf("MustMarshal failed: %v", err))
+	}
+	return value
+}
+
+// Decode stores the JavaScript value in the Go value pointed to by target.
+//
+// It is the inverse of Marshal and walks the target with reflection instead of
+// going through JSON, so undefined properties leave fields untouched, Dates
+// decode into time.Time, typed arrays into []byte and functions into *Value.
+// Errors report the path of the offending field, e.g.
+// "user.address[2].zip: expected number, got string".
+func (v *Value) Decode(target interface{}) error {
+	rv := reflect.ValueOf(target)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return &DecodeError{Msg: fmt.Sprintf("target must be a non-nil pointer, got %T", target)}
+	}
+	d := &decoder{}
+	return d.decode(v.value, rv.Elem(), "")
+}
+
+// encoder holds the state of a single Marshal call
+type encoder struct {
+	// seen holds the pointers, maps and slices currently being encoded, used
+	// to detect cycles
+	seen map[interface{}]bool
+}
+
+// sliceKey identifies a slice in encoder.seen. Slices of different lengths
+// may share a backing array, so like encoding/json the length is part of it.
+type sliceKey struct {
+	ptr uintptr
+	len int
+}
+
+// enter marks a value as being encoded until the returned function is called.
+// It fails if the value is already being encoded, because it contains itself.
+func (e *encoder) enter(key interface{}, rv reflect.Value, path string) (func(), error) {
+	if e.seen[key] {
+		return nil, &MarshalError{Path: path, Msg: fmt.Sprintf("cycle detected through %s", rv.Type())}
+	}
+	e.seen[key] = true
+	return func() { delete(e.seen, key) }, nil
+}
+
+func (e *encoder) encode(rv reflect.Value, path string) (js.Value, error) {
+	if !rv.IsValid() {
+		return js.Null(), nil
+	}
+
+	switch rv.Type() {
+	case valueType:
+		if rv.IsNil() {
+			return js.Null(), nil
+		}
+		return rv.Interface().(*Value).value, nil
+	case rawValueType:
+		return rv.Interface().(js.Value), nil
+	case funcType:
+		return rv.Interface().(js.Func).Value, nil
+	case timeType:
+		t := rv.Interface().(time.Time)
+		return js.Global().Get("Date").New(float64(t.UnixMilli())), nil
+	}
+
+	if rv.Type().Implements(marshalerType) {
+		if rv.Kind() == reflect.Ptr && rv.IsNil() {
+			return js.Null(), nil
+		}
+		value, err := rv.Interface().(Marshaler).MarshalJS()
+		if err != nil {
+			return js.Undefined(), &MarshalError{Path: path, Msg: err.Error()}
+		}
+		if value == nil {
+			return js.Null(), nil
+		}
+		return value.value, nil
+	}
+
+	switch rv.Kind() {
+	case reflect.Bool:
+		return js.ValueOf(rv.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return js.ValueOf(rv.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return js.ValueOf(rv.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		return js.ValueOf(rv.Float()), nil
+	case reflect.String:
+		return js.ValueOf(rv.String()), nil
+	case reflect.Interface:
+		if rv.IsNil() {
+			return js.Null(), nil
+		}
+		return e.encode(rv.Elem(), path)
+	case reflect.Ptr:
+		if rv.IsNil() {
+			return js.Null(), nil
+		}
+		leave, err := e.enter(rv.Pointer(), rv, path)
+		if err != nil {
+			return js.Undefined(), err
+		}
+		defer leave()
+		return e.encode(rv.Elem(), path)
+	case reflect.Slice:
+		if rv.IsNil() {
+			return js.Null(), nil
+		}
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			return encodeBytes(rv.Bytes()), nil
+		}
+		leave, err := e.enter(sliceKey{rv.Pointer(), rv.Len()}, rv, path)
+		if err != nil {
+			return js.Undefined(), err
+		}
+		defer leave()
+		return e.encodeArray(rv, path)
+	case reflect.Array:
+		return e.encodeArray(rv, path)
+	case reflect.Map:
+		if rv.IsNil() {
+			return js.Null(), nil
+		}
+		leave, err := e.enter(rv.Pointer(), rv, path)
+		if err != nil {
+			return js.Undefined(), err
+		}
+		defer leave()
+		return e.encodeMap(rv, path)
+	case reflect.Struct:
+		return e.encodeStruct(rv, path)
+	}
+
+	return js.Undefined(), &MarshalError{Path: path, Msg: fmt.Sprintf("unsupported type %s", rv.Type())}
+}
+
+func (e *encoder) encodeArray(rv reflect.Value, path string) (js.Value, error) {
+	arr := js.Global().Get("Array").New(rv.Len())
+	for i := 0; i < rv.Len(); i++ {
+		elem, err := e.encode(rv.Index(i), indexPath(path, i))
+		if err != nil {
+			return js.Undefined(), err
+		}
+		arr.SetIndex(i, elem)
+	}
+	return arr, nil
+}
+
+func (e *encoder) encodeMap(rv reflect.Value, path string) (js.Value, error) {
+	obj := js.Global().Get("Object").New()
+	iter := rv.MapRange()
+	for iter.Next() {
+		key, err := mapKeyString(iter.Key())
+		if err != nil {
+			return js.Undefined(), &MarshalError{Path: path, Msg: err.Error()}
+		}
+		elem, err := e.encode(iter.Value(), fieldPath(path, key))
+		if err != nil {
+			return js.Undefined(), err
+		}
+		obj.Set(key, elem)
+	}
+	return obj, nil
+}
+
+func (e *encoder) encodeStruct(rv reflect.Value, path string) (js.Value, error) {
+	obj := js.Global().Get("Object").New()
+	for _, f := range cachedFields(rv.Type()) {
+		fv, ok := fieldByIndex(rv, f.index, false)
+		if !ok {
+			continue
+		}
+		if f.omitEmpty && isEmptyValue(fv) {
+			continue
+		}
+		elem, err := e.encode(fv, fieldPath(path, f.name))
+		if err != nil {
+			return js.Undefined(), err
+		}
+		obj.Set(f.name, elem)
+	}
+	return obj, nil
+}
+
+func encodeBytes(b []byte) js.Value {
+	return fromSlice("Uint8Array", b)
+}
+
+func mapKeyString(key reflect.Value) (string, error) {
+	switch key.Kind() {
+	case reflect.String:
+		return key.String(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(key.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(key.Uint(), 10), nil
+	}
+	return "", fmt.Errorf("unsupported map key type %s", key.Type())
+}
+
+// decoder holds the state of a single Decode call
+type decoder struct {
+	// stack holds the objects currently being decoded, used to detect cycles
+	stack []js.Value
+}
+
+func (d *decoder) decode(src js.Value, rv reflect.Value, path string) error {
+	switch rv.Type() {
+	case valueType:
+		rv.Set(reflect.ValueOf(&Value{value: src}))
+		return nil
+	case rawValueType:
+		rv.Set(reflect.ValueOf(src))
+		return nil
+	}
+
+	if src.IsUndefined() {
+		return nil
+	}
+	if src.IsNull() {
+		switch rv.Kind() {
+		case reflect.Ptr, reflect.Map, reflect.Slice, reflect.Interface:
+			rv.Set(reflect.Zero(rv.Type()))
+		}
+		return nil
+	}
+
+	if rv.CanAddr() && reflect.PtrTo(rv.Type()).Implements(unmarshalerType) {
+		if err := rv.Addr().Interface().(Unmarshaler).UnmarshalJS(&Value{value: src}); err != nil {
+			return &DecodeError{Path: path, Msg: err.Error()}
+		}
+		return nil
+	}
+
+	if rv.Type() == timeType {
+		return d.decodeTime(src, rv, path)
+	}
+
+	switch rv.Kind() {
+	case reflect.Ptr:
+		if rv.IsNil() {
+			rv.Set(reflect.New(rv.Type().Elem()))
+		}
+		return d.decode(src, rv.Elem(), path)
+	case reflect.Interface:
+		if rv.NumMethod() != 0 {
+			return &DecodeError{Path: path, Msg: fmt.Sprintf("cannot decode into non-empty interface %s", rv.Type())}
+		}
+		value, err := d.decodeAny(src, path)
+		if err != nil {
+			return err
+		}
+		if value == nil {
+			rv.Set(reflect.Zero(rv.Type()))
+		} else {
+			rv.Set(reflect.ValueOf(value))
+		}
+		return nil
+	case reflect.Bool:
+		if src.Type() != js.TypeBoolean {
+			return typeError(path, "boolean", src)
+		}
+		rv.SetBool(src.Bool())
+		return nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if src.Type() != js.TypeNumber {
+			return typeError(path, "number", src)
+		}
+		f := src.Float()
+		if f != math.Trunc(f) || rv.OverflowInt(int64(f)) {
+			return &DecodeError{Path: path, Msg: fmt.Sprintf("number %v does not fit in %s", f, rv.Type())}
+		}
+		rv.SetInt(int64(f))
+		return nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if src.Type() != js.TypeNumber {
+			return typeError(path, "number", src)
+		}
+		f := src.Float()
+		if f != math.Trunc(f) || f < 0 || rv.OverflowUint(uint64(f)) {
+			return &DecodeError{Path: path, Msg: fmt.Sprintf("number %v does not fit in %s", f, rv.Type())}
+		}
+		rv.SetUint(uint64(f))
+		return nil
+	case reflect.Float32, reflect.Float64:
+		if src.Type() != js.TypeNumber {
+			return typeError(path, "number", src)
+		}
+		rv.SetFloat(src.Float())
+		return nil
+	case reflect.String:
+		if src.Type() != js.TypeString {
+			return typeError(path, "string", src)
+		}
+		rv.SetString(src.String())
+		return nil
+	case reflect.Slice:
+		if rv.Type().Elem().Kind() == reflect.Uint8 && isByteArray(src) {
+			b := make([]byte, src.Length())
+			js.CopyBytesToGo(b, src)
+			rv.SetBytes(b)
+			return nil
+		}
+		return d.decodeArray(src, rv, path)
+	case reflect.Array:
+		return d.decodeArray(src, rv, path)
+	case reflect.Map:
+		return d.decodeMap(src, rv, path)
+	case reflect.Struct:
+		return d.decodeStruct(src, rv, path)
+	}
+
+	return &DecodeError{Path: path, Msg: fmt.Sprintf("unsupported type %s", rv.Type())}
+}
+
+func (d *decoder) decodeTime(src js.Value, rv reflect.Value, path string) error {
+	switch {
+	case src.InstanceOf(js.Global().Get("Date")):
+		rv.Set(reflect.ValueOf(timeFromMillis(src.Call("getTime").Float())))
+	case src.Type() == js.TypeNumber:
+		rv.Set(reflect.ValueOf(timeFromMillis(src.Float())))
+	case src.Type() == js.TypeString:
+		t, err := time.Parse(time.RFC3339Nano, src.String())
+		if err != nil {
+			return &DecodeError{Path: path, Msg: err.Error()}
+		}
+		rv.Set(reflect.ValueOf(t))
+	default:
+		return typeError(path, "Date", src)
+	}
+	return nil
+}
+
+func (d *decoder) decodeArray(src js.Value, rv reflect.Value, path string) error {
+	if !isArray(src) {
+		return typeError(path, "array", src)
+	}
+	if err := d.push(src, path); err != nil {
+		return err
+	}
+	defer d.pop()
+
+	length := src.Length()
+	if rv.Kind() == reflect.Slice {
+		rv.Set(reflect.MakeSlice(rv.Type(), length, length))
+	} else if length > rv.Len() {
+		length = rv.Len()
+	}
+	for i := 0; i < length; i++ {
+		if err := d.decode(src.Index(i), rv.Index(i), indexPath(path, i)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (d *decoder) decodeMap(src js.Value, rv reflect.Value, path string) error {
+	if src.Type() != js.TypeObject {
+		return typeError(path, "object", src)
+	}
+	if err := d.push(src, path); err != nil {
+		return err
+	}
+	defer d.pop()
+
+	if rv.IsNil() {
+		rv.Set(reflect.MakeMap(rv.Type()))
+	}
+	keyType := rv.Type().Key()
+	keys := js.Global().Get("Object").Call("keys", src)
+	for i := 0; i < keys.Length(); i++ {
+		name := keys.Index(i).String()
+		key := reflect.New(keyType).Elem()
+		if err := setMapKey(key, name); err != nil {
+			return &DecodeError{Path: fieldPath(path, name), Msg: err.Error()}
+		}
+		elem := reflect.New(rv.Type().Elem()).Elem()
+		if err := d.decode(src.Get(name), elem, fieldPath(path, name)); err != nil {
+			return err
+		}
+		rv.SetMapIndex(key, elem)
+	}
+	return nil
+}
+
+func (d *decoder) decodeStruct(src js.Value, rv reflect.Value, path string) error {
+	if src.Type() != js.TypeObject && src.Type() != js.TypeFunction {
+		return typeError(path, "object", src)
+	}
+	if err := d.push(src, path); err != nil {
+		return err
+	}
+	defer d.pop()
+
+	for _, f := range cachedFields(rv.Type()) {
+		prop := src.Get(f.name)
+		if prop.IsUndefined() {
+			continue
+		}
+		fv, ok := fieldByIndex(rv, f.index, true)
+		if !ok {
+			continue
+		}
+		if err := d.decode(prop, fv, fieldPath(path, f.name)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// decodeAny converts a JavaScript value into its natural Go representation
+func (d *decoder) decodeAny(src js.Value, path string) (interface{}, error) {
+	switch src.Type() {
+	case js.TypeUndefined, js.TypeNull:
+		return nil, nil
+	case js.TypeBoolean:
+		return src.Bool(), nil
+	case js.TypeNumber:
+		return src.Float(), nil
+	case js.TypeString:
+		return src.String(), nil
+	case js.TypeObject:
+		switch {
+		case src.InstanceOf(js.Global().Get("Date")):
+			return timeFromMillis(src.Call("getTime").Float()), nil
+		case isByteArray(src):
+			b := make([]byte, src.Length())
+			js.CopyBytesToGo(b, src)
+			return b, nil
+		case isArray(src):
+			var arr []interface{}
+			if err := d.decodeArray(src, reflect.ValueOf(&arr).Elem(), path); err != nil {
+				return nil, err
+			}
+			return arr, nil
+		}
+		var obj map[string]interface{}
+		if err := d.decodeMap(src, reflect.ValueOf(&obj).Elem(), path); err != nil {
+			return nil, err
+		}
+		return obj, nil
+	}
+	return &Value{value: src}, nil
+}
+
+func (d *decoder) push(src js.Value, path string) error {
+	for _, v := range d.stack {
+		if v.Equal(src) {
+			return &DecodeError{Path: path, Msg: "cycle detected"}
+		}
+	}
+	d.stack = append(d.stack, src)
+	return nil
+}
+
+func (d *decoder) pop() {
+	d.stack = d.stack[:len(d.stack)-1]
+}
+
+func setMapKey(key reflect.Value, name string) error {
+	switch key.Kind() {
+	case reflect.String:
+		key.SetString(name)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(name, 10, key.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("invalid %s map key %q", key.Type(), name)
+		}
+		key.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n, err := strconv.ParseUint(name, 10, key.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("invalid %s map key %q", key.Type(), name)
+		}
+		key.SetUint(n)
+	default:
+		return fmt.Errorf("unsupported map key type %s", key.Type())
+	}
+	return nil
+}
+
+func typeError(path, expected string, src js.Value) error {
+	got := src.Type().String()
+	if isArray(src) {
+		got = "array"
+	}
+	return &DecodeError{Path: path, Msg: fmt.Sprintf("expected %s, got %s", expected, got)}
+}
+
+func isArray(v js.Value) bool {
+	return v.Type() == js.TypeObject && js.Global().Get("Array").Call("isArray", v).Bool()
+}
+
+func isByteArray(v js.Value) bool {
+	return v.Type() == js.TypeObject &&
+		(v.InstanceOf(js.Global().Get("Uint8Array")) || v.InstanceOf(js.Global().Get("Uint8ClampedArray")))
+}
+
+// timeFromMillis converts milliseconds since the Unix epoch to a time, going
+// through whole milliseconds so that dates beyond the years 1678 to 2262,
+// which int64 nanoseconds cannot hold, keep their value
+func timeFromMillis(ms float64) time.Time {
+	whole, frac := math.Modf(ms)
+	return time.UnixMilli(int64(whole)).Add(time.Duration(frac * float64(time.Millisecond)))
+}
+
+func fieldPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+func indexPath(path string, i int) string {
+	return path + "[" + strconv.Itoa(i) + "]"
+}
+
+// structField describes how a struct field maps to a JavaScript property
+type structField struct {
+	name      string
+	index     []int
+	omitEmpty bool
+}
+
+var fieldCache sync.Map // map[reflect.Type][]structField
+
+func cachedFields(t reflect.Type) []structField {
+	if fields, ok := fieldCache.Load(t); ok {
+		return fields.([]structField)
+	}
+	fields, _ := fieldCache.LoadOrStore(t, typeFields(t))
+	return fields.([]structField)
+}
+
+// typeFields returns the fields of t, flattening untagged embedded structs.
+// When names collide, the shallowest field wins.
+func typeFields(t reflect.Type) []structField {
+	var fields []structField
+	taken := make(map[string]bool)
+
+	type level struct {
+		typ   reflect.Type
+		index []int
+	}
+	current := []level{{typ: t}}
+	visited := map[reflect.Type]bool{}
+
+	for len(current) > 0 {
+		var next []level
+		depthNames := make(map[string]bool)
+		for _, l := range current {
+			if visited[l.typ] {
+				continue
+			}
+			visited[l.typ] = true
+
+			for i := 0; i < l.typ.NumField(); i++ {
+				sf := l.typ.Field(i)
+				index := append(append([]int(nil), l.index...), i)
+
+				name, opts, tagged := fieldTag(sf)
+				if name == "-" && opts == "" {
+					continue
+				}
+
+				if sf.Anonymous && !tagged {
+					ft := sf.Type
+					if ft.Kind() == reflect.Ptr {
+						ft = ft.Elem()
+					}
+					if ft.Kind() == reflect.Struct {
+						next = append(next, level{typ: ft, index: index})
+						continue
+					}
+				}
+				if !sf.IsExported() {
+					continue
+				}
+				if name == "" {
+					name = sf.Name
+				}
+				if taken[name] || depthNames[name] {
+					continue
+				}
+				depthNames[name] = true
+				fields = append(fields, structField{
+					name:      name,
+					index:     index,
+					omitEmpty: hasOption(opts, "omitempty"),
+				})
+			}
+		}
+		for name := range depthNames {
+			taken[name] = true
+		}
+		current = next
+	}
+	return fields
+}
+
+// fieldTag reads the `js` tag of a field, falling back to the `json` tag
+func fieldTag(sf reflect.StructField) (name, opts string, tagged bool) {
+	tag, ok := sf.Tag.Lookup("js")
+	if !ok {
+		tag, ok = sf.Tag.Lookup("json")
+	}
+	if !ok {
+		return "", "", false
+	}
+	name, opts, _ = strings.Cut(tag, ",")
+	return name, opts, name != ""
+}
+
+func hasOption(opts, option string) bool {
+	for opts != "" {
+		var opt string
+		opt, opts, _ = strings.Cut(opts, ",")
+		if opt == option {
+			return true
+		}
+	}
+	return false
+}
+
+// fieldByIndex walks an embedded field path. When alloc is set, nil embedded
+// pointers are allocated; otherwise a nil pointer reports ok == false.
+func fieldByIndex(rv reflect.Value, index []int, alloc bool) (reflect.Value, bool) {
+	for i, x := range index {
+		if i > 0 && rv.Kind() == reflect.Ptr {
+			if rv.IsNil() {
+				if !alloc || !rv.CanSet() {
+					return reflect.Value{}, false
+				}
+				rv.Set(reflect.New(rv.Type().Elem()))
+			}
+			rv = rv.Elem()
+		}
+		rv = rv.Field(x)
+	}
+	return rv, true
+}
+
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool:
+		return !v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return v.Uint() == 0
+	case reflect.Float32, reflect.Float64:
+		return v.Float() == 0
+	case reflect.Interface, reflect.Ptr:
+		return v.IsNil()
+	}
+	return false
+}
@@ -0,0 +1,256 @@
+//go:build js && wasm
+// +build js,wasm
+
+package js
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+type testAddress struct {
+	Street string `js:"street"`
+	Zip    int    `js:"zip"`
+}
+
+type testUser struct {
+	Name     string            `js:"name"`
+	Email    string            `json:"email"`
+	Age      int               `js:"age,omitempty"`
+	Tags     []string          `js:"tags"`
+	Address  []testAddress     `js:"address"`
+	Meta     map[string]int    `js:"meta"`
+	Avatar   []byte            `js:"avatar"`
+	Joined   time.Time         `js:"joined"`
+	Extra    interface{}       `js:"extra"`
+	Callback *Value            `js:"callback"`
+	Ignored  string            `js:"-"`
+	Nested   *testUser         `js:"nested,omitempty"`
+	Labels   map[string]string `js:"labels,omitempty"`
+}
+
+func TestMarshalDecode(t *testing.T) {
+	fmt.Println("Starting Marshal/Decode tests...")
+
+	joined := time.Date(2024, 5, 1, 12, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		validate func() error
+	}{
+		{
+			name: "Marshal struct honors js and json tags",
+			validate: func() error {
+				v, err := Marshal(testUser{
+					Name:    "Ada",
+					Email:   "ada@example.com",
+					Tags:    []string{"admin"},
+					Ignored: "secret",
+				})
+				if err != nil {
+					return err
+				}
+				if v.Get("name").MustString() != "Ada" {
+					return fmt.Errorf("name property mismatch")
+				}
+				if v.Get("email").MustString() != "ada@example.com" {
+					return fmt.Errorf("json tag fallback not honored")
+				}
+				if v.Exists("age") {
+					return fmt.Errorf("omitempty field should be omitted")
+				}
+				if v.Exists("Ignored") || v.Exists("-") {
+					return fmt.Errorf("ignored field should not be marshalled")
+				}
+				if v.Get("tags").MustLength() != 1 {
+					return fmt.Errorf("tags should be an array of length 1")
+				}
+				if !v.Get("avatar").IsNull() {
+					return fmt.Errorf("nil slice should marshal to null")
+				}
+				return nil
+			},
+		},
+		{
+			name: "Round trip keeps dates, bytes and nested values",
+			validate: func() error {
+				in := testUser{
+					Name:    "Grace",
+					Age:     85,
+					Address: []testAddress{{Street: "Main", Zip: 12345}},
+					Meta:    map[string]int{"visits": 3},
+					Avatar:  []byte{1, 2, 3},
+					Joined:  joined,
+					Extra:   map[string]interface{}{"flag": true},
+				}
+				v, err := Marshal(in)
+				if err != nil {
+					return err
+				}
+				if !v.Get("joined").Raw().InstanceOf(Global().Get("Date").Raw()) {
+					return fmt.Errorf("time.Time should marshal to a Date")
+				}
+				if !v.Get("avatar").Raw().InstanceOf(Global().Get("Uint8Array").Raw()) {
+					return fmt.Errorf("[]byte should marshal to a Uint8Array")
+				}
+
+				var out testUser
+				if err := v.Decode(&out); err != nil {
+					return err
+				}
+				if out.Name != in.Name || out.Age != in.Age || out.Meta["visits"] != 3 {
+					return fmt.Errorf("scalar fields mismatch: %+v", out)
+				}
+				if len(out.Address) != 1 || out.Address[0].Zip != 12345 {
+					return fmt.Errorf("nested slice mismatch: %+v", out.Address)
+				}
+				if string(out.Avatar) != "\x01\x02\x03" {
+					return fmt.Errorf("bytes mismatch: %v", out.Avatar)
+				}
+				if !out.Joined.Equal(joined) {
+					return fmt.Errorf("date mismatch: %v", out.Joined)
+				}
+				extra, ok := out.Extra.(map[string]interface{})
+				if !ok || extra["flag"] != true {
+					return fmt.Errorf("interface field mismatch: %#v", out.Extra)
+				}
+				return nil
+			},
+		},
+		{
+			name: "Dates beyond the range of nanoseconds round trip",
+			validate: func() error {
+				for _, in := range []time.Time{
+					time.Date(1000, 1, 1, 0, 0, 0, 0, time.UTC),
+					time.Date(1969, 12, 31, 23, 59, 59, 999e6, time.UTC),
+					time.Date(3000, 6, 15, 8, 0, 0, 250e6, time.UTC),
+				} {
+					v, err := Marshal(in)
+					if err != nil {
+						return err
+					}
+					if got := v.Call("toISOString").MustString(); got != in.Format("2006-01-02T15:04:05.000Z") {
+						return fmt.Errorf("%v marshalled to %s", in, got)
+					}
+					var out time.Time
+					if err := v.Decode(&out); err != nil {
+						return err
+					}
+					if !out.Equal(in) {
+						return fmt.Errorf("%v decoded to %v", in, out)
+					}
+				}
+				return nil
+			},
+		},
+		{
+			name: "Decode keeps functions and leaves undefined fields untouched",
+			validate: func() error {
+				obj := Global().Call("Object")
+				obj.Set("name", "Linus")
+				obj.Set("callback", Global().Get("Math").Get("max"))
+
+				out := testUser{Email: "kept@example.com"}
+				if err := obj.Decode(&out); err != nil {
+					return err
+				}
+				if out.Email != "kept@example.com" {
+					return fmt.Errorf("undefined property should leave field untouched")
+				}
+				if out.Callback == nil || out.Callback.Type().String() != "function" {
+					return fmt.Errorf("function should decode into *Value")
+				}
+				return nil
+			},
+		},
+		{
+			name: "Decode reports field paths",
+			validate: func() error {
+				v, err := Marshal(map[string]interface{}{
+					"user": map[string]interface{}{
+						"address": []interface{}{
+							map[string]interface{}{"zip": 1},
+							map[string]interface{}{"zip": 2},
+							map[string]interface{}{"zip": "oops"},
+						},
+					},
+				})
+				if err != nil {
+					return err
+				}
+				var out struct {
+					User struct {
+						Address []testAddress `js:"address"`
+					} `js:"user"`
+				}
+				err = v.Decode(&out)
+				if err == nil {
+					return fmt.Errorf("expected an error")
+				}
+				if err.Error() != "user.address[2].zip: expected number, got string" {
+					return fmt.Errorf("unexpected error: %v", err)
+				}
+				return nil
+			},
+		},
+		{
+			name: "Cycles are reported instead of recursing forever",
+			validate: func() error {
+				cyclic := &testUser{Name: "loop"}
+				cyclic.Nested = cyclic
+				if _, err := Marshal(cyclic); err == nil || !strings.Contains(err.Error(), "cycle") {
+					return fmt.Errorf("expected cycle error from Marshal, got %v", err)
+				}
+				m := map[string]interface{}{}
+				m["self"] = m
+				if _, err := Marshal(m); err == nil || !strings.Contains(err.Error(), "cycle") {
+					return fmt.Errorf("expected cycle error for a map, got %v", err)
+				}
+				s := []interface{}{nil}
+				s[0] = s
+				if _, err := Marshal(s); err == nil || !strings.Contains(err.Error(), "cycle") {
+					return fmt.Errorf("expected cycle error for a slice, got %v", err)
+				}
+				shared := []int{1}
+				if _, err := Marshal([]interface{}{shared, shared}); err != nil {
+					return fmt.Errorf("a slice used twice is not a cycle: %v", err)
+				}
+
+				obj := Global().Call("Object")
+				obj.Set("self", obj)
+				var out interface{}
+				if err := obj.Decode(&out); err == nil || !strings.Contains(err.Error(), "cycle") {
+					return fmt.Errorf("expected cycle error from Decode, got %v", err)
+				}
+				return nil
+			},
+		},
+		{
+			name: "Unsupported and invalid targets return errors",
+			validate: func() error {
+				if _, err := Marshal(map[string]interface{}{"fn": func() {}}); err == nil {
+					return fmt.Errorf("expected error for func value")
+				}
+				var out testUser
+				if err := Global().Decode(out); err == nil {
+					return fmt.Errorf("expected error for non-pointer target")
+				}
+				return nil
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fmt.Printf("Running test: %s\n", tt.name)
+			if err := tt.validate(); err != nil {
+				t.Errorf("test failed: %v", err)
+				fmt.Printf("❌ Test failed: %s - %v\n", tt.name, err)
+			} else {
+				fmt.Printf("✅ Test passed: %s\n", tt.name)
+			}
+		})
+	}
+}
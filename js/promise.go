@@ -0,0 +1,181 @@
+//go:build js && wasm
+// +build js,wasm
+
+package js
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"syscall/js"
+)
+
+// RejectionError is returned by Await when a promise is rejected
+type RejectionError struct {
+	// Reason is the value the promise was rejected with
+	Reason *Value
+}
+
+// Error implements the error interface
+func (e *RejectionError) Error() string {
+	reason := e.Reason.value
+	if reason.Type() == js.TypeObject && reason.Get("message").Type() == js.TypeString {
+		name := reason.Get("name")
+		if name.Type() == js.TypeString && name.String() != "" {
+			return fmt.Sprintf("promise rejected: %s: %s", name.String(), reason.Get("message").String())
+		}
+		return fmt.Sprintf("promise rejected: %s", reason.Get("message").String())
+	}
+	if reason.Type() == js.TypeString {
+		return fmt.Sprintf("promise rejected: %s", reason.String())
+	}
+	return fmt.Sprintf("promise rejected: %s", js.Global().Call("String", reason).String())
+}
+
+// Unwrap returns the reason as an *Error when the promise was rejected with a JavaScript Error
+func (e *RejectionError) Unwrap() error {
+	reason := e.Reason.value
+	if reason.Type() != js.TypeObject || !reason.InstanceOf(js.Global().Get("Error")) {
+		return nil
+	}
+	return newError(reason)
+}
+
+// IsThenable reports whether the value is a promise or any other object with a then method
+func (v *Value) IsThenable() bool {
+	t := v.Type()
+	if t != js.TypeObject && t != js.TypeFunction {
+		return false
+	}
+	return v.value.Get("then").Type() == js.TypeFunction
+}
+
+// Await blocks the calling goroutine until the promise settles and returns its
+// fulfillment value. A rejection is returned as a *RejectionError carrying the
+// JavaScript reason. Values that are not thenable are returned as-is.
+//
+// Await waits for the JavaScript event loop, so it must not be called from the
+// goroutine running a callback invoked by JavaScript; start a new goroutine instead.
+func (v *Value) Await(ctx context.Context) (*Value, error) {
+	if !v.IsThenable() {
+		return v, nil
+	}
+
+	type outcome struct {
+		value js.Value
+		err   error
+	}
+	done := make(chan outcome, 1)
+
+	// Both callbacks are released by whichever one runs first, or when ctx is
+	// done. The promise keeps its handlers until it settles, so it is given
+	// relays that stop calling the callbacks once they are released.
+	scope := NewScope()
+	settle := func(o outcome) {
+		scope.Release()
+		done <- o
+	}
+	onFulfilled := scope.NewCallback(func(args []*Value) {
+		settle(outcome{value: firstArg(args)})
+	})
+	onRejected := scope.NewCallback(func(args []*Value) {
+		settle(outcome{err: &RejectionError{Reason: &Value{value: firstArg(args)}}})
+	})
+	relay := newRelay().Invoke(onFulfilled.fn, onRejected.fn)
+	v.value.Call("then", relay.Index(0), relay.Index(1))
+
+	select {
+	case o := <-done:
+		if o.err != nil {
+			return nil, o.err
+		}
+		return &Value{value: o.value}, nil
+	case <-ctx.Done():
+		relay.Index(2).Invoke()
+		scope.Release()
+		return nil, ctx.Err()
+	}
+}
+
+// newRelay returns a JavaScript function that takes the handlers of a
+// promise and returns relays to them, along with a function detaching the
+// relays, after which they do nothing and no longer reference the handlers
+var newRelay = sync.OnceValue(func() js.Value {
+	return js.Global().Get("Function").New("onFulfilled", "onRejected", `
+		let handlers = [onFulfilled, onRejected];
+		return [
+			(value) => { if (handlers) handlers[0](value); },
+			(reason) => { if (handlers) handlers[1](reason); },
+			() => { handlers = null; },
+		];
+	`)
+})
+
+// NewPromise returns a JavaScript Promise that runs fn in a new goroutine.
+// The promise is fulfilled with the result converted by Marshal, or rejected
+// with a JavaScript Error carrying the error message.
+func NewPromise(fn func(ctx context.Context) (interface{}, error)) *Value {
+	return NewPromiseContext(context.Background(), fn)
+}
+
+// NewPromiseContext is like NewPromise but derives the context passed to fn
+// from ctx. When ctx is cancelled the promise is rejected right away with the
+// context error and fn observes the cancellation through its own context.
+func NewPromiseContext(ctx context.Context, fn func(ctx context.Context) (interface{}, error)) *Value {
+	executor := NewCallback(func(args []*Value) {
+		resolve, reject := args[0].value, args[1].value
+		go func() {
+			ctx, cancel := context.WithCancel(ctx)
+			defer cancel()
+
+			type outcome struct {
+				result interface{}
+				err    error
+			}
+			done := make(chan outcome, 1)
+			go func() {
+				result, err := fn(ctx)
+				done <- outcome{result: result, err: err}
+			}()
+
+			var o outcome
+			select {
+			case o = <-done:
+			case <-ctx.Done():
+				o.err = ctx.Err()
+			}
+			if o.err != nil {
+				reject.Invoke(errorValue(o.err))
+				return
+			}
+			value, err := Marshal(o.result)
+			if err != nil {
+				reject.Invoke(errorValue(err))
+				return
+			}
+			resolve.Invoke(value.value)
+		}()
+	})
+	// The executor runs synchronously inside the Promise constructor
+	defer executor.Release()
+
+	return &Value{value: js.Global().Get("Promise").New(executor.fn)}
+}
+
+// errorValue converts a Go error into the JavaScript value used to reject a promise
+func errorValue(err error) js.Value {
+	switch e := err.(type) {
+	case *RejectionError:
+		return e.Reason.value
+	case *Error:
+		return e.Value.value
+	}
+	return js.Global().Get("Error").New(err.Error())
+}
+
+func firstArg(args []*Value) js.Value {
+	if len(args) == 0 {
+		return js.Undefined()
+	}
+	return args[0].value
+}
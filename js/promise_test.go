@@ -0,0 +1,161 @@
+//go:build js && wasm
+// +build js,wasm
+
+package js
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestPromises(t *testing.T) {
+	fmt.Println("Starting Promise tests...")
+
+	tests := []struct {
+		name     string
+		validate func() error
+	}{
+		{
+			name: "Await fulfilled promise",
+			validate: func() error {
+				result, err := Global().Get("Promise").Call("resolve", 42).Await(context.Background())
+				if err != nil {
+					return err
+				}
+				if result.MustInt() != 42 {
+					return fmt.Errorf("expected 42, got %v", result.TryInt(0))
+				}
+				return nil
+			},
+		},
+		{
+			name: "Await rejected promise",
+			validate: func() error {
+				reason := Global().Call("Error", "boom")
+				_, err := Global().Get("Promise").Call("reject", reason).Await(context.Background())
+				var rejection *RejectionError
+				if !errors.As(err, &rejection) {
+					return fmt.Errorf("expected *RejectionError, got %T: %v", err, err)
+				}
+				if rejection.Reason.Get("message").MustString() != "boom" {
+					return fmt.Errorf("rejection reason mismatch")
+				}
+				if err.Error() != "promise rejected: Error: boom" {
+					return fmt.Errorf("unexpected error message: %v", err)
+				}
+				return nil
+			},
+		},
+		{
+			name: "Await non-thenable returns value",
+			validate: func() error {
+				v := Global().Get("Math")
+				result, err := v.Await(context.Background())
+				if err != nil {
+					return err
+				}
+				if result != v {
+					return fmt.Errorf("expected the same value back")
+				}
+				return nil
+			},
+		},
+		{
+			name: "Await honors context cancellation",
+			validate: func() error {
+				release := make(chan struct{})
+				defer close(release)
+				pending := NewPromise(func(ctx context.Context) (interface{}, error) {
+					<-release
+					return nil, nil
+				})
+
+				live := LiveCallbacks()
+				ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+				defer cancel()
+				if _, err := pending.Await(ctx); !errors.Is(err, context.DeadlineExceeded) {
+					return fmt.Errorf("expected deadline exceeded, got %v", err)
+				}
+				if n := LiveCallbacks(); n != live {
+					return fmt.Errorf("a cancelled Await should release its callbacks, %d live", n-live)
+				}
+				return nil
+			},
+		},
+		{
+			name: "NewPromise resolves with marshalled value",
+			validate: func() error {
+				promise := NewPromise(func(ctx context.Context) (interface{}, error) {
+					return testAddress{Street: "Main", Zip: 12345}, nil
+				})
+				if !promise.Raw().InstanceOf(Global().Get("Promise").Raw()) {
+					return fmt.Errorf("NewPromise should return a real Promise")
+				}
+				result, err := promise.Await(context.Background())
+				if err != nil {
+					return err
+				}
+				var addr testAddress
+				if err := result.Decode(&addr); err != nil {
+					return err
+				}
+				if addr.Zip != 12345 {
+					return fmt.Errorf("unexpected result: %+v", addr)
+				}
+				return nil
+			},
+		},
+		{
+			name: "NewPromise rejects with Go error",
+			validate: func() error {
+				promise := NewPromise(func(ctx context.Context) (interface{}, error) {
+					return nil, errors.New("failed")
+				})
+				_, err := promise.Await(context.Background())
+				var rejection *RejectionError
+				if !errors.As(err, &rejection) {
+					return fmt.Errorf("expected *RejectionError, got %v", err)
+				}
+				if rejection.Reason.Get("message").MustString() != "failed" {
+					return fmt.Errorf("rejection message mismatch")
+				}
+				return nil
+			},
+		},
+		{
+			name: "NewPromiseContext rejects when cancelled",
+			validate: func() error {
+				ctx, cancel := context.WithCancel(context.Background())
+				observed := make(chan error, 1)
+				promise := NewPromiseContext(ctx, func(ctx context.Context) (interface{}, error) {
+					<-ctx.Done()
+					observed <- ctx.Err()
+					return nil, ctx.Err()
+				})
+				cancel()
+				if _, err := promise.Await(context.Background()); err == nil {
+					return fmt.Errorf("expected rejection after cancel")
+				}
+				if err := <-observed; !errors.Is(err, context.Canceled) {
+					return fmt.Errorf("fn should observe cancellation, got %v", err)
+				}
+				return nil
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fmt.Printf("Running test: %s\n", tt.name)
+			if err := tt.validate(); err != nil {
+				t.Errorf("test failed: %v", err)
+				fmt.Printf("❌ Test failed: %s - %v\n", tt.name, err)
+			} else {
+				fmt.Printf("✅ Test passed: %s\n", tt.name)
+			}
+		})
+	}
+}
@@ -0,0 +1,406 @@
+//go:build js && wasm
+// +build js,wasm
+
+package js
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"syscall/js"
+	"unsafe"
+)
+
+// Typed arrays hold their elements in the byte order of the host, which is
+// also the byte order of Go memory. The helpers below therefore move a whole
+// slice with a single CopyBytesToGo or CopyBytesToJS call through a Uint8Array
+// over the same bytes, instead of one Index call per element.
+
+// FromBytes creates a Uint8Array holding a copy of b
+func FromBytes(b []byte) *Value {
+	return &Value{value: fromSlice("Uint8Array", b)}
+}
+
+// Bytes returns a copy of the bytes of an ArrayBuffer, a typed array or a
+// DataView. For typed arrays with elements wider than a byte, the bytes are in
+// the byte order of the host.
+func (v *Value) Bytes() ([]byte, error) {
+	view, err := byteView(v)
+	if err != nil {
+		return nil, err
+	}
+	b := make([]byte, view.Length())
+	js.CopyBytesToGo(b, view)
+	return b, nil
+}
+
+// MustBytes returns the bytes of the value, panicking on error
+func (v *Value) MustBytes() []byte {
+	b, err := v.Bytes()
+	if err != nil {
+		panic(fmt.Sprintf("MustBytes failed: %v", err))
+	}
+	return b
+}
+
+// TryBytes returns the bytes of the value, with a fallback value on error
+func (v *Value) TryBytes(fallback []byte) []byte {
+	b, err := v.Bytes()
+	if err != nil {
+		return fallback
+	}
+	return b
+}
+
+// byteView returns a Uint8Array over the bytes of an ArrayBuffer or view
+func byteView(v *Value) (js.Value, error) {
+	if v.Type() != js.TypeObject {
+		return js.Value{}, fmt.Errorf("value is not an ArrayBuffer or a view of one")
+	}
+	if isByteArray(v.value) {
+		return v.value, nil
+	}
+	uint8Array := js.Global().Get("Uint8Array")
+	if v.value.InstanceOf(js.Global().Get("ArrayBuffer")) {
+		return uint8Array.New(v.value), nil
+	}
+	if !js.Global().Get("ArrayBuffer").Call("isView", v.value).Bool() {
+		return js.Value{}, fmt.Errorf("value is not an ArrayBuffer or a view of one")
+	}
+	return uint8Array.New(v.value.Get("buffer"), v.value.Get("byteOffset"), v.value.Get("byteLength")), nil
+}
+
+// element is the element type of a typed array
+type element interface {
+	~int8 | ~uint8 | ~int16 | ~uint16 | ~int32 | ~uint32 | ~float32 | ~float64
+}
+
+// memory returns the bytes of Go memory holding the elements of s
+func memory[T element](s []T) []byte {
+	if len(s) == 0 {
+		return nil
+	}
+	return unsafe.Slice((*byte)(unsafe.Pointer(unsafe.SliceData(s))), len(s)*int(unsafe.Sizeof(s[0])))
+}
+
+// fromSlice creates a typed array of the named type holding a copy of s
+func fromSlice[T element](constructor string, s []T) js.Value {
+	arr := js.Global().Get(constructor).New(len(s))
+	view := arr
+	if constructor != "Uint8Array" {
+		view = js.Global().Get("Uint8Array").New(arr.Get("buffer"))
+	}
+	js.CopyBytesToJS(view, memory(s))
+	return arr
+}
+
+// toSlice checks that v is a typed array of the named type and returns a copy
+// of its elements
+func toSlice[T element](v *Value, constructor string) ([]T, error) {
+	if v.Type() != js.TypeObject || !v.value.InstanceOf(js.Global().Get(constructor)) {
+		return nil, fmt.Errorf("value is not a %s", constructor)
+	}
+	view, err := byteView(v)
+	if err != nil {
+		return nil, err
+	}
+	s := make([]T, v.value.Length())
+	js.CopyBytesToGo(memory(s), view)
+	return s, nil
+}
+
+// FromInt8s creates an Int8Array holding a copy of s
+func FromInt8s(s []int8) *Value {
+	return &Value{value: fromSlice("Int8Array", s)}
+}
+
+// Int8s returns a copy of the elements of an Int8Array
+func (v *Value) Int8s() ([]int8, error) {
+	return toSlice[int8](v, "Int8Array")
+}
+
+// FromInt16s creates an Int16Array holding a copy of s
+func FromInt16s(s []int16) *Value {
+	return &Value{value: fromSlice("Int16Array", s)}
+}
+
+// Int16s returns a copy of the elements of an Int16Array
+func (v *Value) Int16s() ([]int16, error) {
+	return toSlice[int16](v, "Int16Array")
+}
+
+// FromUint16s creates an Uint16Array holding a copy of s
+func FromUint16s(s []uint16) *Value {
+	return &Value{value: fromSlice("Uint16Array", s)}
+}
+
+// Uint16s returns a copy of the elements of an Uint16Array
+func (v *Value) Uint16s() ([]uint16, error) {
+	return toSlice[uint16](v, "Uint16Array")
+}
+
+// FromInt32s creates an Int32Array holding a copy of s
+func FromInt32s(s []int32) *Value {
+	return &Value{value: fromSlice("Int32Array", s)}
+}
+
+// Int32s returns a copy of the elements of an Int32Array
+func (v *Value) Int32s() ([]int32, error) {
+	return toSlice[int32](v, "Int32Array")
+}
+
+// FromUint32s creates an Uint32Array holding a copy of s
+func FromUint32s(s []uint32) *Value {
+	return &Value{value: fromSlice("Uint32Array", s)}
+}
+
+// Uint32s returns a copy of the elements of an Uint32Array
+func (v *Value) Uint32s() ([]uint32, error) {
+	return toSlice[uint32](v, "Uint32Array")
+}
+
+// FromFloat32s creates a Float32Array holding a copy of s
+func FromFloat32s(s []float32) *Value {
+	return &Value{value: fromSlice("Float32Array", s)}
+}
+
+// Float32s returns a copy of the elements of a Float32Array
+func (v *Value) Float32s() ([]float32, error) {
+	return toSlice[float32](v, "Float32Array")
+}
+
+// FromFloat64s creates a Float64Array holding a copy of s
+func FromFloat64s(s []float64) *Value {
+	return &Value{value: fromSlice("Float64Array", s)}
+}
+
+// Float64s returns a copy of the elements of a Float64Array
+func (v *Value) Float64s() ([]float64, error) {
+	return toSlice[float64](v, "Float64Array")
+}
+
+// ArrayBuffer is a fixed-length block of raw bytes. It implements
+// io.ReaderAt and io.WriterAt, each call copying its bytes at once.
+type ArrayBuffer struct {
+	Value *Value
+}
+
+// NewArrayBuffer allocates an ArrayBuffer of n zero bytes
+func NewArrayBuffer(n int) *ArrayBuffer {
+	return &ArrayBuffer{Value: &Value{value: js.Global().Get("ArrayBuffer").New(n)}}
+}
+
+// AsArrayBuffer wraps v, failing if it is not an ArrayBuffer
+func AsArrayBuffer(v *Value) (*ArrayBuffer, error) {
+	if v.Type() != js.TypeObject || !v.value.InstanceOf(js.Global().Get("ArrayBuffer")) {
+		return nil, fmt.Errorf("value is not an ArrayBuffer")
+	}
+	return &ArrayBuffer{Value: v}, nil
+}
+
+// GetByteLength returns the size of the buffer in bytes
+func (b *ArrayBuffer) GetByteLength() int {
+	return b.Value.value.Get("byteLength").Int()
+}
+
+// Bytes returns a copy of the contents of the buffer
+func (b *ArrayBuffer) Bytes() []byte {
+	return b.Value.MustBytes()
+}
+
+// window returns a Uint8Array over the bytes of the buffer from off, at most n long
+func (b *ArrayBuffer) window(off int64, n int) (js.Value, error) {
+	size := int64(b.GetByteLength())
+	if off < 0 {
+		return js.Value{}, fmt.Errorf("negative offset %d", off)
+	}
+	if off >= size {
+		return js.Value{}, io.EOF
+	}
+	if rest := size - off; int64(n) > rest {
+		n = int(rest)
+	}
+	return js.Global().Get("Uint8Array").New(b.Value.value, off, n), nil
+}
+
+// ReadAt copies bytes of the buffer starting at off into p
+func (b *ArrayBuffer) ReadAt(p []byte, off int64) (int, error) {
+	view, err := b.window(off, len(p))
+	if err != nil {
+		return 0, err
+	}
+	n := js.CopyBytesToGo(p, view)
+	if n < len(p) {
+		return n, io.EOF
+	}
+	return n, nil
+}
+
+// WriteAt copies p into the buffer starting at off. It fails if p does not fit.
+func (b *ArrayBuffer) WriteAt(p []byte, off int64) (int, error) {
+	view, err := b.window(off, len(p))
+	if err == io.EOF {
+		if len(p) == 0 {
+			return 0, nil
+		}
+		err = io.ErrShortWrite
+	}
+	if err != nil {
+		return 0, err
+	}
+	n := js.CopyBytesToJS(view, p)
+	if n < len(p) {
+		return n, io.ErrShortWrite
+	}
+	return n, nil
+}
+
+// DataView reads and writes numbers of any size and byte order at arbitrary
+// offsets of an ArrayBuffer
+type DataView struct {
+	Value *Value
+}
+
+// NewDataView creates a view over length bytes of buf starting at offset. A
+// negative length extends the view to the end of the buffer.
+func NewDataView(buf *ArrayBuffer, offset, length int) (*DataView, error) {
+	args := []interface{}{buf.Value, offset}
+	if length >= 0 {
+		args = append(args, length)
+	}
+	v, err := Global().Get("DataView").NewE(args...)
+	if err != nil {
+		return nil, err
+	}
+	return &DataView{Value: v}, nil
+}
+
+// GetBuffer returns the buffer the view reads from
+func (d *DataView) GetBuffer() *ArrayBuffer {
+	return &ArrayBuffer{Value: d.Value.Get("buffer")}
+}
+
+// GetByteOffset returns the offset of the view in its buffer
+func (d *DataView) GetByteOffset() int {
+	return d.Value.Get("byteOffset").MustInt()
+}
+
+// GetByteLength returns the length of the view in bytes
+func (d *DataView) GetByteLength() int {
+	return d.Value.Get("byteLength").MustInt()
+}
+
+// littleEndian reports whether order stores the least significant byte first
+func littleEndian(order binary.ByteOrder) bool {
+	var b [2]byte
+	order.PutUint16(b[:], 1)
+	return b[0] == 1
+}
+
+func (d *DataView) get(method string, offset int, order binary.ByteOrder) (float64, error) {
+	args := []interface{}{offset}
+	if order != nil {
+		args = append(args, littleEndian(order))
+	}
+	v, err := d.Value.CallE(method, args...)
+	if err != nil {
+		return 0, err
+	}
+	return v.MustFloat(), nil
+}
+
+func (d *DataView) set(method string, offset int, value interface{}, order binary.ByteOrder) error {
+	args := []interface{}{offset, value}
+	if order != nil {
+		args = append(args, littleEndian(order))
+	}
+	_, err := d.Value.CallE(method, args...)
+	return err
+}
+
+// GetInt8 reads the signed byte at offset
+func (d *DataView) GetInt8(offset int) (int8, error) {
+	f, err := d.get("getInt8", offset, nil)
+	return int8(f), err
+}
+
+// SetInt8 writes a signed byte at offset
+func (d *DataView) SetInt8(offset int, value int8) error {
+	return d.set("setInt8", offset, value, nil)
+}
+
+// GetUint8 reads the byte at offset
+func (d *DataView) GetUint8(offset int) (uint8, error) {
+	f, err := d.get("getUint8", offset, nil)
+	return uint8(f), err
+}
+
+// SetUint8 writes a byte at offset
+func (d *DataView) SetUint8(offset int, value uint8) error {
+	return d.set("setUint8", offset, value, nil)
+}
+
+// GetInt16 reads a 16-bit signed integer at offset in the given byte order
+func (d *DataView) GetInt16(offset int, order binary.ByteOrder) (int16, error) {
+	f, err := d.get("getInt16", offset, order)
+	return int16(f), err
+}
+
+// SetInt16 writes a 16-bit signed integer at offset in the given byte order
+func (d *DataView) SetInt16(offset int, value int16, order binary.ByteOrder) error {
+	return d.set("setInt16", offset, value, order)
+}
+
+// GetUint16 reads a 16-bit unsigned integer at offset in the given byte order
+func (d *DataView) GetUint16(offset int, order binary.ByteOrder) (uint16, error) {
+	f, err := d.get("getUint16", offset, order)
+	return uint16(f), err
+}
+
+// SetUint16 writes a 16-bit unsigned integer at offset in the given byte order
+func (d *DataView) SetUint16(offset int, value uint16, order binary.ByteOrder) error {
+	return d.set("setUint16", offset, value, order)
+}
+
+// GetInt32 reads a 32-bit signed integer at offset in the given byte order
+func (d *DataView) GetInt32(offset int, order binary.ByteOrder) (int32, error) {
+	f, err := d.get("getInt32", offset, order)
+	return int32(f), err
+}
+
+// SetInt32 writes a 32-bit signed integer at offset in the given byte order
+func (d *DataView) SetInt32(offset int, value int32, order binary.ByteOrder) error {
+	return d.set("setInt32", offset, value, order)
+}
+
+// GetUint32 reads a 32-bit unsigned integer at offset in the given byte order
+func (d *DataView) GetUint32(offset int, order binary.ByteOrder) (uint32, error) {
+	f, err := d.get("getUint32", offset, order)
+	return uint32(f), err
+}
+
+// SetUint32 writes a 32-bit unsigned integer at offset in the given byte order
+func (d *DataView) SetUint32(offset int, value uint32, order binary.ByteOrder) error {
+	return d.set("setUint32", offset, value, order)
+}
+
+// GetFloat32 reads a 32-bit float at offset in the given byte order
+func (d *DataView) GetFloat32(offset int, order binary.ByteOrder) (float32, error) {
+	f, err := d.get("getFloat32", offset, order)
+	return float32(f), err
+}
+
+// SetFloat32 writes a 32-bit float at offset in the given byte order
+func (d *DataView) SetFloat32(offset int, value float32, order binary.ByteOrder) error {
+	return d.set("setFloat32", offset, value, order)
+}
+
+// GetFloat64 reads a 64-bit float at offset in the given byte order
+func (d *DataView) GetFloat64(offset int, order binary.ByteOrder) (float64, error) {
+	return d.get("getFloat64", offset, order)
+}
+
+// SetFloat64 writes a 64-bit float at offset in the given byte order
+func (d *DataView) SetFloat64(offset int, value float64, order binary.ByteOrder) error {
+	return d.set("setFloat64", offset, value, order)
+}
@@ -0,0 +1,393 @@
+//go:build js && wasm
+// +build js,wasm
+
+package js
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"syscall/js"
+	"testing"
+)
+
+// Sizes of the buffers used by the benchmarks
+const (
+	imageSize = 1920 * 1080 * 4 // a full HD RGBA frame
+	audioSize = 48000 * 2 * 10  // ten seconds of stereo samples at 48 kHz
+)
+
+func TestTypedArrays(t *testing.T) {
+	fmt.Println("Starting typed array tests...")
+
+	tests := []struct {
+		name     string
+		validate func() error
+	}{
+		{
+			name: "Bytes round-trip through a Uint8Array",
+			validate: func() error {
+				src := []byte{0, 1, 127, 128, 255}
+				arr := FromBytes(src)
+				src[0] = 42
+				if arr.value.Index(0).Int() != 0 || arr.value.Index(4).Int() != 255 {
+					return fmt.Errorf("FromBytes should copy the bytes")
+				}
+				if !arr.value.InstanceOf(js.Global().Get("Uint8Array")) {
+					return fmt.Errorf("FromBytes should create a Uint8Array")
+				}
+				b, err := arr.Bytes()
+				if err != nil {
+					return err
+				}
+				if !bytes.Equal(b, []byte{0, 1, 127, 128, 255}) {
+					return fmt.Errorf("unexpected bytes %v", b)
+				}
+				if got := FromBytes(nil).MustBytes(); len(got) != 0 {
+					return fmt.Errorf("expected no bytes, got %v", got)
+				}
+				return nil
+			},
+		},
+		{
+			name: "Typed slices round-trip element by element",
+			validate: func() error {
+				checks := []struct {
+					constructor string
+					arr         *Value
+					want        []float64
+				}{
+					{"Int8Array", FromInt8s([]int8{-128, -1, 127}), []float64{-128, -1, 127}},
+					{"Int16Array", FromInt16s([]int16{-32768, 258, 32767}), []float64{-32768, 258, 32767}},
+					{"Uint16Array", FromUint16s([]uint16{0, 258, 65535}), []float64{0, 258, 65535}},
+					{"Int32Array", FromInt32s([]int32{math.MinInt32, -2, math.MaxInt32}), []float64{math.MinInt32, -2, math.MaxInt32}},
+					{"Uint32Array", FromUint32s([]uint32{0, 1 << 24, math.MaxUint32}), []float64{0, 1 << 24, math.MaxUint32}},
+					{"Float32Array", FromFloat32s([]float32{-1.5, 0.25, 3}), []float64{-1.5, 0.25, 3}},
+					{"Float64Array", FromFloat64s([]float64{math.Pi, -0.1, 1e300}), []float64{math.Pi, -0.1, 1e300}},
+				}
+				for _, c := range checks {
+					if !c.arr.value.InstanceOf(js.Global().Get(c.constructor)) {
+						return fmt.Errorf("expected a %s", c.constructor)
+					}
+					if n := c.arr.MustLength(); n != len(c.want) {
+						return fmt.Errorf("%s: expected length %d, got %d", c.constructor, len(c.want), n)
+					}
+					for i, want := range c.want {
+						if got := c.arr.value.Index(i).Float(); got != want {
+							return fmt.Errorf("%s[%d]: expected %v, got %v", c.constructor, i, want, got)
+						}
+					}
+				}
+
+				i16, err := FromInt16s([]int16{-32768, 258, 32767}).Int16s()
+				if err != nil || fmt.Sprint(i16) != "[-32768 258 32767]" {
+					return fmt.Errorf("unexpected Int16s %v, %v", i16, err)
+				}
+				u32, err := FromUint32s([]uint32{0, math.MaxUint32}).Uint32s()
+				if err != nil || fmt.Sprint(u32) != "[0 4294967295]" {
+					return fmt.Errorf("unexpected Uint32s %v, %v", u32, err)
+				}
+				f32, err := FromFloat32s([]float32{-1.5, 0.25}).Float32s()
+				if err != nil || fmt.Sprint(f32) != "[-1.5 0.25]" {
+					return fmt.Errorf("unexpected Float32s %v, %v", f32, err)
+				}
+				f64, err := FromFloat64s([]float64{math.Pi}).Float64s()
+				if err != nil || f64[0] != math.Pi {
+					return fmt.Errorf("unexpected Float64s %v, %v", f64, err)
+				}
+				i8, err := FromInt8s([]int8{-1}).Int8s()
+				if err != nil || i8[0] != -1 {
+					return fmt.Errorf("unexpected Int8s %v, %v", i8, err)
+				}
+				u16, err := FromUint16s(nil).Uint16s()
+				if err != nil || len(u16) != 0 {
+					return fmt.Errorf("unexpected Uint16s %v, %v", u16, err)
+				}
+				i32, err := FromInt32s([]int32{7, -7}).Int32s()
+				if err != nil || fmt.Sprint(i32) != "[7 -7]" {
+					return fmt.Errorf("unexpected Int32s %v, %v", i32, err)
+				}
+				return nil
+			},
+		},
+		{
+			name: "Typed slices reject other arrays",
+			validate: func() error {
+				if _, err := FromInt32s([]int32{1}).Float32s(); err == nil {
+					return fmt.Errorf("Float32s should reject an Int32Array")
+				}
+				if _, err := FromBytes([]byte{1}).Int8s(); err == nil {
+					return fmt.Errorf("Int8s should reject a Uint8Array")
+				}
+				if _, err := MustMarshal([]interface{}{1, 2}).Float64s(); err == nil {
+					return fmt.Errorf("Float64s should reject a plain array")
+				}
+				if _, err := MustMarshal("bytes").Bytes(); err == nil {
+					return fmt.Errorf("Bytes should reject a string")
+				}
+				if _, err := Global().Call("Object").Bytes(); err == nil {
+					return fmt.Errorf("Bytes should reject a plain object")
+				}
+				if got := MustMarshal(1).TryBytes([]byte{9}); !bytes.Equal(got, []byte{9}) {
+					return fmt.Errorf("TryBytes should return the fallback, got %v", got)
+				}
+				return nil
+			},
+		},
+		{
+			name: "Bytes reads views in host byte order",
+			validate: func() error {
+				arr := FromUint16s([]uint16{0x0102, 0x0304, 0x0506})
+				sub := arr.Call("subarray", 1)
+				if sub.MustLength() != 2 || sub.Get("byteOffset").MustInt() != 2 {
+					return fmt.Errorf("subarray should view the same buffer at an offset")
+				}
+				want := make([]byte, 4)
+				binary.NativeEndian.PutUint16(want, 0x0304)
+				binary.NativeEndian.PutUint16(want[2:], 0x0506)
+				if got := sub.MustBytes(); !bytes.Equal(got, want) {
+					return fmt.Errorf("expected %v, got %v", want, got)
+				}
+				if n := len(arr.Get("buffer").MustBytes()); n != 6 {
+					return fmt.Errorf("expected 6 bytes from the buffer, got %d", n)
+				}
+				sub.value.SetIndex(0, 0xffff)
+				if arr.value.Index(1).Int() != 0xffff {
+					return fmt.Errorf("subarray should share memory with the array")
+				}
+				view := Global().Get("DataView").New(arr.Get("buffer"), 4)
+				if got := view.MustBytes(); !bytes.Equal(got, want[2:]) {
+					return fmt.Errorf("expected %v from the DataView, got %v", want[2:], got)
+				}
+				return nil
+			},
+		},
+		{
+			name: "Typed arrays convert stored numbers",
+			validate: func() error {
+				clamped := Global().Get("Uint8ClampedArray").New(MustMarshal([]interface{}{300, -5, 1.5, 2.5, "7"}))
+				if got := clamped.MustBytes(); !bytes.Equal(got, []byte{255, 0, 2, 2, 7}) {
+					return fmt.Errorf("unexpected clamped bytes %v", got)
+				}
+				wrapped := Global().Get("Int8Array").New(MustMarshal([]interface{}{200, -129, 3.9}))
+				if got := Global().Get("String").Invoke(wrapped).MustString(); got != "-56,127,3" {
+					return fmt.Errorf("unexpected wrapped values %q", got)
+				}
+				u32 := Global().Get("Uint32Array").New(1)
+				u32.value.SetIndex(0, -1)
+				if u32.value.Index(0).Float() != math.MaxUint32 {
+					return fmt.Errorf("expected -1 to wrap to %d, got %v", uint32(math.MaxUint32), u32.value.Index(0).Float())
+				}
+				if Global().Get("Float64Array").Get("BYTES_PER_ELEMENT").MustInt() != 8 {
+					return fmt.Errorf("Float64Array should have 8 bytes per element")
+				}
+				return nil
+			},
+		},
+		{
+			name: "Typed array constructors validate buffers",
+			validate: func() error {
+				buffer := NewArrayBuffer(8)
+				int32Array := Global().Get("Int32Array")
+				if _, err := int32Array.NewE(buffer.Value, 2); !isRangeError(err) {
+					return fmt.Errorf("a misaligned offset should throw a RangeError, got %v", err)
+				}
+				if _, err := int32Array.NewE(buffer.Value, 4, 2); !isRangeError(err) {
+					return fmt.Errorf("a length past the end should throw a RangeError, got %v", err)
+				}
+				if _, err := int32Array.NewE(-1); !isRangeError(err) {
+					return fmt.Errorf("a negative length should throw a RangeError, got %v", err)
+				}
+				view, err := int32Array.NewE(buffer.Value, 4)
+				if err != nil {
+					return err
+				}
+				view.Call("set", MustMarshal([]interface{}{-2}))
+				if got, _ := buffer.Value.Bytes(); !bytes.Equal(got[4:], FromInt32s([]int32{-2}).MustBytes()) {
+					return fmt.Errorf("set should write through to the buffer, got %v", got)
+				}
+				if _, err := view.CallE("set", MustMarshal([]interface{}{1, 2})); !isRangeError(err) {
+					return fmt.Errorf("setting past the end should throw a RangeError, got %v", err)
+				}
+				return nil
+			},
+		},
+		{
+			name: "ArrayBuffer reads and writes at offsets",
+			validate: func() error {
+				buffer := NewArrayBuffer(4)
+				if buffer.GetByteLength() != 4 {
+					return fmt.Errorf("expected 4 bytes, got %d", buffer.GetByteLength())
+				}
+				if n, err := buffer.WriteAt([]byte{1, 2}, 1); n != 2 || err != nil {
+					return fmt.Errorf("WriteAt returned %d, %v", n, err)
+				}
+				if n, err := buffer.WriteAt([]byte{3, 4}, 3); n != 1 || err != io.ErrShortWrite {
+					return fmt.Errorf("a write past the end should be short, got %d, %v", n, err)
+				}
+				if got := buffer.Bytes(); !bytes.Equal(got, []byte{0, 1, 2, 3}) {
+					return fmt.Errorf("unexpected contents %v", got)
+				}
+				p := make([]byte, 3)
+				if n, err := buffer.ReadAt(p, 2); n != 2 || err != io.EOF || !bytes.Equal(p[:n], []byte{2, 3}) {
+					return fmt.Errorf("ReadAt returned %d, %v, %v", n, err, p)
+				}
+				if _, err := buffer.ReadAt(p, 4); err != io.EOF {
+					return fmt.Errorf("reading at the end should return io.EOF, got %v", err)
+				}
+				if _, err := AsArrayBuffer(FromBytes(nil)); err == nil {
+					return fmt.Errorf("AsArrayBuffer should reject a Uint8Array")
+				}
+				wrapped, err := AsArrayBuffer(FromBytes([]byte{5}).Get("buffer"))
+				if err != nil || !bytes.Equal(wrapped.Bytes(), []byte{5}) {
+					return fmt.Errorf("AsArrayBuffer should wrap the buffer of an array, got %v", err)
+				}
+				return nil
+			},
+		},
+		{
+			name: "DataView reads both byte orders",
+			validate: func() error {
+				buffer := NewArrayBuffer(16)
+				view, err := NewDataView(buffer, 4, -1)
+				if err != nil {
+					return err
+				}
+				if view.GetByteOffset() != 4 || view.GetByteLength() != 12 {
+					return fmt.Errorf("unexpected view bounds %d+%d", view.GetByteOffset(), view.GetByteLength())
+				}
+				if err := view.SetUint16(0, 0x0102, binary.BigEndian); err != nil {
+					return err
+				}
+				if err := view.SetUint32(2, 0x03040506, binary.LittleEndian); err != nil {
+					return err
+				}
+				if err := view.SetInt8(6, -3); err != nil {
+					return err
+				}
+				if got := buffer.Bytes()[4:11]; !bytes.Equal(got, []byte{1, 2, 6, 5, 4, 3, 0xfd}) {
+					return fmt.Errorf("unexpected bytes %v", got)
+				}
+				if v, _ := view.GetUint16(0, binary.LittleEndian); v != 0x0201 {
+					return fmt.Errorf("expected 0x0201, got %#x", v)
+				}
+				if v, _ := view.GetInt32(2, binary.BigEndian); v != 0x06050403 {
+					return fmt.Errorf("expected 0x06050403, got %#x", v)
+				}
+				if v, _ := view.GetUint8(6); v != 0xfd {
+					return fmt.Errorf("expected 0xfd, got %#x", v)
+				}
+				if err := view.SetFloat64(4, -2.5, binary.NativeEndian); err != nil {
+					return err
+				}
+				if v, _ := view.GetFloat64(4, binary.NativeEndian); v != -2.5 {
+					return fmt.Errorf("expected -2.5, got %v", v)
+				}
+				if err := view.SetFloat32(8, 0.5, binary.BigEndian); err != nil {
+					return err
+				}
+				if v, _ := view.GetFloat32(8, binary.BigEndian); v != 0.5 {
+					return fmt.Errorf("expected 0.5, got %v", v)
+				}
+				if err := view.SetInt16(10, -2, binary.LittleEndian); err != nil {
+					return err
+				}
+				if v, _ := view.GetInt16(10, binary.LittleEndian); v != -2 {
+					return fmt.Errorf("expected -2, got %v", v)
+				}
+				if _, err := view.GetUint32(10, binary.BigEndian); !isRangeError(err) {
+					return fmt.Errorf("reading past the end should throw a RangeError, got %v", err)
+				}
+				if _, err := NewDataView(buffer, 8, 9); !isRangeError(err) {
+					return fmt.Errorf("a view past the end should throw a RangeError, got %v", err)
+				}
+				if view.GetBuffer().GetByteLength() != 16 {
+					return fmt.Errorf("GetBuffer should return the whole buffer")
+				}
+				return nil
+			},
+		},
+		{
+			name: "Transfers do not allocate per byte",
+			validate: func() error {
+				small, large := FromBytes(make([]byte, 16)), FromBytes(make([]byte, imageSize))
+				smallAllocs := testing.AllocsPerRun(10, func() { small.MustBytes() })
+				largeAllocs := testing.AllocsPerRun(10, func() { large.MustBytes() })
+				if largeAllocs > smallAllocs {
+					return fmt.Errorf("reading %d bytes took %v allocations, %d bytes took %v", imageSize, largeAllocs, 16, smallAllocs)
+				}
+				return nil
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fmt.Printf("Running test: %s\n", tt.name)
+			if err := tt.validate(); err != nil {
+				t.Errorf("validation failed: %v", err)
+				fmt.Printf("❌ Test failed: %s - %v\n", tt.name, err)
+			} else {
+				fmt.Printf("✅ Test passed: %s\n", tt.name)
+			}
+		})
+	}
+}
+
+// isRangeError reports whether err is a JavaScript RangeError
+func isRangeError(err error) bool {
+	var jsErr *Error
+	return errors.As(err, &jsErr) && jsErr.Name == "RangeError"
+}
+
+func BenchmarkFromBytes(b *testing.B) {
+	src := make([]byte, imageSize)
+	b.SetBytes(imageSize)
+	for i := 0; i < b.N; i++ {
+		FromBytes(src)
+	}
+}
+
+func BenchmarkBytes(b *testing.B) {
+	arr := FromBytes(make([]byte, imageSize))
+	b.SetBytes(imageSize)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		arr.MustBytes()
+	}
+}
+
+// BenchmarkBytesByIndex reads the same image one Index call per byte, for comparison
+func BenchmarkBytesByIndex(b *testing.B) {
+	arr := FromBytes(make([]byte, imageSize))
+	b.SetBytes(imageSize)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		dst := make([]byte, imageSize)
+		for j := range dst {
+			dst[j] = byte(arr.value.Index(j).Int())
+		}
+	}
+}
+
+func BenchmarkFromFloat32s(b *testing.B) {
+	src := make([]float32, audioSize)
+	b.SetBytes(audioSize * 4)
+	for i := 0; i < b.N; i++ {
+		FromFloat32s(src)
+	}
+}
+
+func BenchmarkFloat32s(b *testing.B) {
+	arr := FromFloat32s(make([]float32, audioSize))
+	b.SetBytes(audioSize * 4)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := arr.Float32s(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -14,6 +14,21 @@ type Value struct {
 	value js.Value
 }
 
+// Type represents the JavaScript type of a value
+type Type = js.Type
+
+// JavaScript types returned by Value.Type
+const (
+	TypeUndefined = js.TypeUndefined
+	TypeNull      = js.TypeNull
+	TypeBoolean   = js.TypeBoolean
+	TypeNumber    = js.TypeNumber
+	TypeString    = js.TypeString
+	TypeSymbol    = js.TypeSymbol
+	TypeObject    = js.TypeObject
+	TypeFunction  = js.TypeFunction
+)
+
 // Global returns the JavaScript global object
 func Global() *Value {
 	return &Value{value: js.Global()}
@@ -31,12 +46,49 @@ func (v *Value) Get(name string) *Value {
 
 // Set sets a property of the JavaScript value
 func (v *Value) Set(name string, value interface{}) {
-	v.value.Set(name, value)
+	v.value.Set(name, unwrap(value))
 }
 
 // Call calls a method of the JavaScript value
 func (v *Value) Call(method string, args ...interface{}) *Value {
-	return &Value{value: v.value.Call(method, args...)}
+	return &Value{value: v.value.Call(method, unwrapArgs(args)...)}
+}
+
+// Invoke calls the JavaScript value as a function
+func (v *Value) Invoke(args ...interface{}) *Value {
+	return &Value{value: v.value.Invoke(unwrapArgs(args)...)}
+}
+
+// New uses the JavaScript value as a constructor, like the new operator
+func (v *Value) New(args ...interface{}) *Value {
+	return &Value{value: v.value.New(unwrapArgs(args)...)}
+}
+
+// unwrap converts *Value and *Callback arguments into the js.Value they wrap so
+// they can be passed on to syscall/js
+func unwrap(x interface{}) interface{} {
+	switch v := x.(type) {
+	case *Value:
+		if v == nil {
+			return nil
+		}
+		return v.value
+	case *Callback:
+		if v == nil {
+			return nil
+		}
+		return v.fn.Value
+	}
+	return x
+}
+
+// unwrapArgs applies unwrap to every argument
+func unwrapArgs(args []interface{}) []interface{} {
+	unwrapped := make([]interface{}, len(args))
+	for i, arg := range args {
+		unwrapped[i] = unwrap(arg)
+	}
+	return unwrapped
 }
 
 // Type returns the JavaScript type of the value
@@ -113,7 +165,7 @@ func (v *Value) Object() (map[string]interface{}, error) {
 
 // Array returns the value as a slice of Values
 func (v *Value) Array() ([]*Value, error) {
-	if v.Type() != js.TypeObject || !v.value.Get("Array").Call("isArray", v.value).Bool() {
+	if !isArray(v.value) {
 		return nil, fmt.Errorf("value is not an array")
 	}
 
@@ -132,6 +184,18 @@ func (v *Value) Raw() js.Value {
 	return v.value
 }
 
+// CopyBytesToGo copies bytes from a Uint8Array or Uint8ClampedArray into dst
+// and returns the number of bytes copied. It panics if src is not such an array.
+func CopyBytesToGo(dst []byte, src *Value) int {
+	return js.CopyBytesToGo(dst, src.value)
+}
+
+// CopyBytesToJS copies bytes from src into a Uint8Array or Uint8ClampedArray
+// and returns the number of bytes copied. It panics if dst is not such an array.
+func CopyBytesToJS(dst *Value, src []byte) int {
+	return js.CopyBytesToJS(dst.value, src)
+}
+
 // Exists checks if a property exists on the JavaScript value
 func (v *Value) Exists(key string) bool {
 	return !v.Get(key).IsUndefined()
@@ -324,15 +388,3 @@ func (v *Value) TryToJSON(fallback interface{}) interface{} {
 	}
 	return result
 }
-
-// NewCallback creates a new JavaScript callback function
-func NewCallback(fn func([]*Value)) js.Func {
-	return js.FuncOf(func(this js.Value, args []js.Value) interface{} {
-		wrappedArgs := make([]*Value, len(args))
-		for i, arg := range args {
-			wrappedArgs[i] = &Value{value: arg}
-		}
-		fn(wrappedArgs)
-		return nil
-	})
-}
@@ -0,0 +1,25 @@
+// Package fetch is an HTTP client built on the fetch function of the browser.
+// Unlike net/http compiled to WebAssembly, it exposes the options of fetch,
+// such as the request mode and whether credentials are sent, and streams
+// response bodies as they arrive.
+//
+// A request is built with NewRequest and sent with Do. The context passed to
+// Do is wired to an AbortController, so cancelling it aborts the request and
+// any read of the response body still in progress:
+//
+//	resp, err := fetch.NewRequest("POST", "/api/items").
+//		SetJSON(item).
+//		SetCredentials(fetch.CredentialsInclude).
+//		Do(ctx)
+//	if err != nil {
+//		return err
+//	}
+//	defer resp.Body().Close()
+//	if !resp.OK() {
+//		return fmt.Errorf("unexpected status %d", resp.Status)
+//	}
+//	return resp.JSON(&created)
+//
+// The package is only available when compiled for js/wasm. Package fetchtest
+// serves requests from a Go http.Handler for tests.
+package fetch
@@ -0,0 +1,314 @@
+//go:build js && wasm
+// +build js,wasm
+
+package fetch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/abdorrahmani/go-wasm/js"
+	"github.com/abdorrahmani/go-wasm/web/fetch/fetchtest"
+)
+
+type item struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+func TestFetch(t *testing.T) {
+	fmt.Println("Starting fetch tests...")
+
+	// release is closed to let the streaming handler write its last chunk
+	release := make(chan struct{})
+	// canceled receives the error of handlers whose request was aborted
+	canceled := make(chan error, 1)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/item", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Version", "3")
+		json.NewEncoder(w).Encode(item{Name: "gopher", Count: 2})
+	})
+	mux.HandleFunc("/echo", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		request := fetchtest.Request(r)
+		json.NewEncoder(w).Encode(map[string]string{
+			"method":       r.Method,
+			"contentType":  r.Header.Get("Content-Type"),
+			"token":        r.Header.Get("X-Token"),
+			"body":         string(body),
+			"mode":         r.Header.Get("Sec-Fetch-Mode"),
+			"credentials":  request.Get("credentials").MustString(),
+			"cache":        request.Get("cache").MustString(),
+			"redirect":     request.Get("redirect").MustString(),
+			"multipartKey": r.FormValue("key"),
+		})
+	})
+	mux.HandleFunc("/stream", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("first,"))
+		w.(http.Flusher).Flush()
+		<-release
+		w.Write([]byte("second"))
+	})
+	mux.HandleFunc("/hang", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("header") != "" {
+			w.Write([]byte("partial"))
+		}
+		<-r.Context().Done()
+		canceled <- r.Context().Err()
+	})
+	mux.HandleFunc("/empty", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+	server := fetchtest.NewServer(mux)
+	defer server.Close()
+
+	ctx := context.Background()
+	live := js.LiveCallbacks()
+
+	// echo sends a request to /echo and decodes what the handler saw
+	echo := func(r *Request) (map[string]string, error) {
+		resp, err := r.Do(ctx)
+		if err != nil {
+			return nil, err
+		}
+		var seen map[string]string
+		if err := resp.JSON(&seen); err != nil {
+			return nil, err
+		}
+		return seen, nil
+	}
+
+	tests := []struct {
+		name     string
+		validate func() error
+	}{
+		{
+			name: "Get JSON",
+			validate: func() error {
+				resp, err := Get(ctx, server.URL+"/item")
+				if err != nil {
+					return err
+				}
+				if resp.Status != 200 || resp.StatusText != "OK" || !resp.OK() {
+					return fmt.Errorf("unexpected status: %d %s", resp.Status, resp.StatusText)
+				}
+				if resp.Headers.Get("Content-Type") != "application/json" || resp.Headers.Get("X-Version") != "3" {
+					return fmt.Errorf("unexpected headers: %v", resp.Headers)
+				}
+				var got item
+				if err := resp.JSON(&got); err != nil {
+					return err
+				}
+				if got != (item{Name: "gopher", Count: 2}) {
+					return fmt.Errorf("unexpected item: %+v", got)
+				}
+				return nil
+			},
+		},
+		{
+			name: "Request Options",
+			validate: func() error {
+				seen, err := echo(NewRequest(http.MethodPut, server.URL+"/echo").
+					SetJSON(item{Name: "new"}).
+					SetHeader("X-Token", "secret").
+					SetCredentials(CredentialsInclude).
+					SetMode(ModeSameOrigin).
+					SetCache(CacheNoStore).
+					SetRedirect(RedirectError))
+				if err != nil {
+					return err
+				}
+				want := map[string]string{
+					"method": "PUT", "contentType": "application/json", "token": "secret",
+					"body": `{"name":"new","count":0}`, "mode": "same-origin", "credentials": "include",
+					"cache": "no-store", "redirect": "error", "multipartKey": "",
+				}
+				if fmt.Sprint(seen) != fmt.Sprint(want) {
+					return fmt.Errorf("expected %v, got %v", want, seen)
+				}
+				return nil
+			},
+		},
+		{
+			name: "Request Bodies",
+			validate: func() error {
+				seen, err := echo(NewRequest(http.MethodPost, server.URL+"/echo").SetBody([]byte{'h', 'i', 0}))
+				if err != nil {
+					return err
+				}
+				if seen["body"] != "hi\x00" {
+					return fmt.Errorf("unexpected bytes body: %q", seen["body"])
+				}
+
+				seen, err = echo(NewRequest(http.MethodPost, server.URL+"/echo").SetForm(url.Values{"key": {"a b"}}))
+				if err != nil {
+					return err
+				}
+				if seen["body"] != "key=a+b" || seen["contentType"] != "application/x-www-form-urlencoded" {
+					return fmt.Errorf("unexpected form body: %q, %q", seen["body"], seen["contentType"])
+				}
+
+				data := js.Global().Get("FormData").New()
+				data.Call("append", "key", "multipart value")
+				seen, err = echo(NewRequest(http.MethodPost, server.URL+"/echo").SetFormData(data))
+				if err != nil {
+					return err
+				}
+				if !strings.HasPrefix(seen["contentType"], "multipart/form-data; boundary=") || seen["multipartKey"] != "multipart value" {
+					return fmt.Errorf("unexpected FormData body: %q, %q", seen["contentType"], seen["multipartKey"])
+				}
+
+				options := js.Global().Call("Object")
+				options.Set("type", "text/plain")
+				blob := js.Global().Get("Blob").New(js.MustMarshal([]string{"from ", "blob"}), options)
+				seen, err = echo(NewRequest(http.MethodPost, server.URL+"/echo").SetBlob(blob))
+				if err != nil {
+					return err
+				}
+				if seen["body"] != "from blob" || seen["contentType"] != "text/plain" {
+					return fmt.Errorf("unexpected blob body: %q, %q", seen["body"], seen["contentType"])
+				}
+
+				if _, err := NewRequest(http.MethodPost, server.URL+"/echo").SetJSON(func() {}).Do(ctx); err == nil {
+					return fmt.Errorf("expected an error encoding the JSON body")
+				}
+				return nil
+			},
+		},
+		{
+			name: "Streaming Body",
+			validate: func() error {
+				resp, err := Get(ctx, server.URL+"/stream")
+				if err != nil {
+					return err
+				}
+				body := resp.Body()
+				defer body.Close()
+				buf := make([]byte, 64)
+				n, err := body.Read(buf)
+				if err != nil || string(buf[:n]) != "first," {
+					return fmt.Errorf("expected the first chunk before the handler finished, got %q, %v", buf[:n], err)
+				}
+				close(release)
+				rest, err := io.ReadAll(body)
+				if err != nil || string(rest) != "second" {
+					return fmt.Errorf("unexpected rest of the body: %q, %v", rest, err)
+				}
+				body.Close()
+				if _, err := body.Read(buf); err == nil {
+					return fmt.Errorf("reading a closed body should fail")
+				}
+				return nil
+			},
+		},
+		{
+			name: "Cancel Before Response",
+			validate: func() error {
+				ctx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+				defer cancel()
+				_, err := Get(ctx, server.URL+"/hang")
+				if !errors.Is(err, context.DeadlineExceeded) {
+					return fmt.Errorf("expected context.DeadlineExceeded, got %v", err)
+				}
+				select {
+				case err := <-canceled:
+					if err != context.Canceled {
+						return fmt.Errorf("the handler saw %v", err)
+					}
+				case <-time.After(time.Second):
+					return fmt.Errorf("the handler was not canceled")
+				}
+				return nil
+			},
+		},
+		{
+			name: "Cancel While Reading",
+			validate: func() error {
+				ctx, cancel := context.WithCancel(ctx)
+				defer cancel()
+				resp, err := Get(ctx, server.URL+"/hang?header=1")
+				if err != nil {
+					return err
+				}
+				go func() {
+					time.Sleep(20 * time.Millisecond)
+					cancel()
+				}()
+				b, err := io.ReadAll(resp.Body())
+				if string(b) != "partial" || !errors.Is(err, context.Canceled) {
+					return fmt.Errorf("expected the partial body and context.Canceled, got %q, %v", b, err)
+				}
+				select {
+				case <-canceled:
+				case <-time.After(time.Second):
+					return fmt.Errorf("the handler was not canceled")
+				}
+				return nil
+			},
+		},
+		{
+			name: "Status Codes And Errors",
+			validate: func() error {
+				resp, err := Get(ctx, server.URL+"/missing")
+				if err != nil {
+					return err
+				}
+				if resp.Status != 404 || resp.OK() {
+					return fmt.Errorf("expected 404, got %d", resp.Status)
+				}
+				if text, err := resp.Text(); err != nil || !strings.Contains(text, "not found") {
+					return fmt.Errorf("unexpected body: %q, %v", text, err)
+				}
+
+				resp, err = Get(ctx, server.URL+"/empty")
+				if err != nil {
+					return err
+				}
+				if b, err := resp.Bytes(); resp.Status != 204 || len(b) != 0 || err != nil {
+					return fmt.Errorf("unexpected empty response: %d %q %v", resp.Status, b, err)
+				}
+
+				if _, err := Get(ctx, "http://[::1"); err == nil {
+					return fmt.Errorf("expected an error for an invalid URL")
+				}
+				return nil
+			},
+		},
+		{
+			name: "Callbacks Are Released",
+			validate: func() error {
+				// The stand-in server releases its callbacks once the
+				// aborted handlers have returned
+				time.Sleep(20 * time.Millisecond)
+				if n := js.LiveCallbacks(); n != live {
+					return fmt.Errorf("expected %d live callbacks, got %d", live, n)
+				}
+				return nil
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fmt.Printf("Running test: %s\n", tt.name)
+			if err := tt.validate(); err != nil {
+				t.Errorf("validation failed: %v", err)
+				fmt.Printf("❌ Test failed: %s - %v\n", tt.name, err)
+			} else {
+				fmt.Printf("✅ Test passed: %s\n", tt.name)
+			}
+		})
+	}
+}
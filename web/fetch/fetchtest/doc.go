@@ -0,0 +1,5 @@
+// Package fetchtest serves the requests made with fetch from a Go
+// http.Handler, without a network, so code using the fetch package can be
+// tested under Node.js or in a browser. It is only available when compiled
+// for js/wasm.
+package fetchtest
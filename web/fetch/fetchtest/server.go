@@ -0,0 +1,287 @@
+//go:build js && wasm
+// +build js,wasm
+
+package fetchtest
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/abdorrahmani/go-wasm/js"
+)
+
+// Server is a stand-in HTTP server. While it is open, fetch calls for URLs
+// under its URL are served by its handler, and all other calls go to the
+// real fetch function.
+//
+// The response of the handler is returned as-is: redirects are not followed
+// and no CORS checks are made. Requests carry a Sec-Fetch-Mode header like in
+// browsers; use Request to inspect the other options of a request.
+type Server struct {
+	// URL is the origin of the server, such as http://fetchtest-1.invalid
+	URL     string
+	handler http.Handler
+}
+
+var (
+	mu      sync.Mutex
+	servers = map[string]*Server{}
+	lastID  int
+	// realFetch is the fetch function replaced while servers are open
+	realFetch *js.Value
+	serve     *js.Callback
+)
+
+// requestKey is the context key of the JavaScript Request being served
+type requestKey struct{}
+
+// NewServer starts a server that serves requests with handler
+func NewServer(handler http.Handler) *Server {
+	mu.Lock()
+	defer mu.Unlock()
+	lastID++
+	s := &Server{
+		URL:     fmt.Sprintf("http://fetchtest-%d.invalid", lastID),
+		handler: handler,
+	}
+	if len(servers) == 0 {
+		install()
+	}
+	servers[s.URL] = s
+	return s
+}
+
+// Close stops the server. Once all servers are closed, the real fetch
+// function is restored.
+func (s *Server) Close() {
+	mu.Lock()
+	defer mu.Unlock()
+	if servers[s.URL] != s {
+		return
+	}
+	delete(servers, s.URL)
+	if len(servers) == 0 {
+		js.Global().Set("fetch", realFetch)
+		serve.Release()
+	}
+}
+
+// Request returns the JavaScript Request served by a handler, for inspecting
+// the options that are not sent over HTTP, such as credentials and cache. It
+// returns nil if r was not made by a Server.
+func Request(r *http.Request) *js.Value {
+	request, _ := r.Context().Value(requestKey{}).(*js.Value)
+	return request
+}
+
+// install replaces the fetch function with one that passes the requests to
+// the servers
+func install() {
+	realFetch = js.Global().Get("fetch")
+	serve = js.NewCallback(func(args []*js.Value) {
+		request, resolve, reject := args[0], args[1], args[2]
+		origin := js.Global().Get("URL").New(request.Get("url")).Get("origin").MustString()
+		mu.Lock()
+		s := servers[origin]
+		mu.Unlock()
+		if s == nil {
+			resolve.Invoke(realFetch.Invoke(request))
+			return
+		}
+		// Reading the request body waits for JavaScript, which the goroutine
+		// running a callback must not do
+		go s.serve(request, resolve, reject)
+	})
+	fetch := js.Global().Get("Function").New("serve", `return function fetch(input, init) {
+		return new Promise((resolve, reject) => serve(new Request(input, init), resolve, reject));
+	}`)
+	js.Global().Set("fetch", fetch.Invoke(serve.Value()))
+}
+
+// serve runs the handler for a request and settles the fetch promise
+func (s *Server) serve(request, resolve, reject *js.Value) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	w := &responseWriter{
+		header:  http.Header{},
+		resolve: resolve,
+		reject:  reject,
+		head:    request.Get("method").MustString() == http.MethodHead,
+	}
+
+	signal := request.Get("signal")
+	if signal.Get("aborted").MustBool() {
+		reject.Invoke(signal.Get("reason"))
+		return
+	}
+	onAbort := js.NewCallback(func(args []*js.Value) {
+		cancel()
+		w.abort(signal.Get("reason"))
+	})
+	signal.Call("addEventListener", "abort", onAbort)
+	defer func() {
+		signal.Call("removeEventListener", "abort", onAbort)
+		onAbort.Release()
+	}()
+
+	r, err := newRequest(ctx, request)
+	if err != nil {
+		w.abort(js.Global().Get("TypeError").New(err.Error()))
+		return
+	}
+	s.handler.ServeHTTP(w, r)
+	w.finish()
+}
+
+// newRequest converts a JavaScript Request into the request seen by handlers
+func newRequest(ctx context.Context, request *js.Value) (*http.Request, error) {
+	buffer, err := request.Call("arrayBuffer").Await(ctx)
+	if err != nil {
+		return nil, err
+	}
+	body, err := buffer.Bytes()
+	if err != nil {
+		return nil, err
+	}
+	ctx = context.WithValue(ctx, requestKey{}, request)
+	r, err := http.NewRequestWithContext(ctx, request.Get("method").MustString(), request.Get("url").MustString(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	for _, pair := range js.Global().Get("Array").Call("from", request.Get("headers")).MustArray() {
+		entry := pair.MustArray()
+		r.Header.Add(entry[0].MustString(), entry[1].MustString())
+	}
+	r.Header.Set("Sec-Fetch-Mode", request.Get("mode").MustString())
+	r.RequestURI = r.URL.RequestURI()
+	r.RemoteAddr = "192.0.2.1:1234"
+	return r, nil
+}
+
+// responseWriter streams the response of a handler into a JavaScript Response.
+// The fetch promise is resolved as soon as the header is written, and every
+// Write enqueues a chunk of the body.
+type responseWriter struct {
+	mu          sync.Mutex
+	header      http.Header
+	wroteHeader bool
+	head        bool
+	// done is set once the body is closed or the request is aborted
+	done            bool
+	resolve, reject *js.Value
+	// controller is the controller of the body stream, or nil if the
+	// response has no body
+	controller *js.Value
+}
+
+// Header implements http.ResponseWriter
+func (w *responseWriter) Header() http.Header {
+	return w.header
+}
+
+// WriteHeader implements http.ResponseWriter
+func (w *responseWriter) WriteHeader(status int) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.writeHeader(status)
+}
+
+// Write implements http.ResponseWriter
+func (w *responseWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.writeHeader(http.StatusOK)
+	if w.done {
+		return 0, context.Canceled
+	}
+	if w.controller == nil {
+		return 0, http.ErrBodyNotAllowed
+	}
+	w.controller.Call("enqueue", js.FromBytes(p))
+	return len(p), nil
+}
+
+// Flush implements http.Flusher. Writes are never buffered, so it only sends
+// the header if it has not been sent yet.
+func (w *responseWriter) Flush() {
+	w.WriteHeader(http.StatusOK)
+}
+
+// writeHeader resolves the fetch promise with a Response
+func (w *responseWriter) writeHeader(status int) {
+	if w.wroteHeader {
+		return
+	}
+	w.wroteHeader = true
+	if w.done {
+		return
+	}
+
+	headers := js.Global().Get("Headers").New()
+	for name, values := range w.header {
+		for _, value := range values {
+			headers.Call("append", name, value)
+		}
+	}
+	init := js.Global().Call("Object")
+	init.Set("status", status)
+	init.Set("statusText", http.StatusText(status))
+	init.Set("headers", headers)
+
+	var body interface{}
+	if !w.head && bodyAllowed(status) {
+		start := js.NewOnceCallback(func(args []*js.Value) {
+			w.controller = args[0]
+		})
+		source := js.Global().Call("Object")
+		source.Set("start", start.Value())
+		body = js.Global().Get("ReadableStream").New(source)
+	}
+	response, err := js.Global().Get("Response").NewE(body, init)
+	if err != nil {
+		w.done = true
+		w.controller = nil
+		w.reject.Invoke(err.(*js.Error).Value)
+		return
+	}
+	w.resolve.Invoke(response)
+}
+
+// bodyAllowed reports whether a response with the given status may have a body
+func bodyAllowed(status int) bool {
+	switch status {
+	case http.StatusSwitchingProtocols, http.StatusEarlyHints, http.StatusNoContent, http.StatusResetContent, http.StatusNotModified:
+		return false
+	}
+	return true
+}
+
+// finish sends the header if the handler wrote nothing and closes the body
+func (w *responseWriter) finish() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.writeHeader(http.StatusOK)
+	if !w.done && w.controller != nil {
+		w.controller.Call("close")
+	}
+	w.done = true
+}
+
+// abort rejects the fetch promise, or fails the body if the header was
+// already sent
+func (w *responseWriter) abort(reason *js.Value) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.done {
+		return
+	}
+	w.done = true
+	if !w.wroteHeader {
+		w.reject.Invoke(reason)
+	} else if w.controller != nil {
+		w.controller.Call("error", reason)
+	}
+}
@@ -0,0 +1,237 @@
+//go:build js && wasm
+// +build js,wasm
+
+package fetch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/abdorrahmani/go-wasm/js"
+)
+
+// Credentials controls whether cookies and HTTP authentication are sent
+type Credentials string
+
+// Credentials modes
+const (
+	CredentialsOmit       Credentials = "omit"
+	CredentialsSameOrigin Credentials = "same-origin"
+	CredentialsInclude    Credentials = "include"
+)
+
+// Mode controls whether cross-origin requests are allowed and how their
+// responses can be read
+type Mode string
+
+// Request modes
+const (
+	ModeCORS       Mode = "cors"
+	ModeNoCORS     Mode = "no-cors"
+	ModeSameOrigin Mode = "same-origin"
+)
+
+// Cache controls how the request uses the HTTP cache of the browser
+type Cache string
+
+// Cache modes
+const (
+	CacheDefault      Cache = "default"
+	CacheNoStore      Cache = "no-store"
+	CacheReload       Cache = "reload"
+	CacheNoCache      Cache = "no-cache"
+	CacheForceCache   Cache = "force-cache"
+	CacheOnlyIfCached Cache = "only-if-cached"
+)
+
+// Redirect controls how redirects are handled
+type Redirect string
+
+// Redirect modes
+const (
+	RedirectFollow Redirect = "follow"
+	RedirectError  Redirect = "error"
+	RedirectManual Redirect = "manual"
+)
+
+// Request is an HTTP request to be sent with fetch. Its setters return the
+// request so calls can be chained. Options left unset use the defaults of
+// fetch.
+type Request struct {
+	Method string
+	URL    string
+	Header http.Header
+
+	body        interface{}
+	credentials Credentials
+	mode        Mode
+	cache       Cache
+	redirect    Redirect
+	// err is the first error of a setter, returned by Do
+	err error
+}
+
+// NewRequest creates a request. The URL may be relative to the page.
+func NewRequest(method, url string) *Request {
+	return &Request{
+		Method: method,
+		URL:    url,
+		Header: http.Header{},
+	}
+}
+
+// Get sends a GET request
+func Get(ctx context.Context, url string) (*Response, error) {
+	return NewRequest(http.MethodGet, url).Do(ctx)
+}
+
+// SetHeader sets a header, replacing its values
+func (r *Request) SetHeader(name, value string) *Request {
+	r.Header.Set(name, value)
+	return r
+}
+
+// AddHeader adds a value to a header
+func (r *Request) AddHeader(name, value string) *Request {
+	r.Header.Add(name, value)
+	return r
+}
+
+// setBody sets the body along with a default Content-Type
+func (r *Request) setBody(body interface{}, contentType string) *Request {
+	r.body = body
+	if contentType != "" && r.Header.Get("Content-Type") == "" {
+		r.Header.Set("Content-Type", contentType)
+	}
+	return r
+}
+
+// SetBody sets the body to a copy of b
+func (r *Request) SetBody(b []byte) *Request {
+	return r.setBody(js.FromBytes(b), "")
+}
+
+// SetJSON sets the body to the JSON encoding of v, with the Content-Type
+// application/json unless another one is set
+func (r *Request) SetJSON(v interface{}) *Request {
+	b, err := json.Marshal(v)
+	if err != nil {
+		if r.err == nil {
+			r.err = fmt.Errorf("fetch: encoding JSON body: %w", err)
+		}
+		return r
+	}
+	return r.setBody(string(b), "application/json")
+}
+
+// SetForm sets the body to the URL-encoded values, with the Content-Type
+// application/x-www-form-urlencoded unless another one is set
+func (r *Request) SetForm(values url.Values) *Request {
+	return r.setBody(values.Encode(), "application/x-www-form-urlencoded")
+}
+
+// SetFormData sets the body to a FormData object, such as one created from a
+// form element. It is sent as multipart/form-data with a boundary chosen by
+// the browser, so no Content-Type should be set.
+func (r *Request) SetFormData(data *js.Value) *Request {
+	return r.setBody(data, "")
+}
+
+// SetBlob sets the body to a Blob or File, with the type of the blob as
+// Content-Type unless another one is set
+func (r *Request) SetBlob(blob *js.Value) *Request {
+	return r.setBody(blob, "")
+}
+
+// SetCredentials sets whether cookies and HTTP authentication are sent
+func (r *Request) SetCredentials(credentials Credentials) *Request {
+	r.credentials = credentials
+	return r
+}
+
+// SetMode sets whether cross-origin requests are allowed
+func (r *Request) SetMode(mode Mode) *Request {
+	r.mode = mode
+	return r
+}
+
+// SetCache sets how the request uses the HTTP cache
+func (r *Request) SetCache(cache Cache) *Request {
+	r.cache = cache
+	return r
+}
+
+// SetRedirect sets how redirects are handled
+func (r *Request) SetRedirect(redirect Redirect) *Request {
+	r.redirect = redirect
+	return r
+}
+
+// init returns the RequestInit dictionary passed to fetch
+func (r *Request) init(signal *js.Value) *js.Value {
+	init := js.Global().Call("Object")
+	if r.Method != "" {
+		init.Set("method", r.Method)
+	}
+	headers := js.Global().Get("Headers").New()
+	for name, values := range r.Header {
+		for _, value := range values {
+			headers.Call("append", name, value)
+		}
+	}
+	init.Set("headers", headers)
+	if r.body != nil {
+		init.Set("body", r.body)
+	}
+	options := map[string]string{
+		"credentials": string(r.credentials),
+		"mode":        string(r.mode),
+		"cache":       string(r.cache),
+		"redirect":    string(r.redirect),
+	}
+	for name, value := range options {
+		if value != "" {
+			init.Set(name, value)
+		}
+	}
+	init.Set("signal", signal)
+	return init
+}
+
+// Do sends the request and waits for the response header. The body is read
+// from the returned Response. Cancelling ctx aborts the request, including
+// reading the body, and makes Do return the error of ctx.
+//
+// Do waits for the JavaScript event loop, so it must not be called from the
+// goroutine running a callback invoked by JavaScript, such as an event
+// listener; start a new goroutine instead.
+func (r *Request) Do(ctx context.Context) (*Response, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	controller := js.Global().Get("AbortController").New()
+	stop := context.AfterFunc(ctx, func() {
+		controller.Call("abort")
+	})
+	promise, err := js.Global().CallE("fetch", r.URL, r.init(controller.Get("signal")))
+	if err != nil {
+		stop()
+		return nil, fmt.Errorf("fetch %s %s: %w", r.Method, r.URL, err)
+	}
+	value, err := promise.Await(ctx)
+	if err != nil {
+		stop()
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, fmt.Errorf("fetch %s %s: %w", r.Method, r.URL, err)
+	}
+	return newResponse(ctx, value, stop), nil
+}
@@ -0,0 +1,199 @@
+//go:build js && wasm
+// +build js,wasm
+
+package fetch
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/abdorrahmani/go-wasm/js"
+)
+
+// errBodyClosed is returned when reading a body after closing it
+var errBodyClosed = errors.New("fetch: read on closed response body")
+
+// Response is the response to a request. Its body is read once, with Body,
+// Bytes, Text or JSON.
+type Response struct {
+	Value *js.Value
+	// Status is the HTTP status code, such as 200
+	Status int
+	// StatusText is the status message, such as "OK"
+	StatusText string
+	// Headers holds the response headers that the page is allowed to read
+	Headers http.Header
+	// URL is the final URL of the response, after any redirects
+	URL string
+	// Redirected is true if the response is the result of a redirect
+	Redirected bool
+
+	ctx context.Context
+	// stop stops aborting the request when the context is done
+	stop func() bool
+	body *body
+}
+
+func newResponse(ctx context.Context, value *js.Value, stop func() bool) *Response {
+	r := &Response{
+		Value:      value,
+		Status:     value.Get("status").MustInt(),
+		StatusText: value.Get("statusText").MustString(),
+		Headers:    http.Header{},
+		URL:        value.Get("url").MustString(),
+		Redirected: value.Get("redirected").MustBool(),
+		ctx:        ctx,
+		stop:       stop,
+	}
+	for _, pair := range js.Global().Get("Array").Call("from", value.Get("headers")).MustArray() {
+		entry := pair.MustArray()
+		r.Headers.Add(entry[0].MustString(), entry[1].MustString())
+	}
+	if stream := value.Get("body"); stream.IsNull() || stream.IsUndefined() {
+		// There is nothing left to abort
+		stop()
+	}
+	return r
+}
+
+// OK returns true if the status is in the range 200-299
+func (r *Response) OK() bool {
+	return r.Status >= 200 && r.Status <= 299
+}
+
+// Body returns the body as a stream. Reads return the chunks of the body as
+// they arrive and fail once the context of the request is done. Closing the
+// body cancels the rest of the download; every call returns the same reader.
+func (r *Response) Body() io.ReadCloser {
+	if r.body == nil {
+		r.body = &body{response: r}
+	}
+	return r.body
+}
+
+// Bytes reads the rest of the body
+func (r *Response) Bytes() ([]byte, error) {
+	if r.body != nil {
+		defer r.body.Close()
+		return io.ReadAll(r.body)
+	}
+	// Reading the whole body at once takes a single round trip
+	r.body = &body{response: r, err: io.EOF}
+	defer r.stop()
+	buffer, err := r.Value.Call("arrayBuffer").Await(r.ctx)
+	if err != nil {
+		return nil, r.error(err)
+	}
+	return buffer.Bytes()
+}
+
+// Text reads the rest of the body as a string
+func (r *Response) Text() (string, error) {
+	b, err := r.Bytes()
+	return string(b), err
+}
+
+// JSON reads the rest of the body and decodes it into target with encoding/json
+func (r *Response) JSON(target interface{}) error {
+	b, err := r.Bytes()
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(b, target); err != nil {
+		return fmt.Errorf("fetch: decoding JSON body: %w", err)
+	}
+	return nil
+}
+
+// error converts an error reading the body, preferring the error of the context
+func (r *Response) error(err error) error {
+	if ctxErr := r.ctx.Err(); ctxErr != nil {
+		return ctxErr
+	}
+	return fmt.Errorf("fetch: reading body: %w", err)
+}
+
+// body reads a response body from its ReadableStream
+type body struct {
+	response *Response
+	reader   *js.Value
+	// buf holds the unread part of the last chunk
+	buf    []byte
+	err    error
+	closed bool
+}
+
+// Read implements io.Reader
+func (b *body) Read(p []byte) (int, error) {
+	if b.closed {
+		return 0, errBodyClosed
+	}
+	for len(b.buf) == 0 {
+		if b.err != nil {
+			return 0, b.err
+		}
+		b.next()
+	}
+	n := copy(p, b.buf)
+	b.buf = b.buf[n:]
+	return n, nil
+}
+
+// next waits for the next chunk of the body
+func (b *body) next() {
+	r := b.response
+	if b.reader == nil {
+		stream := r.Value.Get("body")
+		if stream.IsNull() || stream.IsUndefined() {
+			b.err = io.EOF
+			return
+		}
+		reader, err := stream.CallE("getReader")
+		if err != nil {
+			b.fail(err)
+			return
+		}
+		b.reader = reader
+	}
+	result, err := b.reader.Call("read").Await(r.ctx)
+	if err != nil {
+		b.fail(err)
+		return
+	}
+	if result.Get("done").MustBool() {
+		b.err = io.EOF
+		r.stop()
+		return
+	}
+	b.buf, err = result.Get("value").Bytes()
+	if err != nil {
+		b.fail(err)
+	}
+}
+
+func (b *body) fail(err error) {
+	b.err = b.response.error(err)
+	b.response.stop()
+}
+
+// Close implements io.Closer. It cancels the rest of the download.
+func (b *body) Close() error {
+	if b.closed {
+		return nil
+	}
+	b.closed = true
+	b.buf = nil
+	if b.err == nil {
+		if b.reader != nil {
+			b.reader.Call("cancel")
+		} else if stream := b.response.Value.Get("body"); !stream.IsNull() && !stream.IsUndefined() {
+			stream.Call("cancel")
+		}
+	}
+	b.response.stop()
+	return nil
+}
@@ -0,0 +1,401 @@
+//go:build js && wasm
+// +build js,wasm
+
+package idb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/abdorrahmani/go-wasm/js"
+)
+
+// ErrAborted is wrapped by the errors of transactions that were aborted by
+// the browser, for example because a constraint failed while committing or
+// the disk is full
+var ErrAborted = errors.New("idb: transaction aborted")
+
+// Mode is the mode of a transaction
+type Mode string
+
+// Transaction modes
+const (
+	ReadOnly  Mode = "readonly"
+	ReadWrite Mode = "readwrite"
+)
+
+// DB is a connection to a database
+type DB struct {
+	Value *js.Value
+}
+
+// Open opens the database with the given name, creating it if it does not
+// exist. If version is greater than the version of the database, upgrade is
+// called to create or change the object stores and indexes, and an error it
+// returns aborts the upgrade and is returned by Open. A version of 0 opens
+// the current version.
+//
+// Open waits while other connections to the database block the upgrade.
+// If ctx is cancelled first, Open returns and the connection is closed once
+// the browser opens it.
+func Open(ctx context.Context, name string, version int, upgrade func(*Upgrade) error) (*DB, error) {
+	factory, err := indexedDB()
+	if err != nil {
+		return nil, err
+	}
+	args := []interface{}{name}
+	if version > 0 {
+		args = append(args, version)
+	}
+	request, err := factory.CallE("open", args...)
+	if err != nil {
+		return nil, fmt.Errorf("idb: opening %s: %w", name, err)
+	}
+
+	upgrades := make(chan *js.Value, 1)
+	done := make(chan error, 1)
+	scope := js.NewScope()
+	request.Call("addEventListener", "upgradeneeded", scope.NewCallback(func(args []*js.Value) {
+		upgrades <- args[0]
+	}))
+	request.Call("addEventListener", "success", scope.NewCallback(func(args []*js.Value) {
+		done <- nil
+	}))
+	request.Call("addEventListener", "error", scope.NewCallback(func(args []*js.Value) {
+		args[0].Call("preventDefault")
+		done <- requestError(request)
+	}))
+
+	var upgradeTx *Tx
+	var upgradeErr error
+	for {
+		select {
+		case e := <-upgrades:
+			// The upgrade runs before the callback returns, while the
+			// version change transaction is active
+			upgradeTx = newTx(request.Get("transaction"))
+			u := &Upgrade{
+				Tx:         upgradeTx,
+				OldVersion: e.Get("oldVersion").MustInt(),
+				NewVersion: e.Get("newVersion").MustInt(),
+				db:         request.Get("result"),
+			}
+			if upgrade != nil {
+				upgradeErr = upgrade(u)
+			}
+			if upgradeErr != nil {
+				u.abort()
+			}
+		case err := <-done:
+			scope.Release()
+			if upgradeErr != nil {
+				return nil, upgradeErr
+			}
+			if err != nil {
+				if ctxErr := ctx.Err(); ctxErr != nil {
+					return nil, ctxErr
+				}
+				// The abort event of the upgrade comes first and has the
+				// reason for the failure
+				if upgradeTx != nil {
+					<-upgradeTx.done
+					if upgradeTx.err != nil {
+						err = upgradeTx.err
+					}
+				}
+				return nil, fmt.Errorf("idb: opening %s: %w", name, err)
+			}
+			return &DB{Value: request.Get("result")}, nil
+		case <-ctx.Done():
+			// Requests cannot be cancelled, so an upgrade is aborted and
+			// a connection is closed once it arrives
+			go func() {
+				for {
+					select {
+					case <-upgrades:
+						request.Get("transaction").CallE("abort")
+					case err := <-done:
+						if err == nil {
+							request.Get("result").Call("close")
+						}
+						scope.Release()
+						return
+					}
+				}
+			}()
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// Delete deletes the database with the given name. It waits while other
+// connections to the database are open.
+func Delete(ctx context.Context, name string) error {
+	factory, err := indexedDB()
+	if err != nil {
+		return err
+	}
+	request, err := factory.CallE("deleteDatabase", name)
+	if err != nil {
+		return fmt.Errorf("idb: deleting %s: %w", name, err)
+	}
+	done := make(chan error, 1)
+	scope := js.NewScope()
+	defer scope.Release()
+	request.Call("addEventListener", "success", scope.NewCallback(func(args []*js.Value) {
+		done <- nil
+	}))
+	request.Call("addEventListener", "error", scope.NewCallback(func(args []*js.Value) {
+		args[0].Call("preventDefault")
+		done <- requestError(request)
+	}))
+	select {
+	case err := <-done:
+		if err != nil {
+			return fmt.Errorf("idb: deleting %s: %w", name, err)
+		}
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func indexedDB() (*js.Value, error) {
+	// Reading the property throws when storage is blocked
+	factory, err := js.Global().GetE("indexedDB")
+	if err != nil {
+		return nil, fmt.Errorf("idb: IndexedDB is not available: %w", err)
+	}
+	if factory.IsUndefined() || factory.IsNull() {
+		return nil, errors.New("idb: IndexedDB is not available")
+	}
+	return factory, nil
+}
+
+// Close closes the connection once its transactions have finished
+func (db *DB) Close() {
+	db.Value.Call("close")
+}
+
+// GetName returns the name of the database
+func (db *DB) GetName() string {
+	return db.Value.Get("name").MustString()
+}
+
+// GetVersion returns the version of the database
+func (db *DB) GetVersion() int {
+	return db.Value.Get("version").MustInt()
+}
+
+// GetStoreNames returns the names of the object stores, sorted
+func (db *DB) GetStoreNames() []string {
+	return stringList(db.Value.Get("objectStoreNames"))
+}
+
+// Tx runs fn in a transaction over the named object stores. The transaction
+// commits when fn returns nil, and is aborted when fn returns an error, which
+// Tx returns, or when ctx is cancelled, in which case Tx returns ctx.Err().
+//
+// Errors of requests made by fn do not abort the transaction unless fn
+// returns them. Tx must not be called from a JavaScript callback, as it
+// waits for the transaction to finish.
+func (db *DB) Tx(ctx context.Context, stores []string, mode Mode, fn func(*Tx) error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	value, err := db.Value.CallE("transaction", js.MustMarshal(stores), string(mode))
+	if err != nil {
+		return fmt.Errorf("idb: %w", err)
+	}
+	tx := newTx(value)
+	stop := context.AfterFunc(ctx, tx.abort)
+	defer stop()
+
+	if err := fn(tx); err != nil {
+		tx.abort()
+		<-tx.done
+		// The requests of fn fail once cancelling aborts the transaction
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+		return err
+	}
+	// Committing explicitly saves waiting for the browser to notice that no
+	// requests are left; older browsers lack commit
+	if value.Get("commit").Type() == js.TypeFunction {
+		value.CallE("commit")
+	}
+	<-tx.done
+	if tx.err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+		return tx.err
+	}
+	return nil
+}
+
+// Tx is a transaction
+type Tx struct {
+	Value *js.Value
+	scope *js.Scope
+	// done is closed once the transaction has committed or aborted, and err
+	// is set if it aborted
+	done chan struct{}
+	err  error
+}
+
+func newTx(value *js.Value) *Tx {
+	tx := &Tx{Value: value, scope: js.NewScope(), done: make(chan struct{})}
+	value.Call("addEventListener", "complete", tx.scope.NewCallback(func(args []*js.Value) {
+		tx.finish(nil)
+	}))
+	value.Call("addEventListener", "abort", tx.scope.NewCallback(func(args []*js.Value) {
+		err := ErrAborted
+		if e := value.Get("error"); !e.IsNull() && !e.IsUndefined() {
+			err = fmt.Errorf("%w: %w", ErrAborted, domError(e))
+		}
+		tx.finish(err)
+	}))
+	return tx
+}
+
+func (tx *Tx) finish(err error) {
+	select {
+	case <-tx.done:
+		return
+	default:
+	}
+	tx.err = err
+	tx.scope.Release()
+	close(tx.done)
+}
+
+// abort aborts the transaction unless it has already finished
+func (tx *Tx) abort() {
+	tx.Value.CallE("abort")
+}
+
+// GetMode returns the mode of the transaction, or "versionchange" during an
+// upgrade
+func (tx *Tx) GetMode() Mode {
+	return Mode(tx.Value.Get("mode").MustString())
+}
+
+// Store returns an object store in the scope of the transaction. If there is
+// no such store, its methods return the error.
+func (tx *Tx) Store(name string) *Store {
+	s := &Store{tx: tx, name: name}
+	s.Value, s.err = tx.Value.CallE("objectStore", name)
+	if s.err != nil {
+		s.err = fmt.Errorf("idb: %w", s.err)
+	}
+	return s
+}
+
+// wait waits for a request of the transaction and returns its result. The
+// error event is cancelled, so that the transaction is not aborted.
+func (tx *Tx) wait(request *js.Value) (*js.Value, error) {
+	result := make(chan error, 1)
+	success := js.NewCallback(func(args []*js.Value) {
+		result <- nil
+	})
+	failure := js.NewCallback(func(args []*js.Value) {
+		args[0].Call("preventDefault")
+		result <- requestError(request)
+	})
+	request.Call("addEventListener", "success", success)
+	request.Call("addEventListener", "error", failure)
+	defer func() {
+		request.Call("removeEventListener", "success", success)
+		request.Call("removeEventListener", "error", failure)
+		success.Release()
+		failure.Release()
+	}()
+
+	select {
+	case err := <-result:
+		if err != nil {
+			return nil, err
+		}
+		return request.Get("result"), nil
+	case <-tx.done:
+		if tx.err != nil {
+			return nil, tx.err
+		}
+		return nil, errors.New("idb: transaction has finished")
+	}
+}
+
+// StoreOptions configures a new object store
+type StoreOptions struct {
+	// KeyPath is the property holding the key of each value, with dots for
+	// nested properties. If it is empty, keys are passed to Put separately.
+	KeyPath string
+	// AutoIncrement generates keys for values put without one
+	AutoIncrement bool
+}
+
+// Upgrade is the version change transaction passed to the upgrade function
+// of Open. Only an upgrade can change the object stores and indexes.
+type Upgrade struct {
+	*Tx
+	// OldVersion is the version being upgraded from, or 0 for a new database
+	OldVersion int
+	// NewVersion is the version being upgraded to
+	NewVersion int
+	db         *js.Value
+}
+
+// CreateStore creates an object store
+func (u *Upgrade) CreateStore(name string, opts StoreOptions) (*Store, error) {
+	options := js.Global().Get("Object").New()
+	if opts.KeyPath != "" {
+		options.Set("keyPath", opts.KeyPath)
+	}
+	options.Set("autoIncrement", opts.AutoIncrement)
+	if _, err := u.db.CallE("createObjectStore", name, options); err != nil {
+		return nil, fmt.Errorf("idb: creating store %s: %w", name, err)
+	}
+	return u.Store(name), nil
+}
+
+// DeleteStore deletes an object store and its records
+func (u *Upgrade) DeleteStore(name string) error {
+	if _, err := u.db.CallE("deleteObjectStore", name); err != nil {
+		return fmt.Errorf("idb: deleting store %s: %w", name, err)
+	}
+	return nil
+}
+
+// requestError returns the error of a failed request
+func requestError(request *js.Value) error {
+	e, err := request.GetE("error")
+	if err != nil {
+		return err
+	}
+	if e.IsNull() || e.IsUndefined() {
+		return errors.New("unknown error")
+	}
+	return domError(e)
+}
+
+// domError converts a DOMException into a *js.Error
+func domError(e *js.Value) error {
+	return &js.Error{
+		Name:    e.Get("name").TryString(""),
+		Message: e.Get("message").TryString(""),
+		Value:   e,
+	}
+}
+
+// stringList converts a DOMStringList
+func stringList(list *js.Value) []string {
+	n := list.Get("length").MustInt()
+	names := make([]string, n)
+	for i := range names {
+		names[i] = list.Call("item", i).MustString()
+	}
+	return names
+}
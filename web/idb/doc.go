@@ -0,0 +1,44 @@
+// Package idb wraps the IndexedDB API of the browser with blocking calls that
+// take a context, and transactions that run a Go function:
+//
+//	db, err := idb.Open(ctx, "app", 1, func(u *idb.Upgrade) error {
+//		users, err := u.CreateStore("users", idb.StoreOptions{KeyPath: "id"})
+//		if err != nil {
+//			return err
+//		}
+//		return users.CreateIndex("email", "email", idb.IndexOptions{Unique: true})
+//	})
+//	if err != nil {
+//		return err
+//	}
+//	defer db.Close()
+//	err = db.Tx(ctx, []string{"users"}, idb.ReadWrite, func(tx *idb.Tx) error {
+//		users := tx.Store("users")
+//		if _, err := users.Put(nil, User{ID: 1, Email: "gopher@example.com"}); err != nil {
+//			return err
+//		}
+//		for c, err := range users.Cursor(nil, idb.Next) {
+//			if err != nil {
+//				return err
+//			}
+//			...
+//		}
+//		return nil
+//	})
+//
+// Cursors are iterators that Go 1.23 and later can range over, as above;
+// with earlier versions, call them with the loop body as a function that
+// returns false to stop.
+//
+// The transaction commits when the function returns nil and is aborted,
+// undoing its changes, when it returns an error. Values are converted with
+// js.Marshal and Value.Decode, so key paths and index key paths name the
+// properties given by the `js` or `json` struct tags.
+//
+// The browser commits a transaction as soon as it has no pending requests
+// once control returns to the event loop, so the function must not wait for
+// anything but the requests of the transaction, such as a channel, a timer
+// or a fetch.
+//
+// The package is only available when compiled for js/wasm.
+package idb
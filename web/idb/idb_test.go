@@ -0,0 +1,534 @@
+//go:build js && wasm
+// +build js,wasm
+
+package idb_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/abdorrahmani/go-wasm/js"
+	"github.com/abdorrahmani/go-wasm/web/idb"
+)
+
+type user struct {
+	ID    int      `js:"id"`
+	Name  string   `js:"name"`
+	Email string   `js:"email"`
+	Age   int      `js:"age"`
+	Tags  []string `js:"tags"`
+}
+
+var users = []user{
+	{ID: 1, Name: "Ada", Email: "ada@example.com", Age: 36, Tags: []string{"math", "engines"}},
+	{ID: 2, Name: "Grace", Email: "grace@example.com", Age: 45, Tags: []string{"compilers"}},
+	{ID: 3, Name: "Alan", Email: "alan@example.com", Age: 41, Tags: []string{"math"}},
+	{ID: 4, Name: "Edsger", Email: "edsger@example.com", Age: 36},
+}
+
+// openUsers opens a new database with a users store holding users
+func openUsers(ctx context.Context, name string) (*idb.DB, error) {
+	db, err := idb.Open(ctx, name, 1, func(u *idb.Upgrade) error {
+		store, err := u.CreateStore("users", idb.StoreOptions{KeyPath: "id"})
+		if err != nil {
+			return err
+		}
+		if err := store.CreateIndex("email", "email", idb.IndexOptions{Unique: true}); err != nil {
+			return err
+		}
+		if err := store.CreateIndex("age", "age", idb.IndexOptions{}); err != nil {
+			return err
+		}
+		if err := store.CreateIndex("tags", "tags", idb.IndexOptions{MultiEntry: true}); err != nil {
+			return err
+		}
+		for _, u := range users {
+			if _, err := store.Add(nil, u); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return db, nil
+}
+
+// closeAndDelete closes the connection and deletes its database
+func closeAndDelete(db *idb.DB) {
+	name := db.GetName()
+	db.Close()
+	idb.Delete(context.Background(), name)
+}
+
+// errorName returns the name of the JavaScript error wrapped by err
+func errorName(err error) string {
+	var jsErr *js.Error
+	if errors.As(err, &jsErr) {
+		return jsErr.Name
+	}
+	return ""
+}
+
+// names collects the names of the users visited by a cursor. The iterators
+// are called directly, as the module supports Go versions without range over
+// functions.
+func names(cursor func(func(*idb.Cursor, error) bool)) ([]string, error) {
+	var visited []string
+	var err error
+	cursor(func(c *idb.Cursor, cursorErr error) bool {
+		if err = cursorErr; err != nil {
+			return false
+		}
+		var u user
+		if err = c.Decode(&u); err != nil {
+			return false
+		}
+		visited = append(visited, u.Name)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return visited, nil
+}
+
+func TestIndexedDB(t *testing.T) {
+	if js.Global().Get("indexedDB").IsUndefined() {
+		t.Skip("IndexedDB is not available")
+	}
+	fmt.Println("Starting idb tests...")
+
+	ctx := context.Background()
+	live := js.LiveCallbacks()
+
+	tests := []struct {
+		name     string
+		validate func() error
+	}{
+		{
+			name: "Open And Upgrade",
+			validate: func() error {
+				db, err := idb.Open(ctx, "upgrade", 1, func(u *idb.Upgrade) error {
+					if u.OldVersion != 0 || u.NewVersion != 1 || u.GetMode() != "versionchange" {
+						return fmt.Errorf("unexpected upgrade %d -> %d in mode %s", u.OldVersion, u.NewVersion, u.GetMode())
+					}
+					if _, err := u.CreateStore("notes", idb.StoreOptions{AutoIncrement: true}); err != nil {
+						return err
+					}
+					_, err := u.CreateStore("settings", idb.StoreOptions{})
+					return err
+				})
+				if err != nil {
+					return err
+				}
+				if db.GetVersion() != 1 || !reflect.DeepEqual(db.GetStoreNames(), []string{"notes", "settings"}) {
+					return fmt.Errorf("unexpected version %d or stores %v", db.GetVersion(), db.GetStoreNames())
+				}
+				db.Close()
+
+				var oldVersion int
+				db, err = idb.Open(ctx, "upgrade", 2, func(u *idb.Upgrade) error {
+					oldVersion = u.OldVersion
+					if err := u.DeleteStore("notes"); err != nil {
+						return err
+					}
+					if err := u.DeleteStore("missing"); errorName(err) != "NotFoundError" {
+						return fmt.Errorf("expected a NotFoundError, got %v", err)
+					}
+					_, err := u.CreateStore("logs", idb.StoreOptions{KeyPath: "time"})
+					return err
+				})
+				if err != nil {
+					return err
+				}
+				if oldVersion != 1 || !reflect.DeepEqual(db.GetStoreNames(), []string{"logs", "settings"}) {
+					return fmt.Errorf("unexpected old version %d or stores %v", oldVersion, db.GetStoreNames())
+				}
+				db.Close()
+
+				db, err = idb.Open(ctx, "upgrade", 0, func(u *idb.Upgrade) error {
+					return errors.New("no upgrade was needed")
+				})
+				if err != nil {
+					return err
+				}
+				defer closeAndDelete(db)
+				if db.GetVersion() != 2 {
+					return fmt.Errorf("expected the current version, got %d", db.GetVersion())
+				}
+				return nil
+			},
+		},
+		{
+			name: "Failed Upgrades Are Rolled Back",
+			validate: func() error {
+				db, err := openUsers(ctx, "rollback")
+				if err != nil {
+					return err
+				}
+				db.Close()
+				boom := errors.New("boom")
+				_, err = idb.Open(ctx, "rollback", 2, func(u *idb.Upgrade) error {
+					if err := u.DeleteStore("users"); err != nil {
+						return err
+					}
+					return boom
+				})
+				if err != boom {
+					return fmt.Errorf("expected the upgrade error, got %v", err)
+				}
+				_, err = idb.Open(ctx, "rollback", 2, func(u *idb.Upgrade) error {
+					// Two users have the same age
+					return u.Store("users").CreateIndex("age-unique", "age", idb.IndexOptions{Unique: true})
+				})
+				if !errors.Is(err, idb.ErrAborted) || errorName(err) != "ConstraintError" {
+					return fmt.Errorf("expected the upgrade to be aborted with a ConstraintError, got %v", err)
+				}
+
+				db, err = idb.Open(ctx, "rollback", 0, nil)
+				if err != nil {
+					return err
+				}
+				defer closeAndDelete(db)
+				if db.GetVersion() != 1 || !reflect.DeepEqual(db.GetStoreNames(), []string{"users"}) {
+					return fmt.Errorf("unexpected version %d or stores %v", db.GetVersion(), db.GetStoreNames())
+				}
+				return db.Tx(ctx, []string{"users"}, idb.ReadOnly, func(tx *idb.Tx) error {
+					if n, err := tx.Store("users").Count(nil); err != nil || n != len(users) {
+						return fmt.Errorf("expected %d users, got %d, %v", len(users), n, err)
+					}
+					return nil
+				})
+			},
+		},
+		{
+			name: "Get Put Delete Count",
+			validate: func() error {
+				db, err := openUsers(ctx, "crud")
+				if err != nil {
+					return err
+				}
+				defer closeAndDelete(db)
+
+				err = db.Tx(ctx, []string{"users"}, idb.ReadWrite, func(tx *idb.Tx) error {
+					store := tx.Store("users")
+					var u user
+					if ok, err := store.Get(2, &u); err != nil || !ok || !reflect.DeepEqual(u, users[1]) {
+						return fmt.Errorf("expected %+v, got %+v, %v, %v", users[1], u, ok, err)
+					}
+					if ok, err := store.Get(99, &u); err != nil || ok {
+						return fmt.Errorf("expected a missing user, got %v, %v", ok, err)
+					}
+					key, err := store.Put(nil, user{ID: 5, Name: "Barbara", Email: "barbara@example.com", Age: 36})
+					if err != nil || key.MustInt() != 5 {
+						return fmt.Errorf("expected the key 5, got %v, %v", key, err)
+					}
+					if n, err := store.Count(nil); err != nil || n != 5 {
+						return fmt.Errorf("expected 5 users, got %d, %v", n, err)
+					}
+					if n, err := store.Index("age").Count(idb.Only(36)); err != nil || n != 3 {
+						return fmt.Errorf("expected 3 users aged 36, got %d, %v", n, err)
+					}
+					if n, err := store.Index("tags").Count(idb.Only("math")); err != nil || n != 2 {
+						return fmt.Errorf("expected 2 users tagged math, got %d, %v", n, err)
+					}
+					if ok, err := store.Index("email").Get("alan@example.com", &u); err != nil || !ok || u.ID != 3 {
+						return fmt.Errorf("expected Alan by email, got %+v, %v, %v", u, ok, err)
+					}
+					if err := store.Delete(idb.Bound(3, 4, false, false)); err != nil {
+						return err
+					}
+					var all []user
+					if err := store.GetAll(nil, &all); err != nil {
+						return err
+					}
+					if len(all) != 3 || all[0].Name != "Ada" || all[1].Name != "Grace" || all[2].Name != "Barbara" {
+						return fmt.Errorf("unexpected users: %+v", all)
+					}
+					return nil
+				})
+				if err != nil {
+					return err
+				}
+
+				return db.Tx(ctx, []string{"users"}, idb.ReadOnly, func(tx *idb.Tx) error {
+					if n, err := tx.Store("users").Count(nil); err != nil || n != 3 {
+						return fmt.Errorf("expected the changes to be committed, got %d users, %v", n, err)
+					}
+					return nil
+				})
+			},
+		},
+		{
+			name: "Generated And Out-Of-Line Keys",
+			validate: func() error {
+				db, err := idb.Open(ctx, "keys", 1, func(u *idb.Upgrade) error {
+					if _, err := u.CreateStore("notes", idb.StoreOptions{KeyPath: "id", AutoIncrement: true}); err != nil {
+						return err
+					}
+					_, err := u.CreateStore("events", idb.StoreOptions{})
+					return err
+				})
+				if err != nil {
+					return err
+				}
+				defer closeAndDelete(db)
+
+				type note struct {
+					ID   int    `js:"id,omitempty"`
+					Text string `js:"text"`
+				}
+				return db.Tx(ctx, []string{"notes", "events"}, idb.ReadWrite, func(tx *idb.Tx) error {
+					notes := tx.Store("notes")
+					for i, text := range []string{"first", "second"} {
+						if key, err := notes.Put(nil, note{Text: text}); err != nil || key.MustInt() != i+1 {
+							return fmt.Errorf("expected the generated key %d, got %v, %v", i+1, key, err)
+						}
+					}
+					var n note
+					if ok, err := notes.Get(2, &n); err != nil || !ok || n != (note{ID: 2, Text: "second"}) {
+						return fmt.Errorf("expected the key to be stored in the value, got %+v, %v, %v", n, ok, err)
+					}
+
+					events := tx.Store("events")
+					day := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+					if _, err := events.Put(day, "launch"); err != nil {
+						return err
+					}
+					if _, err := events.Put(day.Add(-time.Hour), "rehearsal"); err != nil {
+						return err
+					}
+					if _, err := events.Put(nil, "no key"); errorName(err) != "DataError" {
+						return fmt.Errorf("expected a DataError, got %v", err)
+					}
+					var all []string
+					if err := events.GetAll(idb.UpperBound(day, false), &all); err != nil || !reflect.DeepEqual(all, []string{"rehearsal", "launch"}) {
+						return fmt.Errorf("expected the events in time order, got %v, %v", all, err)
+					}
+					return nil
+				})
+			},
+		},
+		{
+			name: "Errors Abort The Transaction",
+			validate: func() error {
+				db, err := openUsers(ctx, "abort")
+				if err != nil {
+					return err
+				}
+				defer closeAndDelete(db)
+
+				var addErr error
+				err = db.Tx(ctx, []string{"users"}, idb.ReadWrite, func(tx *idb.Tx) error {
+					store := tx.Store("users")
+					if err := store.Delete(1); err != nil {
+						return err
+					}
+					_, addErr = store.Add(nil, users[1])
+					return addErr
+				})
+				if err != addErr || errorName(err) != "ConstraintError" {
+					return fmt.Errorf("expected a ConstraintError, got %v", err)
+				}
+
+				// An error that is not returned does not abort
+				err = db.Tx(ctx, []string{"users"}, idb.ReadWrite, func(tx *idb.Tx) error {
+					store := tx.Store("users")
+					if _, err := store.Put(nil, user{ID: 6, Email: "ada@example.com"}); errorName(err) != "ConstraintError" {
+						return fmt.Errorf("expected a ConstraintError for the unique index, got %v", err)
+					}
+					_, err := store.Put(nil, user{ID: 7, Email: "kathleen@example.com"})
+					return err
+				})
+				if err != nil {
+					return err
+				}
+
+				return db.Tx(ctx, []string{"users"}, idb.ReadOnly, func(tx *idb.Tx) error {
+					store := tx.Store("users")
+					var u user
+					if ok, err := store.Get(1, &u); err != nil || !ok {
+						return fmt.Errorf("expected the deletion to be rolled back, got %v, %v", ok, err)
+					}
+					if n, err := store.Count(nil); err != nil || n != len(users)+1 {
+						return fmt.Errorf("expected %d users, got %d, %v", len(users)+1, n, err)
+					}
+					if _, err := store.Put(nil, u); errorName(err) != "ReadOnlyError" {
+						return fmt.Errorf("expected a ReadOnlyError, got %v", err)
+					}
+					if _, err := tx.Store("missing").Count(nil); errorName(err) != "NotFoundError" {
+						return fmt.Errorf("expected a NotFoundError, got %v", err)
+					}
+					if _, err := store.Index("missing").Count(nil); errorName(err) != "NotFoundError" {
+						return fmt.Errorf("expected a NotFoundError for the index, got %v", err)
+					}
+					return nil
+				})
+			},
+		},
+		{
+			name: "Cursors",
+			validate: func() error {
+				db, err := openUsers(ctx, "cursors")
+				if err != nil {
+					return err
+				}
+				defer closeAndDelete(db)
+
+				return db.Tx(ctx, []string{"users"}, idb.ReadWrite, func(tx *idb.Tx) error {
+					store := tx.Store("users")
+					want := map[string][]string{
+						"next":            {"Ada", "Grace", "Alan", "Edsger"},
+						"prev from 3":     {"Alan", "Grace", "Ada"},
+						"age":             {"Ada", "Edsger", "Alan", "Grace"},
+						"age prev unique": {"Grace", "Alan", "Ada"},
+						"age over 36":     {"Alan", "Grace"},
+					}
+					got := map[string][]string{}
+					var err error
+					if got["next"], err = names(store.Cursor(nil, idb.Next)); err != nil {
+						return err
+					}
+					if got["prev from 3"], err = names(store.Cursor(idb.UpperBound(3, false), idb.Prev)); err != nil {
+						return err
+					}
+					age := store.Index("age")
+					if got["age"], err = names(age.Cursor(nil, idb.Next)); err != nil {
+						return err
+					}
+					if got["age prev unique"], err = names(age.Cursor(nil, idb.PrevUnique)); err != nil {
+						return err
+					}
+					if got["age over 36"], err = names(age.Cursor(idb.LowerBound(36, true), idb.Next)); err != nil {
+						return err
+					}
+					if !reflect.DeepEqual(got, want) {
+						return fmt.Errorf("expected %v, got %v", want, got)
+					}
+
+					// Update and delete while iterating, and stop early
+					visited := 0
+					age.Cursor(idb.Only(36), idb.Next)(func(c *idb.Cursor, cursorErr error) bool {
+						if err = cursorErr; err != nil {
+							return false
+						}
+						visited++
+						if c.Key().MustInt() != 36 {
+							err = fmt.Errorf("unexpected index key %v", c.Key())
+							return false
+						}
+						var u user
+						if err = c.Decode(&u); err != nil {
+							return false
+						}
+						if u.Name == "Ada" {
+							u.Age = 37
+							err = c.Update(u)
+							return err == nil
+						}
+						if err = c.Delete(); err != nil {
+							return false
+						}
+						if c.PrimaryKey().MustInt() != 4 {
+							err = fmt.Errorf("unexpected primary key %v", c.PrimaryKey())
+						}
+						return false
+					})
+					if err != nil {
+						return err
+					}
+					if visited != 2 {
+						return fmt.Errorf("expected to visit 2 users, visited %d", visited)
+					}
+					var all []user
+					if err := store.GetAll(nil, &all); err != nil {
+						return err
+					}
+					if len(all) != 3 || all[0].Age != 37 {
+						return fmt.Errorf("unexpected users after updating: %+v", all)
+					}
+					store.Cursor(idb.Bound(3, 1, false, false), idb.Next)(func(c *idb.Cursor, cursorErr error) bool {
+						err = cursorErr
+						return true
+					})
+					if errorName(err) != "DataError" {
+						return fmt.Errorf("expected a DataError for an empty range, got %v", err)
+					}
+					return nil
+				})
+			},
+		},
+		{
+			name: "Cancellation",
+			validate: func() error {
+				db, err := openUsers(ctx, "cancel")
+				if err != nil {
+					return err
+				}
+				defer closeAndDelete(db)
+
+				canceled, cancel := context.WithCancel(ctx)
+				cancel()
+				if err := db.Tx(canceled, []string{"users"}, idb.ReadOnly, func(tx *idb.Tx) error {
+					return errors.New("fn should not be called")
+				}); err != context.Canceled {
+					return fmt.Errorf("expected context.Canceled, got %v", err)
+				}
+				if _, err := idb.Open(canceled, "cancel-open", 1, nil); err != context.Canceled {
+					return fmt.Errorf("expected context.Canceled from Open, got %v", err)
+				}
+
+				txCtx, cancel := context.WithCancel(ctx)
+				err = db.Tx(txCtx, []string{"users"}, idb.ReadWrite, func(tx *idb.Tx) error {
+					store := tx.Store("users")
+					if err := store.Clear(); err != nil {
+						return err
+					}
+					cancel()
+					_, err := store.Count(nil)
+					return err
+				})
+				if err != context.Canceled {
+					return fmt.Errorf("expected context.Canceled, got %v", err)
+				}
+				return db.Tx(ctx, []string{"users"}, idb.ReadOnly, func(tx *idb.Tx) error {
+					if n, err := tx.Store("users").Count(nil); err != nil || n != len(users) {
+						return fmt.Errorf("expected the cancelled transaction to be rolled back, got %d users, %v", n, err)
+					}
+					return nil
+				})
+			},
+		},
+		{
+			name: "Callbacks Are Released",
+			validate: func() error {
+				if err := idb.Delete(ctx, "cancel-open"); err != nil {
+					return err
+				}
+				time.Sleep(20 * time.Millisecond)
+				if n := js.LiveCallbacks(); n != live {
+					return fmt.Errorf("expected %d live callbacks, got %d", live, n)
+				}
+				return nil
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fmt.Printf("Running test: %s\n", tt.name)
+			if err := tt.validate(); err != nil {
+				t.Errorf("validation failed: %v", err)
+				fmt.Printf("❌ Test failed: %s - %v\n", tt.name, err)
+			} else {
+				fmt.Printf("✅ Test passed: %s\n", tt.name)
+			}
+		})
+	}
+}
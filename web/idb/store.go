@@ -0,0 +1,401 @@
+//go:build js && wasm
+// +build js,wasm
+
+package idb
+
+import (
+	"fmt"
+
+	"github.com/abdorrahmani/go-wasm/js"
+)
+
+// Store is an object store in the scope of a transaction
+type Store struct {
+	Value *js.Value
+	tx    *Tx
+	name  string
+	// err is the error of looking the store up
+	err error
+}
+
+// IndexOptions configures a new index
+type IndexOptions struct {
+	// Unique makes putting a value fail if another value has the same key
+	// in the index
+	Unique bool
+	// MultiEntry indexes each element of an array separately
+	MultiEntry bool
+}
+
+// GetName returns the name of the object store
+func (s *Store) GetName() string {
+	return s.name
+}
+
+// CreateIndex creates an index of the values by the property at keyPath.
+// It can only be called during an upgrade.
+func (s *Store) CreateIndex(name, keyPath string, opts IndexOptions) error {
+	if s.err != nil {
+		return s.err
+	}
+	options := js.Global().Get("Object").New()
+	options.Set("unique", opts.Unique)
+	options.Set("multiEntry", opts.MultiEntry)
+	if _, err := s.Value.CallE("createIndex", name, keyPath, options); err != nil {
+		return fmt.Errorf("idb: creating index %s on %s: %w", name, s.name, err)
+	}
+	return nil
+}
+
+// DeleteIndex deletes an index. It can only be called during an upgrade.
+func (s *Store) DeleteIndex(name string) error {
+	if s.err != nil {
+		return s.err
+	}
+	if _, err := s.Value.CallE("deleteIndex", name); err != nil {
+		return fmt.Errorf("idb: deleting index %s on %s: %w", name, s.name, err)
+	}
+	return nil
+}
+
+// Index returns an index of the object store. If there is no such index,
+// its methods return the error.
+func (s *Store) Index(name string) *Index {
+	i := &Index{tx: s.tx, name: s.name + "." + name, err: s.err}
+	if i.err == nil {
+		i.Value, i.err = s.Value.CallE("index", name)
+		if i.err != nil {
+			i.err = fmt.Errorf("idb: %w", i.err)
+		}
+	}
+	return i
+}
+
+// Get decodes the value with the given key into target and reports whether
+// it exists. The key may also be a *KeyRange, in which case the first value
+// in the range is decoded.
+func (s *Store) Get(key, target interface{}) (bool, error) {
+	return get(s.tx, s.Value, s.name, s.err, key, target)
+}
+
+// GetAll decodes the values in query, in the order of their keys, into the
+// slice pointed to by target. A nil query selects all values.
+func (s *Store) GetAll(query *KeyRange, target interface{}) error {
+	return getAll(s.tx, s.Value, s.name, s.err, query, target)
+}
+
+// Put stores a value, replacing the value with the same key, and returns
+// the key. The key is nil for stores with a key path, which take the key
+// from the value, and may be nil for stores that generate keys.
+func (s *Store) Put(key, value interface{}) (*js.Value, error) {
+	return s.put("put", key, value)
+}
+
+// Add is like Put, but fails with a ConstraintError if a value with the
+// same key exists
+func (s *Store) Add(key, value interface{}) (*js.Value, error) {
+	return s.put("add", key, value)
+}
+
+func (s *Store) put(method string, key, value interface{}) (*js.Value, error) {
+	v, err := js.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("idb: %s on %s: %w", method, s.name, err)
+	}
+	args := []interface{}{v}
+	if key != nil {
+		k, err := toKey(key)
+		if err != nil {
+			return nil, fmt.Errorf("idb: %s on %s: %w", method, s.name, err)
+		}
+		args = append(args, k)
+	}
+	return request(s.tx, s.Value, s.name, s.err, method, args...)
+}
+
+// Delete deletes the value with the given key, or the values in a *KeyRange
+func (s *Store) Delete(key interface{}) error {
+	k, err := toKey(key)
+	if err != nil {
+		return fmt.Errorf("idb: delete on %s: %w", s.name, err)
+	}
+	_, err = request(s.tx, s.Value, s.name, s.err, "delete", k)
+	return err
+}
+
+// Clear deletes all values
+func (s *Store) Clear() error {
+	_, err := request(s.tx, s.Value, s.name, s.err, "clear")
+	return err
+}
+
+// Count returns the number of values in query. A nil query counts all
+// values.
+func (s *Store) Count(query *KeyRange) (int, error) {
+	return count(s.tx, s.Value, s.name, s.err, query)
+}
+
+// Cursor iterates over the values in query in the given direction. A nil
+// query selects all values. Iteration stops after the first error.
+//
+// The iterator calls yield with each cursor until yield returns false. It is
+// an iter.Seq2[*Cursor, error], which Go 1.23 and later can range over;
+// earlier versions call it with the loop body.
+func (s *Store) Cursor(query *KeyRange, dir Direction) func(yield func(*Cursor, error) bool) {
+	return cursor(s.tx, s.Value, s.name, s.err, query, dir)
+}
+
+// Index is an index of an object store, which orders its values by the
+// property at the key path of the index and then by their keys
+type Index struct {
+	Value *js.Value
+	tx    *Tx
+	// name includes the name of the store, for errors
+	name string
+	err  error
+}
+
+// Get decodes the first value with the given index key into target and
+// reports whether it exists. The key may also be a *KeyRange.
+func (i *Index) Get(key, target interface{}) (bool, error) {
+	return get(i.tx, i.Value, i.name, i.err, key, target)
+}
+
+// GetAll decodes the values in query, in the order of the index, into the
+// slice pointed to by target. A nil query selects all values.
+func (i *Index) GetAll(query *KeyRange, target interface{}) error {
+	return getAll(i.tx, i.Value, i.name, i.err, query, target)
+}
+
+// Count returns the number of values in query. A nil query counts all
+// values.
+func (i *Index) Count(query *KeyRange) (int, error) {
+	return count(i.tx, i.Value, i.name, i.err, query)
+}
+
+// Cursor iterates over the values in query in the given direction. Cursor
+// keys are index keys, and primary keys are the keys of the values.
+func (i *Index) Cursor(query *KeyRange, dir Direction) func(yield func(*Cursor, error) bool) {
+	return cursor(i.tx, i.Value, i.name, i.err, query, dir)
+}
+
+// request calls a method of a store or index and waits for the request it
+// returns
+func request(tx *Tx, source *js.Value, name string, lookupErr error, method string, args ...interface{}) (*js.Value, error) {
+	if lookupErr != nil {
+		return nil, lookupErr
+	}
+	req, err := source.CallE(method, args...)
+	if err == nil {
+		var result *js.Value
+		if result, err = tx.wait(req); err == nil {
+			return result, nil
+		}
+	}
+	return nil, fmt.Errorf("idb: %s on %s: %w", method, name, err)
+}
+
+func get(tx *Tx, source *js.Value, name string, lookupErr error, key, target interface{}) (bool, error) {
+	k, err := toKey(key)
+	if err != nil {
+		return false, fmt.Errorf("idb: get on %s: %w", name, err)
+	}
+	result, err := request(tx, source, name, lookupErr, "get", k)
+	if err != nil {
+		return false, err
+	}
+	if result.IsUndefined() {
+		return false, nil
+	}
+	if err := result.Decode(target); err != nil {
+		return true, fmt.Errorf("idb: decoding value from %s: %w", name, err)
+	}
+	return true, nil
+}
+
+func getAll(tx *Tx, source *js.Value, name string, lookupErr error, query *KeyRange, target interface{}) error {
+	q, err := query.value()
+	if err != nil {
+		return fmt.Errorf("idb: getAll on %s: %w", name, err)
+	}
+	result, err := request(tx, source, name, lookupErr, "getAll", q)
+	if err != nil {
+		return err
+	}
+	if err := result.Decode(target); err != nil {
+		return fmt.Errorf("idb: decoding values from %s: %w", name, err)
+	}
+	return nil
+}
+
+func count(tx *Tx, source *js.Value, name string, lookupErr error, query *KeyRange) (int, error) {
+	q, err := query.value()
+	if err != nil {
+		return 0, fmt.Errorf("idb: count on %s: %w", name, err)
+	}
+	result, err := request(tx, source, name, lookupErr, "count", q)
+	if err != nil {
+		return 0, err
+	}
+	return result.MustInt(), nil
+}
+
+// Direction is the order in which a cursor visits values
+type Direction string
+
+// Cursor directions. The unique directions visit only the first value of
+// each key of an index.
+const (
+	Next       Direction = "next"
+	NextUnique Direction = "nextunique"
+	Prev       Direction = "prev"
+	PrevUnique Direction = "prevunique"
+)
+
+// Cursor is the position of an iteration over a store or index. It is only
+// valid until the loop body returns.
+type Cursor struct {
+	Value *js.Value
+	tx    *Tx
+	name  string
+}
+
+func cursor(tx *Tx, source *js.Value, name string, lookupErr error, query *KeyRange, dir Direction) func(yield func(*Cursor, error) bool) {
+	return func(yield func(*Cursor, error) bool) {
+		if lookupErr != nil {
+			yield(nil, lookupErr)
+			return
+		}
+		q, err := query.value()
+		if err != nil {
+			yield(nil, fmt.Errorf("idb: openCursor on %s: %w", name, err))
+			return
+		}
+		req, err := source.CallE("openCursor", q, string(dir))
+		if err != nil {
+			yield(nil, fmt.Errorf("idb: openCursor on %s: %w", name, err))
+			return
+		}
+		for {
+			// Each step fires another success event at the same request
+			value, err := tx.wait(req)
+			if err != nil {
+				yield(nil, fmt.Errorf("idb: cursor on %s: %w", name, err))
+				return
+			}
+			if value.IsNull() {
+				return
+			}
+			if !yield(&Cursor{Value: value, tx: tx, name: name}, nil) {
+				return
+			}
+			if _, err := value.CallE("continue"); err != nil {
+				yield(nil, fmt.Errorf("idb: cursor on %s: %w", name, err))
+				return
+			}
+		}
+	}
+}
+
+// Key returns the key of the value, which is the index key when iterating
+// over an index
+func (c *Cursor) Key() *js.Value {
+	return c.Value.Get("key")
+}
+
+// PrimaryKey returns the key of the value in its object store
+func (c *Cursor) PrimaryKey() *js.Value {
+	return c.Value.Get("primaryKey")
+}
+
+// Decode decodes the value into target
+func (c *Cursor) Decode(target interface{}) error {
+	if err := c.Value.Get("value").Decode(target); err != nil {
+		return fmt.Errorf("idb: decoding value from %s: %w", c.name, err)
+	}
+	return nil
+}
+
+// Update replaces the value. The key of the new value must not change.
+func (c *Cursor) Update(value interface{}) error {
+	v, err := js.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("idb: update on %s: %w", c.name, err)
+	}
+	_, err = request(c.tx, c.Value, c.name, nil, "update", v)
+	return err
+}
+
+// Delete deletes the value
+func (c *Cursor) Delete() error {
+	_, err := request(c.tx, c.Value, c.name, nil, "delete")
+	return err
+}
+
+// KeyRange is an interval of keys. Keys are numbers, strings, time.Time,
+// []byte or slices of keys, ordered in that order of types.
+type KeyRange struct {
+	lower, upper         interface{}
+	lowerOpen, upperOpen bool
+}
+
+// Only returns the range containing only key
+func Only(key interface{}) *KeyRange {
+	return &KeyRange{lower: key, upper: key}
+}
+
+// Bound returns the range between lower and upper. An open bound is excluded
+// from the range.
+func Bound(lower, upper interface{}, lowerOpen, upperOpen bool) *KeyRange {
+	return &KeyRange{lower: lower, upper: upper, lowerOpen: lowerOpen, upperOpen: upperOpen}
+}
+
+// LowerBound returns the range of keys from lower
+func LowerBound(lower interface{}, open bool) *KeyRange {
+	return &KeyRange{lower: lower, lowerOpen: open}
+}
+
+// UpperBound returns the range of keys up to upper
+func UpperBound(upper interface{}, open bool) *KeyRange {
+	return &KeyRange{upper: upper, upperOpen: open}
+}
+
+// value converts the range into an IDBKeyRange, or null for a nil range
+func (r *KeyRange) value() (interface{}, error) {
+	if r == nil {
+		return nil, nil
+	}
+	var lower, upper *js.Value
+	var err error
+	if r.lower != nil {
+		if lower, err = js.Marshal(r.lower); err != nil {
+			return nil, err
+		}
+	}
+	if r.upper != nil {
+		if upper, err = js.Marshal(r.upper); err != nil {
+			return nil, err
+		}
+	}
+	keyRange := js.Global().Get("IDBKeyRange")
+	switch {
+	case lower != nil && upper != nil:
+		return keyRange.CallE("bound", lower, upper, r.lowerOpen, r.upperOpen)
+	case lower != nil:
+		return keyRange.CallE("lowerBound", lower, r.lowerOpen)
+	case upper != nil:
+		return keyRange.CallE("upperBound", upper, r.upperOpen)
+	}
+	return nil, nil
+}
+
+// toKey converts a key or *KeyRange for passing to IndexedDB
+func toKey(key interface{}) (interface{}, error) {
+	switch k := key.(type) {
+	case *KeyRange:
+		return k.value()
+	case *js.Value:
+		return k, nil
+	}
+	return js.Marshal(key)
+}
@@ -0,0 +1,23 @@
+// Package sse is a client for server-sent events built on the EventSource API
+// of the browser. Events are received from a channel instead of event
+// handlers:
+//
+//	source, err := sse.New("/api/updates", sse.Options{
+//		WithCredentials: true,
+//		Events:          []string{"price"},
+//	})
+//	if err != nil {
+//		return err
+//	}
+//	defer source.Close()
+//	for event := range source.Events() {
+//		fmt.Println(event.Type, event.ID, event.Data)
+//	}
+//
+// The browser reconnects on its own when the stream ends or the network
+// fails, sending the id of the last event in the Last-Event-ID header.
+// States reports each reconnection, and GetLastEventID returns that id.
+//
+// The package is only available when compiled for js/wasm. Package ssetest
+// serves event streams from a Go http.Handler for tests.
+package sse
@@ -0,0 +1,279 @@
+//go:build js && wasm
+// +build js,wasm
+
+package sse
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/abdorrahmani/go-wasm/js"
+)
+
+// Event is an event received from the server
+type Event struct {
+	// Type is the event name set by the server, "message" if it set none
+	Type string
+	// ID is the last event id set by the server, which may have been set by
+	// an earlier event
+	ID string
+	// Data is the data of the event, its lines joined by newlines
+	Data string
+}
+
+// ReadyState is the state of the connection
+type ReadyState int
+
+// Ready states of an EventSource
+const (
+	StateConnecting ReadyState = iota
+	StateOpen
+	StateClosed
+)
+
+// String returns the name of the state
+func (s ReadyState) String() string {
+	switch s {
+	case StateConnecting:
+		return "connecting"
+	case StateOpen:
+		return "open"
+	case StateClosed:
+		return "closed"
+	}
+	return fmt.Sprintf("ReadyState(%d)", int(s))
+}
+
+// Options configures an EventSource
+type Options struct {
+	// WithCredentials sends cookies and other credentials with cross-origin
+	// requests
+	WithCredentials bool
+	// Events are the names of the events received besides "message". More
+	// can be added later with Subscribe.
+	Events []string
+}
+
+// EventSource is a connection to a stream of server-sent events. Its methods
+// may be called from any goroutine.
+type EventSource struct {
+	Value *js.Value
+	scope *js.Scope
+
+	mu         sync.Mutex
+	subscribed map[string]bool
+	queue      []Event
+	// lastEventID is the id of the last event received
+	lastEventID string
+	// done is set once no more events are received
+	done bool
+	// notify wakes up the goroutine delivering events
+	notify chan struct{}
+	events chan Event
+	states chan ReadyState
+}
+
+// New connects to the stream at url. The connection is opened in the
+// background; events and state changes are received from Events and States.
+func New(url string, opts Options) (*EventSource, error) {
+	constructor := js.Global().Get("EventSource")
+	if constructor.IsUndefined() {
+		return nil, errors.New("sse: EventSource is not supported")
+	}
+	init := js.Global().Call("Object")
+	init.Set("withCredentials", opts.WithCredentials)
+	value, err := constructor.NewE(url, init)
+	if err != nil {
+		return nil, fmt.Errorf("sse: %w", err)
+	}
+
+	es := &EventSource{
+		Value:      value,
+		scope:      js.NewScope(),
+		subscribed: map[string]bool{},
+		notify:     make(chan struct{}, 1),
+		events:     make(chan Event),
+		states:     make(chan ReadyState, 1),
+	}
+	value.Call("addEventListener", "open", es.scope.NewCallback(func(args []*js.Value) {
+		es.mu.Lock()
+		defer es.mu.Unlock()
+		if !es.done {
+			es.setState(StateOpen)
+		}
+	}))
+	value.Call("addEventListener", "error", es.scope.NewCallback(func(args []*js.Value) {
+		es.mu.Lock()
+		defer es.mu.Unlock()
+		if es.done {
+			return
+		}
+		if ReadyState(value.Get("readyState").MustInt()) == StateConnecting {
+			// The browser reconnects on its own
+			es.setState(StateConnecting)
+			return
+		}
+		// The browser gave up, for example because the server answered
+		// with a status other than 200
+		es.setState(StateClosed)
+		es.finish()
+	}))
+	es.Subscribe("message")
+	es.Subscribe(opts.Events...)
+	go es.deliver()
+	return es, nil
+}
+
+// Events returns the channel of received events. It is closed after Close, or
+// once the browser stops reconnecting and the remaining events were received.
+// Events are queued until they are received, so the channel must be drained
+// or the source closed.
+func (es *EventSource) Events() <-chan Event {
+	return es.events
+}
+
+// States returns a channel that receives the state after each change, such as
+// StateConnecting when the connection is lost and the browser reconnects. Only
+// the latest state is kept until it is received. The channel is closed
+// together with the channel of events.
+func (es *EventSource) States() <-chan ReadyState {
+	return es.states
+}
+
+// Subscribe receives the events with the given names, in addition to those
+// already subscribed to. The "open" and "error" events of the connection can
+// be received too, with no data.
+func (es *EventSource) Subscribe(eventTypes ...string) {
+	es.mu.Lock()
+	if es.done {
+		es.mu.Unlock()
+		return
+	}
+	var added []string
+	for _, eventType := range eventTypes {
+		if !es.subscribed[eventType] {
+			es.subscribed[eventType] = true
+			added = append(added, eventType)
+		}
+	}
+	es.mu.Unlock()
+
+	for _, eventType := range added {
+		es.Value.Call("addEventListener", eventType, es.scope.NewCallback(es.receive))
+	}
+}
+
+// receive queues an event. Events other than messages, such as the open and
+// error events, have no data and keep the last event id.
+func (es *EventSource) receive(args []*js.Value) {
+	event := args[0]
+	es.mu.Lock()
+	defer es.mu.Unlock()
+	if es.done {
+		return
+	}
+	e := Event{
+		Type: event.Get("type").MustString(),
+		ID:   event.Get("lastEventId").TryString(es.lastEventID),
+		Data: event.Get("data").TryString(""),
+	}
+	es.queue = append(es.queue, e)
+	es.lastEventID = e.ID
+	es.wake()
+}
+
+// setState replaces the unreceived state, if any. The caller must hold es.mu.
+func (es *EventSource) setState(state ReadyState) {
+	select {
+	case <-es.states:
+	default:
+	}
+	es.states <- state
+}
+
+// finish stops receiving events and releases the callbacks. The caller must
+// hold es.mu.
+func (es *EventSource) finish() {
+	es.done = true
+	es.scope.Release()
+	es.wake()
+}
+
+// wake wakes up the goroutine delivering events. The caller must hold es.mu.
+func (es *EventSource) wake() {
+	select {
+	case es.notify <- struct{}{}:
+	default:
+	}
+}
+
+// deliver sends the queued events to the channel until the source is done
+func (es *EventSource) deliver() {
+	for {
+		es.mu.Lock()
+		var events chan Event
+		var next Event
+		if len(es.queue) > 0 {
+			events, next = es.events, es.queue[0]
+		}
+		done := es.done
+		es.mu.Unlock()
+		if done && events == nil {
+			close(es.events)
+			es.mu.Lock()
+			close(es.states)
+			es.mu.Unlock()
+			return
+		}
+
+		select {
+		case events <- next:
+			es.mu.Lock()
+			// Close may have dropped the queue in the meantime
+			if len(es.queue) > 0 {
+				es.queue[0] = Event{}
+				es.queue = es.queue[1:]
+			}
+			es.mu.Unlock()
+		case <-es.notify:
+		}
+	}
+}
+
+// Close closes the connection and releases its callbacks. Events that were
+// not received yet are dropped.
+func (es *EventSource) Close() {
+	es.mu.Lock()
+	defer es.mu.Unlock()
+	es.queue = nil
+	if es.done {
+		return
+	}
+	es.Value.Call("close")
+	es.finish()
+}
+
+// GetURL returns the URL of the stream
+func (es *EventSource) GetURL() string {
+	return es.Value.Get("url").MustString()
+}
+
+// GetWithCredentials returns true if credentials are sent with cross-origin
+// requests
+func (es *EventSource) GetWithCredentials() bool {
+	return es.Value.Get("withCredentials").MustBool()
+}
+
+// GetReadyState returns the state of the connection
+func (es *EventSource) GetReadyState() ReadyState {
+	return ReadyState(es.Value.Get("readyState").MustInt())
+}
+
+// GetLastEventID returns the last event id received, which the browser sends
+// in the Last-Event-ID header when it reconnects
+func (es *EventSource) GetLastEventID() string {
+	es.mu.Lock()
+	defer es.mu.Unlock()
+	return es.lastEventID
+}
@@ -0,0 +1,257 @@
+//go:build js && wasm
+// +build js,wasm
+
+package sse_test
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/abdorrahmani/go-wasm/js"
+	"github.com/abdorrahmani/go-wasm/web/sse"
+	"github.com/abdorrahmani/go-wasm/web/sse/ssetest"
+)
+
+// next receives the next event, failing if none arrives in time
+func next(source *sse.EventSource) (sse.Event, error) {
+	select {
+	case event, ok := <-source.Events():
+		if !ok {
+			return sse.Event{}, errors.New("the events channel was closed")
+		}
+		return event, nil
+	case <-time.After(time.Second):
+		return sse.Event{}, errors.New("timed out waiting for an event")
+	}
+}
+
+// nextState receives the next state, failing if none arrives in time
+func nextState(source *sse.EventSource) (sse.ReadyState, error) {
+	select {
+	case state, ok := <-source.States():
+		if !ok {
+			return 0, errors.New("the states channel was closed")
+		}
+		return state, nil
+	case <-time.After(time.Second):
+		return 0, errors.New("timed out waiting for a state")
+	}
+}
+
+// stream returns a handler that writes body as an event stream and then
+// waits for the request to be cancelled
+func stream(body string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		io.WriteString(w, body)
+		<-r.Context().Done()
+	}
+}
+
+func TestEventSource(t *testing.T) {
+	fmt.Println("Starting sse tests...")
+
+	live := js.LiveCallbacks()
+
+	tests := []struct {
+		name     string
+		validate func() error
+	}{
+		{
+			name: "Messages And Named Events",
+			validate: func() error {
+				server := ssetest.NewServer(stream(": comment\n\n" +
+					"data: hello\n\n" +
+					"event: price\nid: 1\ndata: 42\ndata: 43\n\n" +
+					"event: unsubscribed\ndata: skipped\n\n" +
+					"event: volume\r\nid: 2\r\ndata:7\r\n\r\n"))
+				defer server.Close()
+				source, err := sse.New(server.URL+"/prices", sse.Options{Events: []string{"price"}})
+				if err != nil {
+					return err
+				}
+				defer source.Close()
+				source.Subscribe("volume", "price")
+
+				if state, err := nextState(source); err != nil || state != sse.StateOpen {
+					return fmt.Errorf("expected the open state, got %v, %v", state, err)
+				}
+				want := []sse.Event{
+					{Type: "message", Data: "hello"},
+					{Type: "price", ID: "1", Data: "42\n43"},
+					{Type: "volume", ID: "2", Data: "7"},
+				}
+				for _, w := range want {
+					if event, err := next(source); err != nil || event != w {
+						return fmt.Errorf("expected %+v, got %+v, %v", w, event, err)
+					}
+				}
+				if source.GetLastEventID() != "2" || source.GetReadyState() != sse.StateOpen {
+					return fmt.Errorf("unexpected last event id %q or state %v", source.GetLastEventID(), source.GetReadyState())
+				}
+				if source.GetURL() != server.URL+"/prices" || source.GetWithCredentials() {
+					return fmt.Errorf("unexpected URL %q or credentials", source.GetURL())
+				}
+				return nil
+			},
+		},
+		{
+			name: "Open And Error Events",
+			validate: func() error {
+				// The stream ends, so the browser fires an error event and reconnects
+				server := ssetest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					w.Header().Set("Content-Type", "text/event-stream")
+					io.WriteString(w, "retry: 1000\nid: 1\ndata: hello\n\n")
+				}))
+				defer server.Close()
+				source, err := sse.New(server.URL, sse.Options{Events: []string{"open", "error"}})
+				if err != nil {
+					return err
+				}
+				defer source.Close()
+
+				want := []sse.Event{
+					{Type: "open"},
+					{Type: "message", ID: "1", Data: "hello"},
+					{Type: "error", ID: "1"},
+				}
+				for _, w := range want {
+					if event, err := next(source); err != nil || event != w {
+						return fmt.Errorf("expected %+v, got %+v, %v", w, event, err)
+					}
+				}
+				return nil
+			},
+		},
+		{
+			name: "Reconnect With Last Event ID",
+			validate: func() error {
+				var connections atomic.Int32
+				server := ssetest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					w.Header().Set("Content-Type", "text/event-stream")
+					if connections.Add(1) == 1 {
+						// The stream ends, so the browser reconnects
+						io.WriteString(w, "retry: 10\nid: a\ndata: first\n\n")
+						return
+					}
+					fmt.Fprintf(w, "data: resumed after %s\n\n", r.Header.Get("Last-Event-ID"))
+					<-r.Context().Done()
+				}))
+				defer server.Close()
+				source, err := sse.New(server.URL, sse.Options{})
+				if err != nil {
+					return err
+				}
+				defer source.Close()
+
+				for _, want := range []sse.ReadyState{sse.StateOpen, sse.StateConnecting, sse.StateOpen} {
+					if state, err := nextState(source); err != nil || state != want {
+						return fmt.Errorf("expected the %v state, got %v, %v", want, state, err)
+					}
+				}
+				if event, err := next(source); err != nil || event.Data != "first" {
+					return fmt.Errorf("unexpected first event: %+v, %v", event, err)
+				}
+				if event, err := next(source); err != nil || event.Data != "resumed after a" || event.ID != "a" {
+					return fmt.Errorf("unexpected event after reconnecting: %+v, %v", event, err)
+				}
+				return nil
+			},
+		},
+		{
+			name: "Failed Connection Closes The Channels",
+			validate: func() error {
+				server := ssetest.NewServer(http.NotFoundHandler())
+				defer server.Close()
+				source, err := sse.New(server.URL, sse.Options{WithCredentials: true})
+				if err != nil {
+					return err
+				}
+				if !source.GetWithCredentials() {
+					return fmt.Errorf("expected withCredentials to be set")
+				}
+				if _, err := next(source); err == nil {
+					return fmt.Errorf("expected the events channel to be closed")
+				}
+				if state := <-source.States(); state != sse.StateClosed {
+					return fmt.Errorf("expected the closed state, got %v", state)
+				}
+				if source.GetReadyState() != sse.StateClosed {
+					return fmt.Errorf("expected the closed state, got %v", source.GetReadyState())
+				}
+				source.Close()
+				return nil
+			},
+		},
+		{
+			name: "Close",
+			validate: func() error {
+				canceled := make(chan struct{})
+				server := ssetest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					w.Header().Set("Content-Type", "text/event-stream")
+					fmt.Fprintf(w, "data: credentials %v\n\n", ssetest.Source(r).Get("withCredentials").MustBool())
+					io.WriteString(w, "data: dropped\n\n")
+					<-r.Context().Done()
+					close(canceled)
+				}))
+				defer server.Close()
+				source, err := sse.New(server.URL, sse.Options{WithCredentials: true})
+				if err != nil {
+					return err
+				}
+				if event, err := next(source); err != nil || event.Data != "credentials true" {
+					return fmt.Errorf("unexpected event: %+v, %v", event, err)
+				}
+				source.Close()
+				source.Close()
+				if _, ok := <-source.Events(); ok {
+					return fmt.Errorf("expected the events channel to be closed")
+				}
+				select {
+				case <-canceled:
+				case <-time.After(time.Second):
+					return fmt.Errorf("the request was not cancelled")
+				}
+				return nil
+			},
+		},
+		{
+			name: "Invalid URL",
+			validate: func() error {
+				server := ssetest.NewServer(http.NotFoundHandler())
+				defer server.Close()
+				if _, err := sse.New("http://[::1", sse.Options{}); err == nil {
+					return fmt.Errorf("expected an error for an invalid URL")
+				}
+				return nil
+			},
+		},
+		{
+			name: "Callbacks Are Released",
+			validate: func() error {
+				time.Sleep(20 * time.Millisecond)
+				if n := js.LiveCallbacks(); n != live {
+					return fmt.Errorf("expected %d live callbacks, got %d", live, n)
+				}
+				return nil
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fmt.Printf("Running test: %s\n", tt.name)
+			if err := tt.validate(); err != nil {
+				t.Errorf("validation failed: %v", err)
+				fmt.Printf("❌ Test failed: %s - %v\n", tt.name, err)
+			} else {
+				fmt.Printf("✅ Test passed: %s\n", tt.name)
+			}
+		})
+	}
+}
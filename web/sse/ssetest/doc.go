@@ -0,0 +1,5 @@
+// Package ssetest serves the event streams of EventSources from a Go
+// http.Handler, without a network, so code using the sse package can be
+// tested under Node.js or in a browser. It is only available when compiled
+// for js/wasm.
+package ssetest
@@ -0,0 +1,420 @@
+//go:build js && wasm
+// +build js,wasm
+
+package ssetest
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/abdorrahmani/go-wasm/js"
+)
+
+// Server is a stand-in HTTP server for event streams. While servers are open
+// or their EventSources are, the EventSource constructor is replaced by one
+// whose requests are served by the handlers of the servers.
+//
+// The replacement follows the reconnection rules of browsers: when a stream
+// ends, or its URL is under no open server, it reconnects after the retry
+// delay and sends the id of the last event in the Last-Event-ID header. A
+// response with a status other than 200 or a Content-Type other than
+// text/event-stream fails the EventSource for good.
+type Server struct {
+	// URL is the origin of the server, such as http://ssetest-1.invalid
+	URL     string
+	handler http.Handler
+	// streams holds the cancel functions of the requests being served
+	streams map[*stream]context.CancelFunc
+}
+
+// DefaultRetry is the delay before reconnecting until the server sets one
+// with a retry field. Browsers wait a few seconds.
+const DefaultRetry = 3 * time.Second
+
+var (
+	mu      sync.Mutex
+	servers = map[string]*Server{}
+	lastID  int
+	// sources maps the ids of the fake EventSources to their streams
+	sources      = map[int]*stream{}
+	lastSourceID int
+	// realEventSource is the constructor replaced while servers are open
+	realEventSource *js.Value
+	scope           *js.Scope
+)
+
+// sourceKey is the context key of the EventSource being served
+type sourceKey struct{}
+
+// fakeEventSource is the source of the replacement EventSource constructor.
+// It validates its arguments like browsers do and hands the rest to Go.
+const fakeEventSource = `class EventSource extends EventTarget {
+	constructor(url, init = {}) {
+		super();
+		if (arguments.length === 0) {
+			throw new TypeError("Failed to construct 'EventSource': 1 argument required, but only 0 present.");
+		}
+		let parsed;
+		try {
+			parsed = new URL(url);
+		} catch {
+			throw new DOMException("Failed to construct 'EventSource': Cannot open an EventSource to '" + url + "'. The URL is invalid.", "SyntaxError");
+		}
+		this.url = parsed.href;
+		this.withCredentials = Boolean(init && init.withCredentials);
+		this.readyState = EventSource.CONNECTING;
+		this.onopen = this.onmessage = this.onerror = null;
+		connect(this);
+		// Like a network, the server answers in a later task
+		setTimeout(open, 0, this);
+	}
+
+	close() {
+		if (this.readyState === EventSource.CLOSED) {
+			return;
+		}
+		this.readyState = EventSource.CLOSED;
+		close(this);
+	}
+
+	_fire(type, init) {
+		const event = Object.assign(new Event(type), init);
+		this.dispatchEvent(event);
+		if (["open", "message", "error"].includes(type) && typeof this["on" + type] === "function") {
+			this["on" + type](event);
+		}
+	}
+}
+for (const [i, name] of ["CONNECTING", "OPEN", "CLOSED"].entries()) {
+	EventSource[name] = EventSource.prototype[name] = i;
+}
+return EventSource;`
+
+// Ready states of an EventSource
+const (
+	stateConnecting = iota
+	stateOpen
+	stateClosed
+)
+
+// NewServer starts a server that serves the event streams with handler
+func NewServer(handler http.Handler) *Server {
+	mu.Lock()
+	defer mu.Unlock()
+	lastID++
+	s := &Server{
+		URL:     fmt.Sprintf("http://ssetest-%d.invalid", lastID),
+		handler: handler,
+		streams: map[*stream]context.CancelFunc{},
+	}
+	if scope == nil {
+		install()
+	}
+	servers[s.URL] = s
+	return s
+}
+
+// Close stops the server and cancels the requests it is serving. Their
+// EventSources keep reconnecting until they are closed. Once all servers and
+// EventSources are closed, the real EventSource constructor is restored.
+func (s *Server) Close() {
+	mu.Lock()
+	defer mu.Unlock()
+	if servers[s.URL] != s {
+		return
+	}
+	delete(servers, s.URL)
+	for _, cancel := range s.streams {
+		cancel()
+	}
+	uninstall()
+}
+
+// Source returns the JavaScript EventSource served by a handler, for
+// inspecting options such as withCredentials. It returns nil if r was not
+// made by a Server.
+func Source(r *http.Request) *js.Value {
+	source, _ := r.Context().Value(sourceKey{}).(*js.Value)
+	return source
+}
+
+// install replaces the EventSource constructor with the fake one. The caller
+// must hold mu.
+func install() {
+	realEventSource = js.Global().Get("EventSource")
+	scope = js.NewScope()
+	connect := scope.NewCallback(func(args []*js.Value) {
+		source := args[0]
+		ctx, cancel := context.WithCancel(context.Background())
+		st := &stream{source: source, ctx: ctx, cancel: cancel, retry: DefaultRetry}
+		mu.Lock()
+		defer mu.Unlock()
+		lastSourceID++
+		st.id = lastSourceID
+		sources[st.id] = st
+		source.Set("_id", st.id)
+	})
+	openSource := scope.NewCallback(func(args []*js.Value) {
+		if st := lookup(args[0]); st != nil {
+			go st.run()
+		}
+	})
+	closeSource := scope.NewCallback(func(args []*js.Value) {
+		if st := lookup(args[0]); st != nil {
+			st.remove()
+		}
+	})
+	constructor := js.Global().Get("Function").New("connect", "open", "close", fakeEventSource)
+	js.Global().Set("EventSource", constructor.Invoke(connect, openSource, closeSource))
+}
+
+// uninstall restores the real EventSource constructor once all servers and
+// EventSources are closed. The caller must hold mu.
+func uninstall() {
+	if scope == nil || len(servers) > 0 || len(sources) > 0 {
+		return
+	}
+	js.Global().Set("EventSource", realEventSource)
+	scope.Release()
+	scope = nil
+}
+
+// lookup returns the stream of a fake EventSource, or nil once it is closed
+func lookup(source *js.Value) *stream {
+	mu.Lock()
+	defer mu.Unlock()
+	return sources[source.Get("_id").MustInt()]
+}
+
+// stream is the client side of an EventSource, which connects to the servers
+// until it is closed
+type stream struct {
+	id     int
+	source *js.Value
+	// ctx is cancelled when the EventSource is closed
+	ctx    context.Context
+	cancel context.CancelFunc
+	// lastEventID and retry outlive the connections
+	lastEventID string
+	retry       time.Duration
+}
+
+// remove stops the stream for good
+func (st *stream) remove() {
+	st.cancel()
+	mu.Lock()
+	defer mu.Unlock()
+	delete(sources, st.id)
+	uninstall()
+}
+
+// run connects until the stream is closed or fails
+func (st *stream) run() {
+	for {
+		ok := st.connect()
+		if st.ctx.Err() != nil {
+			return
+		}
+		if !ok {
+			st.source.Set("readyState", stateClosed)
+			st.source.Call("_fire", "error")
+			st.remove()
+			return
+		}
+		st.source.Set("readyState", stateConnecting)
+		st.source.Call("_fire", "error")
+
+		timer := time.NewTimer(st.retry)
+		select {
+		case <-timer.C:
+		case <-st.ctx.Done():
+			timer.Stop()
+			return
+		}
+	}
+}
+
+// connect makes one request and dispatches the events of the response. It
+// returns false if the EventSource must fail rather than reconnect.
+func (st *stream) connect() bool {
+	origin := js.Global().Get("URL").New(st.source.Get("url")).Get("origin").MustString()
+	ctx, cancel := context.WithCancel(st.ctx)
+	defer cancel()
+	mu.Lock()
+	s := servers[origin]
+	if s != nil {
+		s.streams[st] = cancel
+	}
+	mu.Unlock()
+	if s == nil {
+		// The network failed
+		return true
+	}
+	defer func() {
+		mu.Lock()
+		delete(s.streams, st)
+		mu.Unlock()
+	}()
+
+	r, err := http.NewRequestWithContext(context.WithValue(ctx, sourceKey{}, st.source), http.MethodGet, st.source.Get("url").MustString(), nil)
+	if err != nil {
+		return false
+	}
+	r.Header.Set("Accept", "text/event-stream")
+	r.Header.Set("Cache-Control", "no-cache")
+	r.Header.Set("Sec-Fetch-Mode", "cors")
+	if st.lastEventID != "" {
+		r.Header.Set("Last-Event-ID", st.lastEventID)
+	}
+	r.RequestURI = r.URL.RequestURI()
+	r.RemoteAddr = "192.0.2.1:1234"
+
+	body, pw := io.Pipe()
+	w := &responseWriter{header: http.Header{}, body: pw, wroteHeader: make(chan struct{})}
+	go func() {
+		s.handler.ServeHTTP(w, r)
+		w.WriteHeader(http.StatusOK)
+		pw.Close()
+	}()
+	stop := context.AfterFunc(ctx, func() {
+		body.CloseWithError(ctx.Err())
+	})
+	defer stop()
+
+	select {
+	case <-w.wroteHeader:
+	case <-ctx.Done():
+		return true
+	}
+	mediaType, _, _ := mime.ParseMediaType(w.header.Get("Content-Type"))
+	if w.status != http.StatusOK || mediaType != "text/event-stream" {
+		return false
+	}
+	st.source.Set("readyState", stateOpen)
+	st.source.Call("_fire", "open")
+	st.dispatch(bufio.NewReader(body), origin)
+	return true
+}
+
+// dispatch parses the event stream and fires its events until the body ends
+func (st *stream) dispatch(body *bufio.Reader, origin string) {
+	var data strings.Builder
+	var eventType string
+	idBuffer := st.lastEventID
+	var skipLF bool
+	for {
+		line, err := readLine(body, &skipLF)
+		if err != nil {
+			// An incomplete event is discarded
+			return
+		}
+		if line == "" {
+			st.lastEventID = idBuffer
+			if data.Len() > 0 && st.ctx.Err() == nil {
+				init := js.Global().Call("Object")
+				init.Set("data", strings.TrimSuffix(data.String(), "\n"))
+				init.Set("lastEventId", st.lastEventID)
+				init.Set("origin", origin)
+				if eventType == "" {
+					eventType = "message"
+				}
+				st.source.Call("_fire", eventType, init)
+			}
+			data.Reset()
+			eventType = ""
+			continue
+		}
+		if strings.HasPrefix(line, ":") {
+			// A comment
+			continue
+		}
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+		switch field {
+		case "event":
+			eventType = value
+		case "data":
+			data.WriteString(value)
+			data.WriteByte('\n')
+		case "id":
+			if !strings.Contains(value, "\x00") {
+				idBuffer = value
+			}
+		case "retry":
+			// Only ASCII digits are allowed
+			if ms, err := strconv.Atoi(value); err == nil && strings.Trim(value, "0123456789") == "" {
+				st.retry = time.Duration(ms) * time.Millisecond
+			}
+		}
+	}
+}
+
+// readLine reads a line ended by CRLF, LF or CR. skipLF records whether the
+// last line ended with CR, so that a following LF is not read as a line.
+func readLine(r *bufio.Reader, skipLF *bool) (string, error) {
+	var line []byte
+	for {
+		b, err := r.ReadByte()
+		if err != nil {
+			return "", err
+		}
+		if *skipLF {
+			*skipLF = false
+			if b == '\n' {
+				continue
+			}
+		}
+		switch b {
+		case '\n':
+			return string(line), nil
+		case '\r':
+			*skipLF = true
+			return string(line), nil
+		}
+		line = append(line, b)
+	}
+}
+
+// responseWriter streams the response of a handler into a pipe read by the
+// stream
+type responseWriter struct {
+	header http.Header
+	body   *io.PipeWriter
+	status int
+	// wroteHeader is closed once the header is written
+	wroteHeader chan struct{}
+	once        sync.Once
+}
+
+// Header implements http.ResponseWriter
+func (w *responseWriter) Header() http.Header {
+	return w.header
+}
+
+// WriteHeader implements http.ResponseWriter
+func (w *responseWriter) WriteHeader(status int) {
+	w.once.Do(func() {
+		w.status = status
+		close(w.wroteHeader)
+	})
+}
+
+// Write implements http.ResponseWriter. It waits until the stream has read p.
+func (w *responseWriter) Write(p []byte) (int, error) {
+	w.WriteHeader(http.StatusOK)
+	return w.body.Write(p)
+}
+
+// Flush implements http.Flusher. Writes are never buffered, so it only sends
+// the header if it has not been sent yet.
+func (w *responseWriter) Flush() {
+	w.WriteHeader(http.StatusOK)
+}
@@ -0,0 +1,39 @@
+//go:build js && wasm
+// +build js,wasm
+
+package storage_test
+
+import (
+	"github.com/abdorrahmani/go-wasm/js"
+)
+
+// changeFromOtherTab changes an item of the "local" or "session" storage
+// area as another tab would, firing a storage event at the window. A nil
+// value removes the item.
+func changeFromOtherTab(area, key string, value *string) {
+	storage := js.Global().Get(area + "Storage")
+	init := js.Global().Call("Object")
+	init.Set("key", key)
+	init.Set("oldValue", storage.Call("getItem", key))
+	if value == nil {
+		storage.Call("removeItem", key)
+	} else {
+		storage.Call("setItem", key, *value)
+		init.Set("newValue", *value)
+	}
+	fireStorageEvent(storage, init)
+}
+
+// clearFromOtherTab clears the "local" or "session" storage area as another
+// tab would, firing a storage event with a null key at the window
+func clearFromOtherTab(area string) {
+	storage := js.Global().Get(area + "Storage")
+	storage.Call("clear")
+	fireStorageEvent(storage, js.Global().Call("Object"))
+}
+
+func fireStorageEvent(storage, init *js.Value) {
+	init.Set("url", js.Global().Get("document").Get("URL"))
+	init.Set("storageArea", storage)
+	js.Global().Call("dispatchEvent", js.Global().Get("StorageEvent").New("storage", init))
+}
@@ -0,0 +1,199 @@
+//go:build js && wasm
+// +build js,wasm
+
+// Package storage wraps the localStorage and sessionStorage areas of the
+// browser. Keys can be namespaced with a prefix, values can be stored as JSON,
+// and running out of space is reported as ErrQuotaExceeded:
+//
+//	store, err := storage.Local()
+//	if err != nil {
+//		return err
+//	}
+//	settings := store.WithPrefix("settings:")
+//	if err := settings.SetJSON("theme", theme); errors.Is(err, storage.ErrQuotaExceeded) {
+//		...
+//	}
+//	theme, ok, err := storage.GetJSON[Theme](settings, "theme")
+//
+// OnChange reports changes made by other tabs of the same origin.
+//
+// The package is only available when compiled for js/wasm.
+package storage
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/abdorrahmani/go-wasm/dom"
+	"github.com/abdorrahmani/go-wasm/js"
+)
+
+// ErrQuotaExceeded is returned when a value does not fit in the storage area
+var ErrQuotaExceeded = errors.New("storage: quota exceeded")
+
+// Storage is a storage area, or the part of one whose keys start with a prefix
+type Storage struct {
+	Value  *js.Value
+	prefix string
+}
+
+// Local returns the localStorage area, which persists across sessions. It
+// fails if the browser blocks storage, for example for sandboxed iframes.
+func Local() (*Storage, error) {
+	return open("localStorage")
+}
+
+// Session returns the sessionStorage area, which lasts as long as the tab
+func Session() (*Storage, error) {
+	return open("sessionStorage")
+}
+
+func open(name string) (*Storage, error) {
+	// Reading the property throws when storage is blocked
+	value, err := js.Global().GetE(name)
+	if err != nil {
+		return nil, fmt.Errorf("storage: %s is not available: %w", name, err)
+	}
+	if value.IsUndefined() || value.IsNull() {
+		return nil, fmt.Errorf("storage: %s is not available", name)
+	}
+	return &Storage{Value: value}, nil
+}
+
+// WithPrefix returns a view of the keys that start with prefix. Keys passed
+// to and returned by the view leave the prefix out. Prefixes of nested views
+// are joined.
+func (s *Storage) WithPrefix(prefix string) *Storage {
+	return &Storage{Value: s.Value, prefix: s.prefix + prefix}
+}
+
+// GetPrefix returns the prefix of the keys
+func (s *Storage) GetPrefix() string {
+	return s.prefix
+}
+
+// Get returns the value of an item and whether it exists
+func (s *Storage) Get(key string) (string, bool) {
+	value := s.Value.Call("getItem", s.prefix+key)
+	if value.IsNull() {
+		return "", false
+	}
+	return value.MustString(), true
+}
+
+// Set sets the value of an item. It returns an error wrapping
+// ErrQuotaExceeded if the storage area is full.
+func (s *Storage) Set(key, value string) error {
+	if _, err := s.Value.CallE("setItem", s.prefix+key, value); err != nil {
+		var jsErr *js.Error
+		// Old versions of Firefox use their own name
+		if errors.As(err, &jsErr) && (jsErr.Name == "QuotaExceededError" || jsErr.Name == "NS_ERROR_DOM_QUOTA_REACHED") {
+			err = ErrQuotaExceeded
+		}
+		return fmt.Errorf("storage: setting %q: %w", s.prefix+key, err)
+	}
+	return nil
+}
+
+// Remove removes an item
+func (s *Storage) Remove(key string) {
+	s.Value.Call("removeItem", s.prefix+key)
+}
+
+// Clear removes all items, or only those under the prefix
+func (s *Storage) Clear() {
+	if s.prefix == "" {
+		s.Value.Call("clear")
+		return
+	}
+	for _, key := range s.Keys() {
+		s.Remove(key)
+	}
+}
+
+// Keys returns the keys of the items, in the order of the browser
+func (s *Storage) Keys() []string {
+	n := s.Value.Get("length").MustInt()
+	keys := []string{}
+	for i := 0; i < n; i++ {
+		key := s.Value.Call("key", i).MustString()
+		if strings.HasPrefix(key, s.prefix) {
+			keys = append(keys, key[len(s.prefix):])
+		}
+	}
+	return keys
+}
+
+// SetJSON sets the value of an item to the JSON encoding of value
+func (s *Storage) SetJSON(key string, value interface{}) error {
+	b, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("storage: encoding %q: %w", s.prefix+key, err)
+	}
+	return s.Set(key, string(b))
+}
+
+// GetJSON decodes the JSON value of an item and reports whether it exists.
+// It is a function rather than a method because methods cannot have type
+// parameters.
+func GetJSON[T any](s *Storage, key string) (T, bool, error) {
+	var value T
+	text, ok := s.Get(key)
+	if !ok {
+		return value, false, nil
+	}
+	if err := json.Unmarshal([]byte(text), &value); err != nil {
+		return value, true, fmt.Errorf("storage: decoding %q: %w", s.prefix+key, err)
+	}
+	return value, true, nil
+}
+
+// Change describes a change made to the storage area by another tab
+type Change struct {
+	// Key is the key of the changed item without the prefix, or empty if
+	// the storage area was cleared
+	Key string
+	// OldValue is the previous value, or nil if the item was added
+	OldValue *string
+	// NewValue is the new value, or nil if the item was removed
+	NewValue *string
+	// Cleared is true if the storage area was cleared
+	Cleared bool
+	// URL is the URL of the page that made the change
+	URL string
+}
+
+// OnChange calls handler whenever another tab of the same origin changes an
+// item under the prefix or clears the storage area, and returns a handle that
+// removes the listener. Changes made by the page itself are not reported.
+func (s *Storage) OnChange(handler func(*Change)) *dom.Listener {
+	return dom.GetWindow().AddEventListener("storage", func(e *dom.Event) {
+		if !e.Value.Get("storageArea").Raw().Equal(s.Value.Raw()) {
+			return
+		}
+		change := &Change{
+			OldValue: optionalString(e.Value.Get("oldValue")),
+			NewValue: optionalString(e.Value.Get("newValue")),
+			URL:      e.Value.Get("url").MustString(),
+		}
+		if key := e.Value.Get("key"); key.IsNull() {
+			change.Cleared = true
+		} else if k := key.MustString(); strings.HasPrefix(k, s.prefix) {
+			change.Key = k[len(s.prefix):]
+		} else {
+			return
+		}
+		handler(change)
+	})
+}
+
+// optionalString converts a string or null
+func optionalString(v *js.Value) *string {
+	if v.IsNull() || v.IsUndefined() {
+		return nil
+	}
+	s := v.MustString()
+	return &s
+}
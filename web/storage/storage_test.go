@@ -0,0 +1,236 @@
+//go:build js && wasm
+// +build js,wasm
+
+package storage_test
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/abdorrahmani/go-wasm/js"
+	"github.com/abdorrahmani/go-wasm/web/storage"
+)
+
+type settings struct {
+	Theme    string   `json:"theme"`
+	FontSize int      `json:"fontSize"`
+	Recent   []string `json:"recent"`
+}
+
+// sorted returns keys sorted, as browsers list them in no particular order
+func sorted(keys []string) []string {
+	sort.Strings(keys)
+	return keys
+}
+
+// formatChange formats a change with the values it points to
+func formatChange(c storage.Change) string {
+	value := func(v *string) string {
+		if v == nil {
+			return "<nil>"
+		}
+		return strconv.Quote(*v)
+	}
+	return fmt.Sprintf("{Key:%q OldValue:%s NewValue:%s Cleared:%v URL:%q}", c.Key, value(c.OldValue), value(c.NewValue), c.Cleared, c.URL)
+}
+
+func TestStorage(t *testing.T) {
+	if js.Global().Get("localStorage").IsUndefined() {
+		t.Skip("Web Storage is not available")
+	}
+	fmt.Println("Starting storage tests...")
+
+	local, err := storage.Local()
+	if err != nil {
+		t.Fatal(err)
+	}
+	session, err := storage.Session()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		validate func() error
+	}{
+		{
+			name: "Get Set Remove",
+			validate: func() error {
+				if err := local.Set("name", "gopher"); err != nil {
+					return err
+				}
+				if err := local.Set("empty", ""); err != nil {
+					return err
+				}
+				if value, ok := local.Get("name"); !ok || value != "gopher" {
+					return fmt.Errorf("expected gopher, got %q, %v", value, ok)
+				}
+				if value, ok := local.Get("empty"); !ok || value != "" {
+					return fmt.Errorf("expected an empty value, got %q, %v", value, ok)
+				}
+				if _, ok := local.Get("missing"); ok {
+					return fmt.Errorf("expected a missing item")
+				}
+				if _, ok := session.Get("name"); ok {
+					return fmt.Errorf("the storage areas should be separate")
+				}
+				if keys := sorted(local.Keys()); !reflect.DeepEqual(keys, []string{"empty", "name"}) {
+					return fmt.Errorf("unexpected keys: %v", keys)
+				}
+				local.Remove("name")
+				if _, ok := local.Get("name"); ok {
+					return fmt.Errorf("expected the item to be removed")
+				}
+				local.Clear()
+				if keys := local.Keys(); len(keys) != 0 {
+					return fmt.Errorf("expected no keys, got %v", keys)
+				}
+				return nil
+			},
+		},
+		{
+			name: "Prefixes",
+			validate: func() error {
+				defer local.Clear()
+				app := local.WithPrefix("app:")
+				user := app.WithPrefix("user:")
+				local.Set("other", "1")
+				app.Set("version", "2")
+				user.Set("id", "3")
+				if user.GetPrefix() != "app:user:" {
+					return fmt.Errorf("unexpected prefix %q", user.GetPrefix())
+				}
+				if value, ok := local.Get("app:user:id"); !ok || value != "3" {
+					return fmt.Errorf("expected the prefixed key to be stored, got %q, %v", value, ok)
+				}
+				if keys := sorted(app.Keys()); !reflect.DeepEqual(keys, []string{"user:id", "version"}) {
+					return fmt.Errorf("unexpected keys under app: %v", keys)
+				}
+				if keys := user.Keys(); !reflect.DeepEqual(keys, []string{"id"}) {
+					return fmt.Errorf("unexpected keys under user: %v", keys)
+				}
+				app.Clear()
+				if keys := local.Keys(); !reflect.DeepEqual(keys, []string{"other"}) {
+					return fmt.Errorf("clearing a prefix should keep the other keys, got %v", keys)
+				}
+				return nil
+			},
+		},
+		{
+			name: "JSON Values",
+			validate: func() error {
+				defer local.Clear()
+				want := settings{Theme: "dark", FontSize: 14, Recent: []string{"a.go"}}
+				if err := local.SetJSON("settings", want); err != nil {
+					return err
+				}
+				if text, _ := local.Get("settings"); text != `{"theme":"dark","fontSize":14,"recent":["a.go"]}` {
+					return fmt.Errorf("unexpected encoding: %s", text)
+				}
+				got, ok, err := storage.GetJSON[settings](local, "settings")
+				if err != nil || !ok || !reflect.DeepEqual(got, want) {
+					return fmt.Errorf("expected %+v, got %+v, %v, %v", want, got, ok, err)
+				}
+				if _, ok, err := storage.GetJSON[settings](local, "missing"); ok || err != nil {
+					return fmt.Errorf("expected a missing item, got %v, %v", ok, err)
+				}
+				local.Set("broken", "{")
+				if _, ok, err := storage.GetJSON[settings](local, "broken"); !ok || err == nil {
+					return fmt.Errorf("expected a decoding error, got %v, %v", ok, err)
+				}
+				if n, _, err := storage.GetJSON[int](local.WithPrefix("set"), "tings"); err == nil {
+					return fmt.Errorf("expected an error decoding an object into an int, got %d", n)
+				}
+				if err := local.SetJSON("func", func() {}); err == nil {
+					return fmt.Errorf("expected an encoding error")
+				}
+				return nil
+			},
+		},
+		{
+			name: "Quota Exceeded",
+			validate: func() error {
+				defer local.Clear()
+				local.Set("small", "kept")
+				err := local.Set("big", strings.Repeat("x", 5<<20))
+				if !errors.Is(err, storage.ErrQuotaExceeded) {
+					return fmt.Errorf("expected ErrQuotaExceeded, got %v", err)
+				}
+				if _, ok := local.Get("big"); ok {
+					return fmt.Errorf("the value over the quota should not be stored")
+				}
+				if value, _ := local.Get("small"); value != "kept" {
+					return fmt.Errorf("the other items should be kept")
+				}
+				if err := local.Set("big", strings.Repeat("x", 1<<20)); err != nil {
+					return fmt.Errorf("a value under the quota should be stored: %v", err)
+				}
+				if err := local.WithPrefix("json:").SetJSON("big", strings.Repeat("x", 5<<20)); !errors.Is(err, storage.ErrQuotaExceeded) {
+					return fmt.Errorf("expected ErrQuotaExceeded from SetJSON, got %v", err)
+				}
+				return nil
+			},
+		},
+		{
+			name: "Changes From Other Tabs",
+			validate: func() error {
+				defer local.Clear()
+				app := local.WithPrefix("app:")
+				var changes []storage.Change
+				listener := app.OnChange(func(c *storage.Change) {
+					changes = append(changes, *c)
+				})
+
+				url := js.Global().Get("document").Get("URL").MustString()
+				value, updated := "1", "2"
+				changeFromOtherTab("local", "app:count", &value)
+				changeFromOtherTab("local", "app:count", &updated)
+				changeFromOtherTab("local", "unrelated", &value)
+				changeFromOtherTab("session", "app:count", &value)
+				app.Set("mine", "ignored")
+				changeFromOtherTab("local", "app:count", nil)
+				clearFromOtherTab("local")
+				listener.Remove()
+				changeFromOtherTab("local", "app:count", &value)
+
+				if len(changes) != 4 {
+					formatted := make([]string, len(changes))
+					for i, c := range changes {
+						formatted[i] = formatChange(c)
+					}
+					return fmt.Errorf("expected 4 changes, got %d: %v", len(changes), formatted)
+				}
+				if c := changes[0]; c.Key != "count" || c.OldValue != nil || c.NewValue == nil || *c.NewValue != "1" || c.Cleared || c.URL != url {
+					return fmt.Errorf("unexpected added item: %s", formatChange(c))
+				}
+				if c := changes[1]; c.OldValue == nil || *c.OldValue != "1" || c.NewValue == nil || *c.NewValue != "2" {
+					return fmt.Errorf("unexpected updated item: %s", formatChange(c))
+				}
+				if c := changes[2]; c.Key != "count" || c.OldValue == nil || *c.OldValue != "2" || c.NewValue != nil {
+					return fmt.Errorf("unexpected removed item: %s", formatChange(c))
+				}
+				if c := changes[3]; !c.Cleared || c.Key != "" || c.OldValue != nil || c.NewValue != nil {
+					return fmt.Errorf("unexpected clear: %s", formatChange(c))
+				}
+				return nil
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fmt.Printf("Running test: %s\n", tt.name)
+			if err := tt.validate(); err != nil {
+				t.Errorf("validation failed: %v", err)
+				fmt.Printf("❌ Test failed: %s - %v\n", tt.name, err)
+			} else {
+				fmt.Printf("✅ Test passed: %s\n", tt.name)
+			}
+		})
+	}
+}
@@ -0,0 +1,453 @@
+//go:build js && wasm
+// +build js,wasm
+
+package websocket
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/abdorrahmani/go-wasm/js"
+)
+
+// MessageType is the type of a message
+type MessageType int
+
+// Message types
+const (
+	MessageText MessageType = iota + 1
+	MessageBinary
+)
+
+// String returns the name of the message type
+func (t MessageType) String() string {
+	switch t {
+	case MessageText:
+		return "text"
+	case MessageBinary:
+		return "binary"
+	}
+	return fmt.Sprintf("MessageType(%d)", int(t))
+}
+
+// Close status codes defined by RFC 6455. Applications may also close with
+// codes from 3000 to 4999.
+const (
+	StatusNormalClosure    = 1000
+	StatusGoingAway        = 1001
+	StatusProtocolError    = 1002
+	StatusUnsupportedData  = 1003
+	StatusNoStatusReceived = 1005
+	StatusAbnormalClosure  = 1006
+	StatusInvalidPayload   = 1007
+	StatusPolicyViolation  = 1008
+	StatusMessageTooBig    = 1009
+	StatusInternalError    = 1011
+)
+
+// CloseError is returned by Read and Write once the connection is closed,
+// and by Dial when the connection could not be opened
+type CloseError struct {
+	// Code is the close status code, such as StatusNormalClosure
+	Code int
+	// Reason is the reason given by the side that closed the connection
+	Reason string
+	// WasClean is true if the closing handshake completed
+	WasClean bool
+}
+
+// Error implements the error interface
+func (e *CloseError) Error() string {
+	if e.Reason == "" {
+		return fmt.Sprintf("websocket: closed with status %d", e.Code)
+	}
+	return fmt.Sprintf("websocket: closed with status %d: %s", e.Code, e.Reason)
+}
+
+// CloseStatus returns the close code of err if it is or wraps a *CloseError,
+// and -1 otherwise
+func CloseStatus(err error) int {
+	var closeErr *CloseError
+	if errors.As(err, &closeErr) {
+		return closeErr.Code
+	}
+	return -1
+}
+
+// errClosing is returned by Write after Close has been called
+var errClosing = errors.New("websocket: connection is closing")
+
+// DefaultHighWaterMark is the number of queued bytes above which Write waits
+const DefaultHighWaterMark = 1 << 20
+
+// drainInterval is how often a waiting Write checks whether the queue has
+// drained, since browsers have no event for it
+const drainInterval = 10 * time.Millisecond
+
+// Backoff configures automatic reconnection. The delay before each attempt
+// is the previous one times Multiplier, starting at Initial and capped at Max.
+type Backoff struct {
+	// Initial is the delay before the first attempt, 500ms if zero
+	Initial time.Duration
+	// Max is the longest delay, 30s if zero
+	Max time.Duration
+	// Multiplier is the growth factor of the delay, 2 if zero
+	Multiplier float64
+	// MaxAttempts is the number of failed attempts in a row after which the
+	// connection gives up; zero means it never does
+	MaxAttempts int
+}
+
+// delay returns the delay before the given attempt, counting from zero
+func (b *Backoff) delay(attempt int) time.Duration {
+	d, max, multiplier := b.Initial, b.Max, b.Multiplier
+	if d <= 0 {
+		d = 500 * time.Millisecond
+	}
+	if max <= 0 {
+		max = 30 * time.Second
+	}
+	if multiplier <= 0 {
+		multiplier = 2
+	}
+	for i := 0; i < attempt && d < max; i++ {
+		d = time.Duration(float64(d) * multiplier)
+	}
+	if d > max {
+		d = max
+	}
+	return d
+}
+
+// DialOptions configures a connection
+type DialOptions struct {
+	// Protocols are the subprotocols offered to the server, in order of preference
+	Protocols []string
+	// HighWaterMark is the number of queued bytes above which Write waits,
+	// DefaultHighWaterMark if zero
+	HighWaterMark int
+	// Reconnect makes the connection reconnect when it is closed by anything
+	// but Close. Reads and writes wait while it reconnects, and fail with the
+	// last error once it gives up.
+	Reconnect *Backoff
+	// OnReconnect is called after each successful reconnection, for example
+	// to subscribe again. It runs in its own goroutine, so it may write.
+	OnReconnect func(*Conn)
+}
+
+// Conn is a WebSocket connection. Read, Write and Close may be called from
+// different goroutines. Read and Write wait for the JavaScript event loop, so
+// they must not be called from the goroutine running a callback invoked by
+// JavaScript, such as an event listener.
+type Conn struct {
+	url  string
+	opts DialOptions
+	// ctx is cancelled by Close, stopping any reconnection
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	mu     sync.Mutex
+	socket *socket
+	queue  []message
+	// changed is closed and replaced whenever the state changes
+	changed chan struct{}
+	closing bool
+	// err is set once the connection is closed for good
+	err error
+}
+
+// socket is one underlying WebSocket. A connection that reconnects goes
+// through several.
+type socket struct {
+	value *js.Value
+	scope *js.Scope
+	open  bool
+	// closeErr is set when the socket closes
+	closeErr *CloseError
+}
+
+type message struct {
+	typ  MessageType
+	data []byte
+}
+
+// Dial opens a connection and waits until it is established. Cancelling ctx
+// abandons the attempt; it does not affect the connection once Dial returns.
+func Dial(ctx context.Context, url string, protocols []string) (*Conn, error) {
+	return DialWithOptions(ctx, url, DialOptions{Protocols: protocols})
+}
+
+// DialWithOptions opens a connection configured by opts and waits until it is
+// established
+func DialWithOptions(ctx context.Context, url string, opts DialOptions) (*Conn, error) {
+	c := &Conn{
+		url:     url,
+		opts:    opts,
+		changed: make(chan struct{}),
+	}
+	c.ctx, c.cancel = context.WithCancel(context.Background())
+	if err := c.connect(ctx); err != nil {
+		c.cancel()
+		return nil, err
+	}
+	return c, nil
+}
+
+// broadcast wakes up everything waiting for a state change. The caller must hold c.mu.
+func (c *Conn) broadcast() {
+	close(c.changed)
+	c.changed = make(chan struct{})
+}
+
+// connect opens a new socket and waits until it is open
+func (c *Conn) connect(ctx context.Context) error {
+	constructor := js.Global().Get("WebSocket")
+	if constructor.IsUndefined() {
+		return errors.New("websocket: WebSocket is not supported")
+	}
+	args := []interface{}{c.url}
+	if len(c.opts.Protocols) > 0 {
+		args = append(args, js.MustMarshal(c.opts.Protocols))
+	}
+	value, err := constructor.NewE(args...)
+	if err != nil {
+		return fmt.Errorf("websocket: %w", err)
+	}
+	value.Set("binaryType", "arraybuffer")
+	s := &socket{value: value, scope: js.NewScope()}
+	c.listen(s)
+
+	c.mu.Lock()
+	c.socket = s
+	c.mu.Unlock()
+	for {
+		c.mu.Lock()
+		open, closeErr, changed := s.open, s.closeErr, c.changed
+		c.mu.Unlock()
+		if closeErr != nil {
+			return closeErr
+		}
+		if open {
+			return nil
+		}
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			value.Call("close")
+			return ctx.Err()
+		}
+	}
+}
+
+// listen registers the event handlers of a socket
+func (c *Conn) listen(s *socket) {
+	s.value.Call("addEventListener", "open", s.scope.NewCallback(func(args []*js.Value) {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		s.open = true
+		c.broadcast()
+	}))
+	s.value.Call("addEventListener", "message", s.scope.NewCallback(func(args []*js.Value) {
+		data := args[0].Get("data")
+		m := message{typ: MessageText}
+		if data.Type() == js.TypeString {
+			m.data = []byte(data.MustString())
+		} else {
+			m.typ = MessageBinary
+			m.data = data.MustBytes()
+		}
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		c.queue = append(c.queue, m)
+		c.broadcast()
+	}))
+	s.value.Call("addEventListener", "close", s.scope.NewCallback(func(args []*js.Value) {
+		event := args[0]
+		closeErr := &CloseError{
+			Code:     event.Get("code").MustInt(),
+			Reason:   event.Get("reason").MustString(),
+			WasClean: event.Get("wasClean").MustBool(),
+		}
+		s.scope.Release()
+
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		s.closeErr = closeErr
+		// A socket that never opened is handled by connect
+		if c.socket == s && s.open {
+			if !c.closing && c.opts.Reconnect != nil {
+				go c.reconnect(closeErr)
+			} else if c.err == nil {
+				c.err = closeErr
+			}
+		}
+		c.broadcast()
+	}))
+}
+
+// reconnect opens new sockets with exponential backoff until one opens, the
+// connection gives up or Close is called
+func (c *Conn) reconnect(err error) {
+	b := c.opts.Reconnect
+	for attempt := 0; b.MaxAttempts == 0 || attempt < b.MaxAttempts; attempt++ {
+		timer := time.NewTimer(b.delay(attempt))
+		select {
+		case <-timer.C:
+		case <-c.ctx.Done():
+			timer.Stop()
+		}
+		if c.ctx.Err() != nil {
+			// Close was called
+			break
+		}
+		if err = c.connect(c.ctx); err == nil {
+			if c.opts.OnReconnect != nil {
+				c.opts.OnReconnect(c)
+			}
+			return
+		}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.err == nil {
+		c.err = err
+	}
+	c.broadcast()
+}
+
+// Read waits for the next message. Once the connection is closed, the
+// messages received before are still returned, followed by a *CloseError.
+func (c *Conn) Read(ctx context.Context) (MessageType, []byte, error) {
+	for {
+		c.mu.Lock()
+		if len(c.queue) > 0 {
+			m := c.queue[0]
+			c.queue[0] = message{}
+			c.queue = c.queue[1:]
+			c.mu.Unlock()
+			return m.typ, m.data, nil
+		}
+		err, changed := c.err, c.changed
+		c.mu.Unlock()
+		if err != nil {
+			return 0, nil, err
+		}
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return 0, nil, ctx.Err()
+		}
+	}
+}
+
+// Write sends a message. Text messages must be valid UTF-8. While more than
+// the high water mark is queued for sending, Write waits for the queue to
+// drain, so fast writers are slowed down to the speed of the network.
+func (c *Conn) Write(ctx context.Context, typ MessageType, data []byte) error {
+	var payload interface{}
+	switch typ {
+	case MessageText:
+		payload = string(data)
+	case MessageBinary:
+		payload = js.FromBytes(data)
+	default:
+		return fmt.Errorf("websocket: invalid message type %d", int(typ))
+	}
+	highWaterMark := c.opts.HighWaterMark
+	if highWaterMark <= 0 {
+		highWaterMark = DefaultHighWaterMark
+	}
+
+	for {
+		c.mu.Lock()
+		err, closing, s, changed := c.err, c.closing, c.socket, c.changed
+		ready := s.open && s.closeErr == nil
+		c.mu.Unlock()
+		switch {
+		case err != nil:
+			return err
+		case closing:
+			return errClosing
+		case !ready:
+			// Wait for the connection to reopen
+			select {
+			case <-changed:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+			continue
+		}
+
+		if s.value.Get("bufferedAmount").MustInt() <= highWaterMark {
+			if _, err := s.value.CallE("send", payload); err != nil {
+				return fmt.Errorf("websocket: %w", err)
+			}
+			return nil
+		}
+		timer := time.NewTimer(drainInterval)
+		select {
+		case <-timer.C:
+		case <-changed:
+			timer.Stop()
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		}
+	}
+}
+
+// Close starts the closing handshake with a status code and a reason of at
+// most 123 bytes. It does not wait for the handshake to complete; Read
+// returns the remaining messages and then the *CloseError of the handshake.
+func (c *Conn) Close(code int, reason string) error {
+	c.mu.Lock()
+	s := c.socket
+	established := s.open && s.closeErr == nil
+	if c.closing || c.err != nil {
+		c.mu.Unlock()
+		return nil
+	}
+	c.mu.Unlock()
+
+	// Closing a socket that is already closed only validates the arguments
+	if _, err := s.value.CallE("close", code, reason); err != nil {
+		return fmt.Errorf("websocket: %w", err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.closing = true
+	c.cancel()
+	if !established && c.err == nil {
+		// The connection was reconnecting, so there is no handshake
+		c.err = &CloseError{Code: code, Reason: reason, WasClean: true}
+	}
+	c.broadcast()
+	return nil
+}
+
+// GetURL returns the URL the connection was dialed with
+func (c *Conn) GetURL() string {
+	return c.url
+}
+
+// GetProtocol returns the subprotocol selected by the server, or an empty
+// string if none was
+func (c *Conn) GetProtocol() string {
+	c.mu.Lock()
+	s := c.socket
+	c.mu.Unlock()
+	return s.value.Get("protocol").MustString()
+}
+
+// GetBufferedAmount returns the number of bytes queued for sending
+func (c *Conn) GetBufferedAmount() int {
+	c.mu.Lock()
+	s := c.socket
+	c.mu.Unlock()
+	return s.value.Get("bufferedAmount").MustInt()
+}
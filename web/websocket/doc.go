@@ -0,0 +1,25 @@
+// Package websocket is a WebSocket client built on the WebSocket API of the
+// browser. Messages are read and written with blocking calls that take a
+// context, instead of event handlers:
+//
+//	conn, err := websocket.Dial(ctx, "wss://example.com/feed", nil)
+//	if err != nil {
+//		return err
+//	}
+//	defer conn.Close(websocket.StatusNormalClosure, "")
+//	for {
+//		typ, data, err := conn.Read(ctx)
+//		if err != nil {
+//			return err
+//		}
+//		...
+//	}
+//
+// Binary messages are received as ArrayBuffers, so each one is copied once
+// into a Go slice. Write waits while too much data is queued for sending, and
+// a connection can reconnect on its own with exponential backoff; see
+// DialWithOptions.
+//
+// The package is only available when compiled for js/wasm. Package
+// websockettest serves connections from Go handlers for tests.
+package websocket
@@ -0,0 +1,301 @@
+//go:build js && wasm
+// +build js,wasm
+
+package websocket_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/abdorrahmani/go-wasm/js"
+	"github.com/abdorrahmani/go-wasm/web/websocket"
+	"github.com/abdorrahmani/go-wasm/web/websocket/websockettest"
+)
+
+// echo sends every message back to the client
+func echo(conn *websockettest.Conn) {
+	ctx := context.Background()
+	for {
+		typ, data, err := conn.Read(ctx)
+		if err != nil {
+			return
+		}
+		conn.Write(ctx, typ, data)
+	}
+}
+
+func TestWebSocket(t *testing.T) {
+	fmt.Println("Starting websocket tests...")
+
+	ctx := context.Background()
+	live := js.LiveCallbacks()
+
+	tests := []struct {
+		name     string
+		validate func() error
+	}{
+		{
+			name: "Text And Binary Messages",
+			validate: func() error {
+				server := websockettest.NewServer(echo)
+				defer server.Close()
+				conn, err := websocket.Dial(ctx, server.URL+"/echo", []string{"chat", "v2"})
+				if err != nil {
+					return err
+				}
+				defer conn.Close(websocket.StatusNormalClosure, "")
+				if conn.GetProtocol() != "chat" || conn.GetURL() != server.URL+"/echo" {
+					return fmt.Errorf("unexpected protocol %q or URL %q", conn.GetProtocol(), conn.GetURL())
+				}
+
+				binary := []byte{0, 1, 2, 255}
+				if err := conn.Write(ctx, websocket.MessageText, []byte("héllo")); err != nil {
+					return err
+				}
+				if err := conn.Write(ctx, websocket.MessageBinary, binary); err != nil {
+					return err
+				}
+				typ, data, err := conn.Read(ctx)
+				if err != nil || typ != websocket.MessageText || string(data) != "héllo" {
+					return fmt.Errorf("unexpected text message: %v %q %v", typ, data, err)
+				}
+				typ, data, err = conn.Read(ctx)
+				if err != nil || typ != websocket.MessageBinary || !bytes.Equal(data, binary) {
+					return fmt.Errorf("unexpected binary message: %v %v %v", typ, data, err)
+				}
+				if err := conn.Write(ctx, 0, nil); err == nil {
+					return fmt.Errorf("expected an error for an invalid message type")
+				}
+				return nil
+			},
+		},
+		{
+			name: "Client Close",
+			validate: func() error {
+				closed := make(chan error, 1)
+				server := websockettest.NewServer(func(conn *websockettest.Conn) {
+					_, _, err := conn.Read(ctx)
+					closed <- err
+				})
+				defer server.Close()
+				conn, err := websocket.Dial(ctx, server.URL, nil)
+				if err != nil {
+					return err
+				}
+				if err := conn.Close(1001, ""); err == nil {
+					return fmt.Errorf("browsers only allow closing with 1000 or 3000-4999")
+				}
+				if err := conn.Close(websocket.StatusNormalClosure, "bye"); err != nil {
+					return err
+				}
+				if err := <-closed; websocket.CloseStatus(err) != websocket.StatusNormalClosure {
+					return fmt.Errorf("the server saw %v", err)
+				}
+				_, _, err = conn.Read(ctx)
+				var closeErr *websocket.CloseError
+				if !errors.As(err, &closeErr) || *closeErr != (websocket.CloseError{Code: 1000, Reason: "bye", WasClean: true}) {
+					return fmt.Errorf("unexpected close error: %v", err)
+				}
+				if err := conn.Write(ctx, websocket.MessageText, []byte("late")); err == nil {
+					return fmt.Errorf("writing to a closed connection should fail")
+				}
+				return nil
+			},
+		},
+		{
+			name: "Server Close",
+			validate: func() error {
+				server := websockettest.NewServer(func(conn *websockettest.Conn) {
+					conn.Write(ctx, websocket.MessageText, []byte("last words"))
+					conn.Close(4001, "kicked")
+				})
+				defer server.Close()
+				conn, err := websocket.Dial(ctx, server.URL, nil)
+				if err != nil {
+					return err
+				}
+				if _, data, err := conn.Read(ctx); err != nil || string(data) != "last words" {
+					return fmt.Errorf("messages sent before closing should be read first, got %q, %v", data, err)
+				}
+				_, _, err = conn.Read(ctx)
+				if websocket.CloseStatus(err) != 4001 || err.Error() != "websocket: closed with status 4001: kicked" {
+					return fmt.Errorf("unexpected close error: %v", err)
+				}
+				if websocket.CloseStatus(errors.New("other")) != -1 {
+					return fmt.Errorf("CloseStatus should be -1 for other errors")
+				}
+				return nil
+			},
+		},
+		{
+			name: "Dial Failures",
+			validate: func() error {
+				server := websockettest.NewServer(echo)
+				defer server.Close()
+				_, err := websocket.Dial(ctx, "ws://unreachable.invalid", nil)
+				if websocket.CloseStatus(err) != websocket.StatusAbnormalClosure {
+					return fmt.Errorf("expected an abnormal closure, got %v", err)
+				}
+				if _, err := websocket.Dial(ctx, "http://example.com", nil); err == nil {
+					return fmt.Errorf("expected an error for a URL that is not a WebSocket URL")
+				}
+				canceled, cancel := context.WithCancel(ctx)
+				cancel()
+				if _, err := websocket.Dial(canceled, server.URL, nil); err != context.Canceled {
+					return fmt.Errorf("expected context.Canceled, got %v", err)
+				}
+				return nil
+			},
+		},
+		{
+			name: "Backpressure",
+			validate: func() error {
+				drain := make(chan struct{})
+				server := websockettest.NewServer(func(conn *websockettest.Conn) {
+					<-drain
+					echo(conn)
+				})
+				defer server.Close()
+				conn, err := websocket.DialWithOptions(ctx, server.URL, websocket.DialOptions{HighWaterMark: 10})
+				if err != nil {
+					return err
+				}
+				defer conn.Close(websocket.StatusNormalClosure, "")
+
+				chunk := []byte("12345678")
+				for i := 0; i < 2; i++ {
+					if err := conn.Write(ctx, websocket.MessageBinary, chunk); err != nil {
+						return err
+					}
+				}
+				if conn.GetBufferedAmount() != 16 {
+					return fmt.Errorf("expected 16 buffered bytes, got %d", conn.GetBufferedAmount())
+				}
+				blocked, cancel := context.WithTimeout(ctx, 30*time.Millisecond)
+				defer cancel()
+				if err := conn.Write(blocked, websocket.MessageBinary, chunk); err != context.DeadlineExceeded {
+					return fmt.Errorf("expected the write to wait for the buffer to drain, got %v", err)
+				}
+
+				close(drain)
+				if err := conn.Write(ctx, websocket.MessageBinary, chunk); err != nil {
+					return err
+				}
+				for i := 0; i < 3; i++ {
+					if _, _, err := conn.Read(ctx); err != nil {
+						return err
+					}
+				}
+				if conn.GetBufferedAmount() != 0 {
+					return fmt.Errorf("expected an empty buffer, got %d", conn.GetBufferedAmount())
+				}
+				return nil
+			},
+		},
+		{
+			name: "Reconnect With Backoff",
+			validate: func() error {
+				// Another server keeps the stand-in WebSocket installed once
+				// the first one is closed
+				other := websockettest.NewServer(echo)
+				defer other.Close()
+
+				var connections atomic.Int32
+				server := websockettest.NewServer(func(conn *websockettest.Conn) {
+					n := connections.Add(1)
+					if n > 1 {
+						// Wait for the client to subscribe again
+						if _, data, err := conn.Read(ctx); err != nil || string(data) != "subscribe" {
+							conn.Close(4000, "not subscribed")
+							return
+						}
+					}
+					conn.Write(ctx, websocket.MessageText, []byte(fmt.Sprintf("hello %d", n)))
+					if n == 1 {
+						conn.Drop()
+					}
+				})
+				conn, err := websocket.DialWithOptions(ctx, server.URL, websocket.DialOptions{
+					Reconnect: &websocket.Backoff{Initial: 5 * time.Millisecond, MaxAttempts: 3},
+					OnReconnect: func(conn *websocket.Conn) {
+						conn.Write(ctx, websocket.MessageText, []byte("subscribe"))
+					},
+				})
+				if err != nil {
+					server.Close()
+					return err
+				}
+				for _, want := range []string{"hello 1", "hello 2"} {
+					if _, data, err := conn.Read(ctx); err != nil || string(data) != want {
+						server.Close()
+						return fmt.Errorf("expected %q, got %q, %v", want, data, err)
+					}
+				}
+
+				// Once the server is gone, the connection gives up after three attempts
+				start := time.Now()
+				server.Close()
+				if _, _, err := conn.Read(ctx); websocket.CloseStatus(err) != websocket.StatusAbnormalClosure {
+					return fmt.Errorf("expected an abnormal closure, got %v", err)
+				}
+				if elapsed := time.Since(start); elapsed < 35*time.Millisecond {
+					return fmt.Errorf("the attempts should back off, gave up after %v", elapsed)
+				}
+				if connections.Load() != 2 {
+					return fmt.Errorf("expected 2 connections, got %d", connections.Load())
+				}
+				return nil
+			},
+		},
+		{
+			name: "Close While Reconnecting",
+			validate: func() error {
+				server := websockettest.NewServer(func(conn *websockettest.Conn) {
+					conn.Drop()
+				})
+				defer server.Close()
+				conn, err := websocket.DialWithOptions(ctx, server.URL, websocket.DialOptions{
+					Reconnect: &websocket.Backoff{Initial: time.Hour},
+				})
+				if err != nil {
+					return err
+				}
+				time.Sleep(10 * time.Millisecond)
+				if err := conn.Close(websocket.StatusNormalClosure, "done"); err != nil {
+					return err
+				}
+				if _, _, err := conn.Read(ctx); websocket.CloseStatus(err) != websocket.StatusNormalClosure {
+					return fmt.Errorf("expected a normal closure, got %v", err)
+				}
+				return nil
+			},
+		},
+		{
+			name: "Callbacks Are Released",
+			validate: func() error {
+				time.Sleep(20 * time.Millisecond)
+				if n := js.LiveCallbacks(); n != live {
+					return fmt.Errorf("expected %d live callbacks, got %d", live, n)
+				}
+				return nil
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fmt.Printf("Running test: %s\n", tt.name)
+			if err := tt.validate(); err != nil {
+				t.Errorf("validation failed: %v", err)
+				fmt.Printf("❌ Test failed: %s - %v\n", tt.name, err)
+			} else {
+				fmt.Printf("✅ Test passed: %s\n", tt.name)
+			}
+		})
+	}
+}
@@ -0,0 +1,4 @@
+// Package websockettest serves WebSocket connections from Go handlers,
+// without a network, so code using the websocket package can be tested under
+// Node.js or in a browser. It is only available when compiled for js/wasm.
+package websockettest
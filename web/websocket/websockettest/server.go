@@ -0,0 +1,374 @@
+//go:build js && wasm
+// +build js,wasm
+
+package websockettest
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/abdorrahmani/go-wasm/js"
+	"github.com/abdorrahmani/go-wasm/web/websocket"
+)
+
+// Server is a stand-in WebSocket server. While servers are open, the
+// WebSocket constructor is replaced by one that connects to them; sockets
+// for URLs under no server fail to connect like unreachable ones.
+//
+// The first subprotocol offered by the client is selected. Data sent by the
+// client counts towards its bufferedAmount until the handler reads it, so
+// a handler that reads slowly makes the client see backpressure.
+type Server struct {
+	// URL is the origin of the server, such as ws://websockettest-1.invalid
+	URL     string
+	handler func(*Conn)
+	conns   map[*Conn]struct{}
+}
+
+var (
+	mu      sync.Mutex
+	servers = map[string]*Server{}
+	lastID  int
+	// sockets maps the ids of the fake WebSockets to their connections
+	sockets      = map[int]*Conn{}
+	lastSocketID int
+	// realWebSocket is the constructor replaced while servers are open
+	realWebSocket *js.Value
+	scope         *js.Scope
+)
+
+// fakeWebSocket is the source of the replacement WebSocket constructor. Its
+// methods validate their arguments like browsers do and hand the rest to Go.
+const fakeWebSocket = `const encoder = new TextEncoder();
+class WebSocket extends EventTarget {
+	constructor(url, protocols = []) {
+		super();
+		if (arguments.length === 0) {
+			throw new TypeError("Failed to construct 'WebSocket': 1 argument required, but only 0 present.");
+		}
+		const parsed = new URL(url);
+		if (parsed.protocol !== "ws:" && parsed.protocol !== "wss:") {
+			throw new DOMException("Failed to construct 'WebSocket': The URL's scheme must be either 'ws' or 'wss'.", "SyntaxError");
+		}
+		this.url = parsed.href;
+		this.protocol = "";
+		this.extensions = "";
+		this.readyState = WebSocket.CONNECTING;
+		this.bufferedAmount = 0;
+		this.binaryType = "blob";
+		this.onopen = this.onmessage = this.onerror = this.onclose = null;
+		connect(this, [].concat(protocols).map(String));
+		// Like a network, the server answers in a later task
+		setTimeout(open, 0, this);
+	}
+
+	send(data) {
+		if (this.readyState === WebSocket.CONNECTING) {
+			throw new DOMException("Failed to execute 'send' on 'WebSocket': Still in CONNECTING state.", "InvalidStateError");
+		}
+		if (this.readyState !== WebSocket.OPEN) {
+			return;
+		}
+		if (typeof data === "string") {
+			this.bufferedAmount += encoder.encode(data).length;
+			send(this, data, false);
+			return;
+		}
+		const bytes = ArrayBuffer.isView(data) ?
+			new Uint8Array(data.buffer, data.byteOffset, data.byteLength).slice() : new Uint8Array(data).slice();
+		this.bufferedAmount += bytes.length;
+		send(this, bytes, true);
+	}
+
+	close(code, reason = "") {
+		if (code !== undefined && code !== 1000 && !(code >= 3000 && code <= 4999)) {
+			throw new DOMException("Failed to execute 'close' on 'WebSocket': The close code must be either 1000, or between 3000 and 4999. " + code + " is neither.", "InvalidAccessError");
+		}
+		reason = String(reason);
+		if (encoder.encode(reason).length > 123) {
+			throw new DOMException("Failed to execute 'close' on 'WebSocket': The message must not be greater than 123 bytes.", "SyntaxError");
+		}
+		if (this.readyState === WebSocket.CLOSING || this.readyState === WebSocket.CLOSED) {
+			return;
+		}
+		const failed = this.readyState === WebSocket.CONNECTING;
+		this.readyState = WebSocket.CLOSING;
+		close(this, code ?? 1005, reason, failed);
+	}
+
+	_fire(type, init) {
+		if (type === "message" && typeof init.data !== "string") {
+			init.data = this.binaryType === "arraybuffer" ? init.data.buffer : new Blob([init.data]);
+		}
+		const event = Object.assign(new Event(type), init);
+		this.dispatchEvent(event);
+		if (typeof this["on" + type] === "function") {
+			this["on" + type](event);
+		}
+	}
+}
+for (const [i, name] of ["CONNECTING", "OPEN", "CLOSING", "CLOSED"].entries()) {
+	WebSocket[name] = WebSocket.prototype[name] = i;
+}
+return WebSocket;`
+
+// Ready states of a WebSocket
+const (
+	stateConnecting = iota
+	stateOpen
+	stateClosing
+	stateClosed
+)
+
+// NewServer starts a server that runs handler for every connection, in its
+// own goroutine, once the connection is open
+func NewServer(handler func(*Conn)) *Server {
+	mu.Lock()
+	defer mu.Unlock()
+	lastID++
+	s := &Server{
+		URL:     fmt.Sprintf("ws://websockettest-%d.invalid", lastID),
+		handler: handler,
+		conns:   map[*Conn]struct{}{},
+	}
+	if len(servers) == 0 {
+		install()
+	}
+	servers[s.URL] = s
+	return s
+}
+
+// Close stops the server and drops its open connections. Once all servers
+// are closed, the real WebSocket constructor is restored.
+func (s *Server) Close() {
+	mu.Lock()
+	if servers[s.URL] != s {
+		mu.Unlock()
+		return
+	}
+	delete(servers, s.URL)
+	var conns []*Conn
+	for c := range s.conns {
+		conns = append(conns, c)
+	}
+	mu.Unlock()
+
+	for _, c := range conns {
+		c.Drop()
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(servers) == 0 {
+		js.Global().Set("WebSocket", realWebSocket)
+		scope.Release()
+	}
+}
+
+// install replaces the WebSocket constructor with the fake one
+func install() {
+	realWebSocket = js.Global().Get("WebSocket")
+	scope = js.NewScope()
+	connect := scope.NewCallback(func(args []*js.Value) {
+		ws := args[0]
+		mu.Lock()
+		lastSocketID++
+		c := &Conn{ws: ws, changed: make(chan struct{})}
+		sockets[lastSocketID] = c
+		ws.Set("_id", lastSocketID)
+		origin := js.Global().Get("URL").New(ws.Get("url")).Get("origin").MustString()
+		s := servers[origin]
+		if s != nil {
+			c.server = s
+			s.conns[c] = struct{}{}
+		}
+		mu.Unlock()
+
+		if protocols := args[1].MustArray(); len(protocols) > 0 {
+			c.protocol = protocols[0].MustString()
+		}
+	})
+	openSocket := scope.NewCallback(func(args []*js.Value) {
+		if c := lookup(args[0]); c != nil {
+			go c.open()
+		}
+	})
+	sendMessage := scope.NewCallback(func(args []*js.Value) {
+		c := lookup(args[0])
+		if c == nil {
+			return
+		}
+		m := message{typ: websocket.MessageText}
+		if args[2].MustBool() {
+			m.typ, m.data = websocket.MessageBinary, args[1].MustBytes()
+		} else {
+			m.data = []byte(args[1].MustString())
+		}
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		c.queue = append(c.queue, m)
+		c.broadcast()
+	})
+	closeSocket := scope.NewCallback(func(args []*js.Value) {
+		c := lookup(args[0])
+		if c == nil {
+			return
+		}
+		closeErr := &websocket.CloseError{Code: args[1].MustInt(), Reason: args[2].MustString(), WasClean: true}
+		if args[3].MustBool() {
+			// Closing a socket that is still connecting fails the connection
+			closeErr = &websocket.CloseError{Code: websocket.StatusAbnormalClosure}
+		}
+		go c.closed(closeErr)
+	})
+	constructor := js.Global().Get("Function").New("connect", "open", "send", "close", fakeWebSocket)
+	js.Global().Set("WebSocket", constructor.Invoke(connect, openSocket, sendMessage, closeSocket))
+}
+
+// lookup returns the connection of a fake WebSocket, or nil once it is closed
+func lookup(ws *js.Value) *Conn {
+	mu.Lock()
+	defer mu.Unlock()
+	return sockets[ws.Get("_id").MustInt()]
+}
+
+type message struct {
+	typ  websocket.MessageType
+	data []byte
+}
+
+// Conn is the server side of a connection
+type Conn struct {
+	ws       *js.Value
+	server   *Server
+	protocol string
+
+	mu    sync.Mutex
+	queue []message
+	// changed is closed and replaced whenever the state changes
+	changed chan struct{}
+	// err is set once the connection is closed
+	err error
+}
+
+func (c *Conn) broadcast() {
+	close(c.changed)
+	c.changed = make(chan struct{})
+}
+
+// open opens the connection and runs the handler, or fails it if there is no server
+func (c *Conn) open() {
+	if c.server == nil {
+		c.Drop()
+		return
+	}
+	if c.ws.Get("readyState").MustInt() != stateConnecting {
+		// The client gave up before the server answered
+		return
+	}
+	c.ws.Set("protocol", c.protocol)
+	c.ws.Set("readyState", stateOpen)
+	c.ws.Call("_fire", "open", js.Global().Call("Object"))
+	c.server.handler(c)
+}
+
+// closed finishes the closing handshake and fires the close event
+func (c *Conn) closed(closeErr *websocket.CloseError) {
+	c.mu.Lock()
+	if c.err != nil {
+		c.mu.Unlock()
+		return
+	}
+	c.err = closeErr
+	c.broadcast()
+	c.mu.Unlock()
+
+	mu.Lock()
+	for id, conn := range sockets {
+		if conn == c {
+			delete(sockets, id)
+		}
+	}
+	if c.server != nil {
+		delete(c.server.conns, c)
+	}
+	mu.Unlock()
+
+	if !closeErr.WasClean {
+		c.ws.Call("_fire", "error", js.Global().Call("Object"))
+	}
+	c.ws.Set("readyState", stateClosed)
+	init := js.Global().Call("Object")
+	init.Set("code", closeErr.Code)
+	init.Set("reason", closeErr.Reason)
+	init.Set("wasClean", closeErr.WasClean)
+	c.ws.Call("_fire", "close", init)
+}
+
+// GetProtocol returns the selected subprotocol
+func (c *Conn) GetProtocol() string {
+	return c.protocol
+}
+
+// Read waits for the next message from the client. Once the connection is
+// closed, the messages received before are still returned, followed by a
+// *websocket.CloseError.
+func (c *Conn) Read(ctx context.Context) (websocket.MessageType, []byte, error) {
+	for {
+		c.mu.Lock()
+		if len(c.queue) > 0 {
+			m := c.queue[0]
+			c.queue = c.queue[1:]
+			c.mu.Unlock()
+			// The data has left the send buffer of the client
+			c.ws.Set("bufferedAmount", c.ws.Get("bufferedAmount").MustInt()-len(m.data))
+			return m.typ, m.data, nil
+		}
+		err, changed := c.err, c.changed
+		c.mu.Unlock()
+		if err != nil {
+			return 0, nil, err
+		}
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return 0, nil, ctx.Err()
+		}
+	}
+}
+
+// Write sends a message to the client
+func (c *Conn) Write(ctx context.Context, typ websocket.MessageType, data []byte) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	c.mu.Lock()
+	err := c.err
+	c.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	init := js.Global().Call("Object")
+	if typ == websocket.MessageBinary {
+		init.Set("data", js.FromBytes(data))
+	} else {
+		init.Set("data", string(data))
+	}
+	c.ws.Call("_fire", "message", init)
+	return nil
+}
+
+// Close closes the connection cleanly with a status code and reason
+func (c *Conn) Close(code int, reason string) {
+	if c.ws.Get("readyState").MustInt() == stateOpen {
+		c.ws.Set("readyState", stateClosing)
+	}
+	c.closed(&websocket.CloseError{Code: code, Reason: reason, WasClean: true})
+}
+
+// Drop closes the connection abruptly, as if the network failed. The client
+// sees an error event and a close event with StatusAbnormalClosure.
+func (c *Conn) Drop() {
+	c.closed(&websocket.CloseError{Code: websocket.StatusAbnormalClosure})
+}